@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -28,18 +30,44 @@ Flags:`
 
 	helpUsage    = "show the help message"
 	versionUsage = "print the version for this build"
+	evalUsage    = "evaluate the passed expression and print its result"
+	checkUsage   = "check the input script for syntax errors without running it"
+	jsonUsage    = "report the errors of the input script as json"
+	fmtUsage     = "print the passed script in its canonical form, dropping comments"
+	writeUsage   = "write the result of -fmt back to the script instead of stdout, refusing scripts with comments"
+	verboseUsage = "trace each top-level statement of the script and its result to stderr"
+	colorUsage   = "color the prompts of the interactive-mode, when running in a terminal"
 	embedUsage   = `embed the input script into an executable
 containing the interpreter runtime, instead 
 of running the script; this requires a local 
 go installation`
 )
 
+// evalTemplate wraps the expressions passed with -e into a script printing
+// their value, if any, or failing if they evaluate to an error.
+const evalTemplate = `var result = try (%s)
+if type(result) != "Null" { print(result) }`
+
 func main() {
 	fs := flag.NewFlagSet("harlock", flag.ExitOnError)
 	help := fs.Bool("help", false, helpUsage)
 	version := fs.Bool("version", false, versionUsage)
 	embed := fs.String("embed", "", embedUsage)
 
+	var eval string
+	fs.StringVar(&eval, "eval", "", evalUsage)
+	fs.StringVar(&eval, "e", "", evalUsage)
+
+	var check bool
+	fs.BoolVar(&check, "check", false, checkUsage)
+	fs.BoolVar(&check, "c", false, checkUsage)
+
+	jsonErrors := fs.Bool("json", false, jsonUsage)
+	format := fs.String("fmt", "", fmtUsage)
+	write := fs.Bool("w", false, writeUsage)
+	color := fs.Bool("color", false, colorUsage)
+	verbose := fs.Bool("v", false, verboseUsage)
+
 	if err := fs.Parse(os.Args[1:]); err != nil {
 		panic(err)
 	}
@@ -58,16 +86,95 @@ func main() {
 			_, _ = io.WriteString(os.Stderr, err.Error()+"\n")
 			return
 		}
+	case eval != "":
+		errs := evalExpression(eval, fs.Args()...)
+		if errs != nil {
+			for _, err := range errs {
+				_, _ = io.WriteString(os.Stderr, fmt.Sprintf("%s\n", err))
+			}
+			os.Exit(1)
+		}
+	case *format != "":
+		src, err := os.ReadFile(*format)
+		if err != nil {
+			_, _ = io.WriteString(os.Stderr, err.Error()+"\n")
+			os.Exit(1)
+		}
+
+		// scripts are overwritten only if formatting them loses nothing
+		formatScript := interpreter.Format
+		if *write {
+			formatScript = interpreter.FormatLossless
+		}
+
+		var out bytes.Buffer
+		errs := formatScript(bytes.NewReader(src), &out)
+		if errs != nil {
+			for _, err := range errs {
+				_, _ = io.WriteString(os.Stderr, fmt.Sprintf("%s\n", err))
+			}
+			os.Exit(1)
+		}
+
+		if !*write {
+			_, _ = os.Stdout.Write(out.Bytes())
+			return
+		}
+
+		info, err := os.Stat(*format)
+		if err == nil {
+			err = os.WriteFile(*format, out.Bytes(), info.Mode().Perm())
+		}
+		if err != nil {
+			_, _ = io.WriteString(os.Stderr, err.Error()+"\n")
+			os.Exit(1)
+		}
+	case check:
+		if len(fs.Args()) == 0 {
+			_, _ = io.WriteString(os.Stderr, "check: no input script passed\n")
+			os.Exit(1)
+		}
+
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			_, _ = io.WriteString(os.Stderr, err.Error()+"\n")
+			os.Exit(1)
+		}
+
+		errs := interpreter.Check(f)
+		if errs != nil {
+			for _, err := range errs {
+				_, _ = io.WriteString(os.Stderr, fmt.Sprintf("%s\n", err))
+			}
+			os.Exit(1)
+		}
 	case len(fs.Args()) == 0:
 		fmt.Printf("Harlock %s - %s on %s\n", interpreter.Version, runtime.GOARCH, runtime.GOOS)
-		repl.Start(os.Stdin, os.Stdout)
+		config := repl.ConfigFromEnv()
+		config.Color = config.Color || *color
+		repl.StartWithConfig(os.Stdin, os.Stdout, config)
 	case len(fs.Args()) > 0:
 		f, err := os.Open(fs.Arg(0))
 		if err != nil {
 			_, _ = io.WriteString(os.Stderr, err.Error()+"\n")
+			os.Exit(1)
+		}
+
+		if *jsonErrors {
+			infos := interpreter.ExecStructured(f, fs.Args()...)
+			if infos != nil {
+				_ = json.NewEncoder(os.Stderr).Encode(infos)
+				os.Exit(1)
+			}
+			return
 		}
 
-		errs := interpreter.Exec(f, os.Stderr, fs.Args()...)
+		exec := interpreter.Exec
+		if *verbose {
+			exec = interpreter.ExecWithTrace
+		}
+
+		errs := exec(f, os.Stderr, fs.Args()...)
 		if errs != nil {
 			for _, err := range errs {
 				_, _ = io.WriteString(os.Stderr, fmt.Sprintf("%s\n", err))
@@ -76,3 +183,9 @@ func main() {
 		}
 	}
 }
+
+// evalExpression executes the passed expression, printing its value.
+func evalExpression(expr string, args ...string) []string {
+	script := fmt.Sprintf(evalTemplate, expr)
+	return interpreter.Exec(bytes.NewReader([]byte(script)), os.Stderr, args...)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io"
+	"os"
+	"testing"
+)
+
+func TestEvalExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		isErr    bool
+	}{
+		{`1 + 2`, "3\n", false},
+		{`hex(255)`, "0xff\n", false},
+		{`[1, 2, 3].map(fun(x) { ret x * 2 })`, "[2, 4, 6]\n", false},
+		{`print("side effect")`, "side effect\n", false},
+		{`1 / 0`, "", true},
+		{`from_hex("jkjk")`, "", true},
+		{`var = 12`, "", true},
+	}
+
+	for _, testCase := range tests {
+		var errs []string
+		out := captureStdout(t, func() {
+			errs = evalExpression(testCase.input)
+		})
+
+		if testCase.isErr != (errs != nil) {
+			t.Errorf("%s: expected error = %t, got %v", testCase.input, testCase.isErr, errs)
+		}
+
+		if out != testCase.expected {
+			t.Errorf("%s: expected output %q, got %q", testCase.input, testCase.expected, out)
+		}
+	}
+}
+
+// captureStdout returns what the passed function writes to the standard output.
+func captureStdout(t *testing.T, fn func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("cannot create a pipe: %v", err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	_ = w.Close()
+
+	out, _ := io.ReadAll(r)
+	_ = r.Close()
+	return string(out)
+}
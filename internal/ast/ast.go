@@ -2,7 +2,9 @@ package ast
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/Abathargh/harlock/internal/token"
 )
@@ -39,11 +41,7 @@ func (program *Program) TokenLiteral() string {
 }
 
 func (program *Program) String() string {
-	var buf strings.Builder
-	for _, statement := range program.Statements {
-		buf.WriteString(statement.String())
-	}
-	return buf.String()
+	return joinStatements(program.Statements)
 }
 
 type Identifier struct {
@@ -101,9 +99,10 @@ func (rs *ReturnStatement) TokenLiteral() string {
 
 func (rs *ReturnStatement) String() string {
 	var buf strings.Builder
-	buf.WriteString(rs.TokenLiteral() + " ")
+	buf.WriteString(rs.TokenLiteral())
 
 	if rs.ReturnValue != nil {
+		buf.WriteString(" ")
 		buf.WriteString(rs.ReturnValue.String())
 	}
 	return buf.String()
@@ -206,6 +205,21 @@ func (b *Boolean) String() string {
 	return b.Token.Literal
 }
 
+type Null struct {
+	LineMetadata
+	Token token.Token
+}
+
+func (n *Null) expressionNode() {}
+
+func (n *Null) TokenLiteral() string {
+	return n.Token.Literal
+}
+
+func (n *Null) String() string {
+	return n.Token.Literal
+}
+
 type IfExpression struct {
 	LineMetadata
 	Token       token.Token
@@ -222,19 +236,64 @@ func (ife *IfExpression) TokenLiteral() string {
 
 func (ife *IfExpression) String() string {
 	var buf strings.Builder
-	buf.WriteString("if")
+	buf.WriteString("if ")
 	buf.WriteString(ife.Condition.String())
-	buf.WriteString(" {\n")
-	buf.WriteString(ife.Consequence.String())
-	buf.WriteString("\n}")
+	buf.WriteString(" ")
+	buf.WriteString(braced(ife.Consequence))
 
 	if ife.Alternative != nil {
-		buf.WriteString("else ")
-		buf.WriteString(ife.Alternative.String())
+		buf.WriteString(" else ")
+		buf.WriteString(braced(ife.Alternative))
 	}
 	return buf.String()
 }
 
+type DoWhileExpression struct {
+	LineMetadata
+	Token     token.Token
+	Label     *Identifier
+	Body      *BlockStatement
+	Condition Expression
+}
+
+func (dwe *DoWhileExpression) expressionNode() {}
+
+func (dwe *DoWhileExpression) TokenLiteral() string {
+	return dwe.Token.Literal
+}
+
+func (dwe *DoWhileExpression) String() string {
+	var buf strings.Builder
+	if dwe.Label != nil {
+		buf.WriteString(dwe.Label.String())
+		buf.WriteString(": ")
+	}
+	buf.WriteString("do ")
+	buf.WriteString(braced(dwe.Body))
+	buf.WriteString(" while ")
+	buf.WriteString(dwe.Condition.String())
+	return buf.String()
+}
+
+type BreakStatement struct {
+	LineMetadata
+	Token token.Token
+	Label *Identifier
+}
+
+func (bs *BreakStatement) statementNode() {}
+
+func (bs *BreakStatement) TokenLiteral() string {
+	return bs.Token.Literal
+}
+
+func (bs *BreakStatement) String() string {
+	if bs.Label == nil {
+		return "break"
+	}
+	return "break " + bs.Label.String()
+}
+
 type BlockStatement struct {
 	LineMetadata
 	Token      token.Token
@@ -248,11 +307,7 @@ func (bs *BlockStatement) TokenLiteral() string {
 }
 
 func (bs *BlockStatement) String() string {
-	var buf strings.Builder
-	for _, statement := range bs.Statements {
-		buf.WriteString(statement.String())
-	}
-	return buf.String()
+	return joinStatements(bs.Statements)
 }
 
 type FunctionLiteral struct {
@@ -279,8 +334,8 @@ func (fl *FunctionLiteral) String() string {
 	buf.WriteString(fl.TokenLiteral())
 	buf.WriteString("(")
 	buf.WriteString(strings.Join(parameters, ", "))
-	buf.WriteString(")")
-	buf.WriteString(fl.Body.String())
+	buf.WriteString(") ")
+	buf.WriteString(braced(fl.Body))
 
 	return buf.String()
 }
@@ -342,7 +397,39 @@ func (sl *StringLiteral) TokenLiteral() string {
 }
 
 func (sl *StringLiteral) String() string {
-	return sl.Token.Literal
+	return Quote(sl.Value)
+}
+
+// InterpolatedString is a string embedding ${expr} expressions: its
+// parts alternate string literals and embedded expressions, starting and
+// ending with a (possibly empty) string literal.
+type InterpolatedString struct {
+	LineMetadata
+	Token token.Token
+	Parts []Expression
+}
+
+func (is *InterpolatedString) expressionNode() {}
+
+func (is *InterpolatedString) TokenLiteral() string {
+	return is.Token.Literal
+}
+
+func (is *InterpolatedString) String() string {
+	var buf strings.Builder
+	buf.WriteString(`"`)
+	for idx, part := range is.Parts {
+		if idx%2 == 0 {
+			quoted := part.String()
+			buf.WriteString(quoted[1 : len(quoted)-1])
+			continue
+		}
+		buf.WriteString("${")
+		buf.WriteString(part.String())
+		buf.WriteString("}")
+	}
+	buf.WriteString(`"`)
+	return buf.String()
 }
 
 type ArrayLiteral struct {
@@ -392,6 +479,31 @@ func (ie *IndexExpression) String() string {
 	return buf.String()
 }
 
+type SliceExpression struct {
+	LineMetadata
+	Token token.Token
+	Left  Expression
+	Start Expression
+	End   Expression
+}
+
+func (se *SliceExpression) expressionNode() {}
+
+func (se *SliceExpression) TokenLiteral() string {
+	return se.Token.Literal
+}
+
+func (se *SliceExpression) String() string {
+	var buf strings.Builder
+	buf.WriteString(se.Left.String())
+	buf.WriteString("[")
+	buf.WriteString(se.Start.String())
+	buf.WriteString(":")
+	buf.WriteString(se.End.String())
+	buf.WriteString("]")
+	return buf.String()
+}
+
 type MapLiteral struct {
 	LineMetadata
 	Token    token.Token
@@ -410,6 +522,7 @@ func (hl *MapLiteral) String() string {
 	for key, val := range hl.Mappings {
 		mappings = append(mappings, fmt.Sprintf("%s: %s", key.String(), val.String()))
 	}
+	sort.Strings(mappings)
 
 	buf.WriteString("{")
 	buf.WriteString(strings.Join(mappings, ", "))
@@ -461,3 +574,58 @@ func (te *TryExpression) String() string {
 	buf.WriteString(te.Expression.String())
 	return buf.String()
 }
+
+// joinStatements returns the representation of the passed
+// statements, one per line.
+func joinStatements(statements []Statement) string {
+	lines := make([]string, len(statements))
+	for idx, statement := range statements {
+		lines[idx] = statement.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// braced returns the representation of the passed block enclosed in
+// braces, with each of its lines indented by a tab.
+func braced(block *BlockStatement) string {
+	if len(block.Statements) == 0 {
+		return "{}"
+	}
+
+	lines := strings.Split(block.String(), "\n")
+	return "{\n\t" + strings.Join(lines, "\n\t") + "\n}"
+}
+
+// Quote returns the double-quoted representation of the passed string,
+// escaping the characters that could not appear verbatim in a harlock
+// string literal, so that it can be read back by the lexer.
+func Quote(str string) string {
+	var buf strings.Builder
+	buf.WriteString(`"`)
+	for _, char := range str {
+		switch {
+		case char == '\\':
+			buf.WriteString(`\\`)
+		case char == '\n':
+			buf.WriteString(`\n`)
+		case char == '\t':
+			buf.WriteString(`\t`)
+		case char == '\r':
+			buf.WriteString(`\r`)
+		case char == '"':
+			buf.WriteString(`\x22`)
+		case char == '$':
+			buf.WriteString(`\$`)
+		case unicode.IsPrint(char):
+			buf.WriteRune(char)
+		case char <= 0xff:
+			buf.WriteString(fmt.Sprintf(`\x%02x`, char))
+		case char <= 0xffff:
+			buf.WriteString(fmt.Sprintf(`\u%04x`, char))
+		default:
+			buf.WriteRune(char)
+		}
+	}
+	buf.WriteString(`"`)
+	return buf.String()
+}
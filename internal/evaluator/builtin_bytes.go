@@ -1,24 +1,23 @@
 package evaluator
 
-import "github.com/Abathargh/harlock/internal/object"
+import (
+	"bytes"
+	"io"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
 
 func bytesBuiltinWriteAt(this object.Object, args ...object.Object) object.Object {
 	bytesThis := this.(*object.BytesFile)
 
 	position := args[0].(*object.Integer)
-	data := args[1].(*object.Array)
 	if position.Value < 0 {
 		return newBytesError("position must be a positive integer")
 	}
 
-	byteArr := make([]byte, len(data.Elements))
-	for idx, elem := range data.Elements {
-		intElem, isInt := elem.(*object.Integer)
-		if !isInt || intElem.Value > maxByte || intElem.Value < 0 {
-			return newTypeError("data must be an array of 1 byte positive integers "+
-				"(data[%d] = %s does not follow this constraint)", idx, elem.Inspect())
-		}
-		byteArr[idx] = byte(intElem.Value)
+	byteArr, typeErr := writtenBytes(args[1])
+	if typeErr != nil {
+		return typeErr
 	}
 
 	err := bytesThis.Bytes.WriteAt(int(position.Value), byteArr)
@@ -28,6 +27,211 @@ func bytesBuiltinWriteAt(this object.Object, args ...object.Object) object.Objec
 	return nil
 }
 
+func bytesBuiltinCopyRegion(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	src := args[0].(*object.Integer)
+	dst := args[1].(*object.Integer)
+	size := args[2].(*object.Integer)
+	if src.Value < 0 || dst.Value < 0 || size.Value < 0 {
+		return newBytesError("positions and size must be positive integers")
+	}
+
+	// ReadAt returns a copy of the region, so that overlapping
+	// regions are not corrupted while writing
+	region, err := bytesThis.Bytes.ReadAt(int(src.Value), int(size.Value))
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+
+	err = bytesThis.Bytes.WriteAt(int(dst.Value), region)
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+	return nil
+}
+
+func bytesBuiltinFindAll(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+	pattern := args[0].(*object.Array)
+
+	patternArr := make([]byte, len(pattern.Elements))
+	if err := intArrayToBytes(pattern, patternArr); err != nil {
+		return err
+	}
+
+	offsets, err := bytesThis.Bytes.FindAll(patternArr)
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+
+	retVal := &object.Array{Elements: make([]object.Object, len(offsets))}
+	for idx, offset := range offsets {
+		retVal.Elements[idx] = &object.Integer{Value: int64(offset)}
+	}
+	return retVal
+}
+
+func bytesBuiltinScanStrings(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	minLen := args[0].(*object.Integer)
+	if minLen.Value < 1 {
+		return newBytesError("the minimum length must be a positive integer")
+	}
+
+	runs := printableRuns(bytesThis.AsBytes(), 0, int(minLen.Value))
+	if runs == nil {
+		runs = []object.Object{}
+	}
+	return &object.Array{Elements: runs}
+}
+
+func bytesBuiltinReplace(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+	old := args[0].(*object.Array)
+	replacement := args[1].(*object.Array)
+
+	oldArr := make([]byte, len(old.Elements))
+	if err := intArrayToBytes(old, oldArr); err != nil {
+		return err
+	}
+
+	replacementArr := make([]byte, len(replacement.Elements))
+	if err := intArrayToBytes(replacement, replacementArr); err != nil {
+		return err
+	}
+
+	count, err := bytesThis.Bytes.Replace(oldArr, replacementArr)
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+	return &object.Integer{Value: int64(count)}
+}
+
+func bytesBuiltinFillPattern(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	position := args[0].(*object.Integer)
+	size := args[1].(*object.Integer)
+	pattern := args[2].(*object.Array)
+	if position.Value < 0 || size.Value < 0 {
+		return newBytesError("position and size must be positive integers")
+	}
+
+	if len(pattern.Elements) == 0 {
+		return newBytesError("the fill pattern cannot be empty")
+	}
+
+	if err := checkBytesRegion(bytesThis, position.Value, size.Value); err != nil {
+		return err
+	}
+
+	filled, typeErr := patternBytes(int(size.Value), pattern)
+	if typeErr != nil {
+		return typeErr
+	}
+
+	err := bytesThis.Bytes.WriteAt(int(position.Value), filled)
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+	return nil
+}
+
+func bytesBuiltinApplyPatch(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	edits, typeErr := patchEdits(args[0].(*object.Array))
+	if typeErr != nil {
+		return typeErr
+	}
+
+	// all the edits are checked before writing, so that
+	// an invalid one leaves the file untouched
+	for idx, edit := range edits {
+		if edit.offset+int64(len(edit.data)) > int64(bytesThis.Bytes.Len()) {
+			return newBytesError("patch[%d] writes out of the bounds of the file", idx)
+		}
+	}
+
+	for _, edit := range edits {
+		if err := bytesThis.Bytes.WriteAt(int(edit.offset), edit.data); err != nil {
+			return newBytesError("%s", err)
+		}
+	}
+	return nil
+}
+
+func bytesBuiltinClone(this object.Object, _ ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+	size := int64(len(bytesThis.AsBytes()))
+	clone := object.NewBytesFile(bytesThis.Name(), bytesThis.Perms(), size, bytesThis.Bytes.Clone())
+	clone.SetReadOnly(bytesThis.ReadOnly())
+	return clone
+}
+
+func bytesBuiltinView(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	start := args[0].(*object.Integer)
+	end := args[1].(*object.Integer)
+	if start.Value < 0 || end.Value < 0 {
+		return newBytesError("start and end must be positive integers")
+	}
+
+	view, err := bytesThis.Bytes.View(int(start.Value), int(end.Value))
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+
+	// views share their storage with the parent file, so they can
+	// only be used to read from it
+	size := end.Value - start.Value
+	viewFile := object.NewBytesFile(bytesThis.Name(), bytesThis.Perms(), size, view)
+	viewFile.SetReadOnly(true)
+	return viewFile
+}
+
+func bytesBuiltinInsertAt(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	position := args[0].(*object.Integer)
+	data := args[1].(*object.Array)
+	if position.Value < 0 {
+		return newBytesError("position must be a positive integer")
+	}
+
+	dataArr := make([]byte, len(data.Elements))
+	if err := intArrayToBytes(data, dataArr); err != nil {
+		return err
+	}
+
+	err := bytesThis.Bytes.InsertAt(int(position.Value), dataArr)
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+	bytesThis.SetSize(int64(bytesThis.Bytes.Len()))
+	return nil
+}
+
+func bytesBuiltinRemoveRange(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	start := args[0].(*object.Integer)
+	size := args[1].(*object.Integer)
+	if start.Value < 0 || size.Value < 0 {
+		return newBytesError("start and size must be positive integers")
+	}
+
+	err := bytesThis.Bytes.RemoveRange(int(start.Value), int(size.Value))
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+	bytesThis.SetSize(int64(bytesThis.Bytes.Len()))
+	return nil
+}
+
 func bytesBuiltinReadAt(this object.Object, args ...object.Object) object.Object {
 	bytesThis := this.(*object.BytesFile)
 
@@ -47,3 +251,213 @@ func bytesBuiltinReadAt(this object.Object, args ...object.Object) object.Object
 	}
 	return retVal
 }
+
+func bytesBuiltinRegionHash(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	position := args[0].(*object.Integer)
+	size := args[1].(*object.Integer)
+	algo := args[2].(*object.String)
+	if position.Value < 0 || size.Value < 0 {
+		return newBytesError("position and size must be positive integers")
+	}
+
+	hasher := newHasher(algo.Value)
+	if hasher == nil {
+		return newTypeError("unsupported hash function %s", algo.Value)
+	}
+
+	// a view over the region avoids copying it before hashing
+	region, err := bytesThis.Bytes.View(int(position.Value), int(position.Value+size.Value))
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+
+	if _, err := io.Copy(hasher, region.Reader()); err != nil {
+		return newBytesError("%s", err)
+	}
+	return bytestoIntarray(hasher.Sum(nil))
+}
+
+func bytesBuiltinEntropy(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+
+	position := args[0].(*object.Integer)
+	size := args[1].(*object.Integer)
+	if position.Value < 0 || size.Value < 0 {
+		return newBytesError("position and size must be positive integers")
+	}
+
+	region, err := bytesThis.Bytes.View(int(position.Value), int(position.Value+size.Value))
+	if err != nil {
+		return newBytesError("%s", err)
+	}
+
+	var histogram byteHistogram
+	if _, err := io.Copy(&histogram, region.Reader()); err != nil {
+		return newBytesError("%s", err)
+	}
+	return &object.Integer{Value: histogram.Entropy()}
+}
+
+func bytesBuiltinEquals(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+	other := args[0].(*object.BytesFile)
+	return getBoolReference(bytes.Equal(bytesThis.AsBytes(), other.AsBytes()))
+}
+
+func bytesBuiltinMap(this object.Object, args ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+	fun := args[0]
+
+	switch callable := fun.(type) {
+	case *object.Function:
+		if len(callable.Parameters) != 1 {
+			return newTypeError("the map callback requires exactly one argument (a one-args function(x) -> x)")
+		}
+	case *object.Builtin:
+		if len(callable.GetBuiltinArgTypes()) != 1 {
+			return newTypeError("the map callback requires exactly one argument (a one-args function(x) -> x)")
+		}
+	}
+
+	// the whole buffer is computed before writing it back, so that
+	// the file is left untouched if the callback fails for any byte
+	data := bytesThis.AsBytes()
+	for idx, elem := range data {
+		res := callFunction("<anonymous callback>", fun,
+			[]object.Object{&object.Integer{Value: int64(elem)}}, noLineInfo)
+		if isError(res) || isRuntimeError(res) {
+			return res
+		}
+
+		intRes, isInt := res.(*object.Integer)
+		if !isInt || intRes.Value > maxByte || intRes.Value < 0 {
+			return newTypeError("map requires a fun returning 1 byte positive integers "+
+				"(the result for byte %d does not follow this constraint)", idx)
+		}
+		data[idx] = byte(intRes.Value)
+	}
+
+	if err := bytesThis.Bytes.WriteAt(0, data); err != nil {
+		return newBytesError("%s", err)
+	}
+	return nil
+}
+
+func bytesBuiltinSum(this object.Object, args ...object.Object) object.Object {
+	sum, err := bytesRegionSum(this.(*object.BytesFile), args...)
+	if err != nil {
+		return err
+	}
+	return &object.Integer{Value: int64(sum)}
+}
+
+func bytesBuiltinComplementChecksum(this object.Object, args ...object.Object) object.Object {
+	sum, err := bytesRegionSum(this.(*object.BytesFile), args...)
+	if err != nil {
+		return err
+	}
+	width := args[2].(*object.Integer).Value
+	return &object.Integer{Value: int64(-sum & widthMask(width))}
+}
+
+// bytesRegionSum adds up the bytes of the [start, start+size) region
+// in a width-bits accumulator, discarding the overflowing bits.
+func bytesRegionSum(bytesThis *object.BytesFile, args ...object.Object) (uint64, *object.RuntimeError) {
+	start := args[0].(*object.Integer)
+	size := args[1].(*object.Integer)
+	width := args[2].(*object.Integer)
+	if start.Value < 0 || size.Value < 0 {
+		return 0, newTypeError("start and size must be positive integers")
+	}
+
+	switch width.Value {
+	case 8, 16, 32:
+	default:
+		return 0, newTypeError("the width must be one of 8, 16 or 32 bits, got %d", width.Value)
+	}
+
+	data, err := bytesThis.Bytes.ReadAt(int(start.Value), int(size.Value))
+	if err != nil {
+		return 0, newBytesError("%s", err)
+	}
+
+	sum := uint64(0)
+	for _, b := range data {
+		sum += uint64(b)
+	}
+	return sum & widthMask(width.Value), nil
+}
+
+func widthMask(width int64) uint64 {
+	return (uint64(1) << width) - 1
+}
+
+func bytesBuiltinCursor(this object.Object, _ ...object.Object) object.Object {
+	bytesThis := this.(*object.BytesFile)
+	return &object.BytesCursor{File: bytesThis}
+}
+
+func cursorBuiltinReadU8(this object.Object, _ ...object.Object) object.Object {
+	cursor := this.(*object.BytesCursor)
+	readData := cursorRead(cursor, 1, bytesBuiltinReadAt, &object.Integer{Value: 1})
+	if byteArr, isArray := readData.(*object.Array); isArray {
+		return byteArr.Elements[0]
+	}
+	return readData
+}
+
+func cursorBuiltinReadU16(this object.Object, args ...object.Object) object.Object {
+	cursor := this.(*object.BytesCursor)
+	return cursorRead(cursor, 2, readUint(2, bytesBuiltinReadAt), args[0])
+}
+
+func cursorBuiltinReadBytes(this object.Object, args ...object.Object) object.Object {
+	cursor := this.(*object.BytesCursor)
+	size := args[0].(*object.Integer)
+	if size.Value > int64(cursor.File.Bytes.Len())-cursor.Position {
+		return newBytesError("cannot read %d bytes from %d, out of the bounds of the file",
+			size.Value, cursor.Position)
+	}
+	return cursorRead(cursor, size.Value, bytesBuiltinReadAt, size)
+}
+
+func cursorBuiltinSkip(this object.Object, args ...object.Object) object.Object {
+	cursor := this.(*object.BytesCursor)
+	offset := args[0].(*object.Integer)
+	return cursorMove(cursor, cursor.Position+offset.Value)
+}
+
+func cursorBuiltinSeek(this object.Object, args ...object.Object) object.Object {
+	cursor := this.(*object.BytesCursor)
+	position := args[0].(*object.Integer)
+	return cursorMove(cursor, position.Value)
+}
+
+func cursorBuiltinTell(this object.Object, _ ...object.Object) object.Object {
+	cursor := this.(*object.BytesCursor)
+	return &object.Integer{Value: cursor.Position}
+}
+
+// cursorRead calls the passed read method of the file underlying the cursor
+// with its position followed by args, advancing the cursor by size bytes
+// if the read succeeds.
+func cursorRead(cursor *object.BytesCursor, size int64, read object.MethodFunction, args ...object.Object) object.Object {
+	position := &object.Integer{Value: cursor.Position}
+	readData := read(cursor.File, append([]object.Object{position}, args...)...)
+	if !isRuntimeError(readData) {
+		cursor.Position += size
+	}
+	return readData
+}
+
+// cursorMove moves the cursor to the passed position, which can
+// go from the start of the underlying file up to its end.
+func cursorMove(cursor *object.BytesCursor, position int64) object.Object {
+	if position < 0 || position > int64(cursor.File.Bytes.Len()) {
+		return newBytesError("cannot move the cursor to %d, out of the bounds of the file", position)
+	}
+	cursor.Position = position
+	return nil
+}
@@ -23,6 +23,18 @@ func elfBuiltinSections(this object.Object, _ ...object.Object) object.Object {
 	return retVal
 }
 
+func elfBuiltinClone(this object.Object, _ ...object.Object) object.Object {
+	elfThis := this.(*object.ElfFile)
+	file, err := elfThis.File.Clone()
+	if err != nil {
+		return newElfError("%s", err)
+	}
+
+	clone := object.NewElfFile(elfThis.Name(), elfThis.Perms(), file)
+	clone.SetReadOnly(elfThis.ReadOnly())
+	return clone
+}
+
 func elfBuiltinWriteSection(this object.Object, args ...object.Object) object.Object {
 	elfThis := this.(*object.ElfFile)
 	section := args[0].(*object.String)
@@ -90,3 +102,67 @@ func elfBuiltinSectionSize(this object.Object, args ...object.Object) object.Obj
 	retVal := &object.Integer{Value: int64(addr)}
 	return retVal
 }
+
+func elfBuiltinAddSection(this object.Object, args ...object.Object) object.Object {
+	elfThis := this.(*object.ElfFile)
+	section := args[0].(*object.String)
+	data := args[1].(*object.Array)
+
+	flags := args[2].(*object.Integer)
+	if flags.Value < 0 {
+		return newTypeError("the flags must be a positive integer")
+	}
+
+	byteArr := make([]byte, len(data.Elements))
+	if err := intArrayToBytes(data, byteArr); err != nil {
+		return err
+	}
+
+	if err := elfThis.File.AddSection(section.Value, byteArr, uint64(flags.Value)); err != nil {
+		return newElfError("%s", err)
+	}
+	return nil
+}
+
+func elfBuiltinRelocations(this object.Object, args ...object.Object) object.Object {
+	elfThis := this.(*object.ElfFile)
+	section := args[0].(*object.String)
+
+	relocations, err := elfThis.File.Relocations(section.Value)
+	if err != nil {
+		return newElfError("%s", err)
+	}
+
+	retVal := &object.Array{Elements: make([]object.Object, len(relocations))}
+	for idx, relocation := range relocations {
+		entry := &object.Map{Mappings: make(map[object.HashKey]object.HashPair)}
+		_ = setMapping(entry, &object.String{Value: "offset"}, &object.Integer{Value: int64(relocation.Offset)})
+		_ = setMapping(entry, &object.String{Value: "symbol"}, &object.String{Value: relocation.Symbol})
+		_ = setMapping(entry, &object.String{Value: "type"}, &object.Integer{Value: int64(relocation.Type)})
+		retVal.Elements[idx] = entry
+	}
+	return retVal
+}
+
+func elfBuiltinDynamic(this object.Object, _ ...object.Object) object.Object {
+	elfThis := this.(*object.ElfFile)
+
+	entries, err := elfThis.File.Dynamic()
+	if err != nil {
+		return newElfError("%s", err)
+	}
+
+	retVal := &object.Array{Elements: make([]object.Object, len(entries))}
+	for idx, dynEntry := range entries {
+		var value object.Object = &object.Integer{Value: int64(dynEntry.Value)}
+		if dynEntry.Name != "" {
+			value = &object.String{Value: dynEntry.Name}
+		}
+
+		entry := &object.Map{Mappings: make(map[object.HashKey]object.HashPair)}
+		_ = setMapping(entry, &object.String{Value: "tag"}, &object.String{Value: dynEntry.Tag.String()})
+		_ = setMapping(entry, &object.String{Value: "value"}, value)
+		retVal.Elements[idx] = entry
+	}
+	return retVal
+}
@@ -0,0 +1,93 @@
+package evaluator
+
+import (
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+func machoBuiltinHasSection(this object.Object, args ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	section := args[0].(*object.String)
+	if machoThis.File.HasSection(section.Value) {
+		return TRUE
+	}
+	return FALSE
+}
+
+func machoBuiltinSections(this object.Object, _ ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	return stringsToArray(machoThis.File.Sections())
+}
+
+func machoBuiltinSegments(this object.Object, _ ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	return stringsToArray(machoThis.File.Segments())
+}
+
+func machoBuiltinWriteSection(this object.Object, args ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	section := args[0].(*object.String)
+	data := args[1].(*object.Array)
+
+	offset := args[2].(*object.Integer)
+	if offset.Value < 0 {
+		return newTypeError("the offset must be a positive integer")
+	}
+
+	byteArr := make([]byte, len(data.Elements))
+	if err := intArrayToBytes(data, byteArr); err != nil {
+		return err
+	}
+
+	if err := machoThis.File.WriteSection(section.Value, byteArr, uint64(offset.Value)); err != nil {
+		return newMachoError("%s", err)
+	}
+	return nil
+}
+
+func machoBuiltinReadSection(this object.Object, args ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	section := args[0].(*object.String)
+
+	readData, err := machoThis.File.ReadSection(section.Value)
+	if err != nil {
+		return newMachoError("%s", err)
+	}
+
+	retVal := &object.Array{Elements: make([]object.Object, len(readData))}
+	for idx, readByte := range readData {
+		retVal.Elements[idx] = &object.Integer{Value: int64(readByte)}
+	}
+	return retVal
+}
+
+func machoBuiltinSectionAddress(this object.Object, args ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	return machoUintResult(machoThis.File.SectionAddress(args[0].(*object.String).Value))
+}
+
+func machoBuiltinSectionSize(this object.Object, args ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	return machoUintResult(machoThis.File.SectionSize(args[0].(*object.String).Value))
+}
+
+func machoBuiltinSegmentAddress(this object.Object, args ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	return machoUintResult(machoThis.File.SegmentAddress(args[0].(*object.String).Value))
+}
+
+func machoBuiltinSegmentSize(this object.Object, args ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	return machoUintResult(machoThis.File.SegmentSize(args[0].(*object.String).Value))
+}
+
+func machoBuiltinEntry(this object.Object, _ ...object.Object) object.Object {
+	machoThis := this.(*object.MachoFile)
+	return machoUintResult(machoThis.File.Entry())
+}
+
+func machoUintResult(value uint64, err error) object.Object {
+	if err != nil {
+		return newMachoError("%s", err)
+	}
+	return &object.Integer{Value: int64(value)}
+}
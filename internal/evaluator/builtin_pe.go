@@ -0,0 +1,83 @@
+package evaluator
+
+import (
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+func peBuiltinHasSection(this object.Object, args ...object.Object) object.Object {
+	peThis := this.(*object.PeFile)
+	section := args[0].(*object.String)
+	if peThis.File.HasSection(section.Value) {
+		return TRUE
+	}
+	return FALSE
+}
+
+func peBuiltinSections(this object.Object, _ ...object.Object) object.Object {
+	peThis := this.(*object.PeFile)
+	sections := peThis.File.Sections()
+	retVal := &object.Array{Elements: make([]object.Object, len(sections))}
+	for idx, section := range sections {
+		retVal.Elements[idx] = &object.String{Value: section}
+	}
+	return retVal
+}
+
+func peBuiltinWriteSection(this object.Object, args ...object.Object) object.Object {
+	peThis := this.(*object.PeFile)
+	section := args[0].(*object.String)
+	data := args[1].(*object.Array)
+
+	offset := args[2].(*object.Integer)
+	if offset.Value < 0 {
+		return newTypeError("the offset must be a positive integer")
+	}
+
+	byteArr := make([]byte, len(data.Elements))
+	if err := intArrayToBytes(data, byteArr); err != nil {
+		return err
+	}
+
+	if err := peThis.File.WriteSection(section.Value, byteArr, uint64(offset.Value)); err != nil {
+		return newPeError("%s", err)
+	}
+	return nil
+}
+
+func peBuiltinReadSection(this object.Object, args ...object.Object) object.Object {
+	peThis := this.(*object.PeFile)
+	section := args[0].(*object.String)
+
+	readData, err := peThis.File.ReadSection(section.Value)
+	if err != nil {
+		return newPeError("%s", err)
+	}
+
+	retVal := &object.Array{Elements: make([]object.Object, len(readData))}
+	for idx, readByte := range readData {
+		retVal.Elements[idx] = &object.Integer{Value: int64(readByte)}
+	}
+	return retVal
+}
+
+func peBuiltinSectionAddress(this object.Object, args ...object.Object) object.Object {
+	peThis := this.(*object.PeFile)
+	section := args[0].(*object.String)
+
+	addr, err := peThis.File.SectionAddress(section.Value)
+	if err != nil {
+		return newPeError("%s", err)
+	}
+	return &object.Integer{Value: int64(addr)}
+}
+
+func peBuiltinSectionSize(this object.Object, args ...object.Object) object.Object {
+	peThis := this.(*object.PeFile)
+	section := args[0].(*object.String)
+
+	size, err := peThis.File.SectionSize(section.Value)
+	if err != nil {
+		return newPeError("%s", err)
+	}
+	return &object.Integer{Value: int64(size)}
+}
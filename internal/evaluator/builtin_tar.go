@@ -0,0 +1,47 @@
+package evaluator
+
+import (
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+func tarBuiltinHasEntry(this object.Object, args ...object.Object) object.Object {
+	tarThis := this.(*object.TarFile)
+	entry := args[0].(*object.String)
+	if tarThis.File.HasEntry(entry.Value) {
+		return TRUE
+	}
+	return FALSE
+}
+
+func tarBuiltinEntries(this object.Object, _ ...object.Object) object.Object {
+	tarThis := this.(*object.TarFile)
+	return stringsToArray(tarThis.File.Entries())
+}
+
+func tarBuiltinReadEntry(this object.Object, args ...object.Object) object.Object {
+	tarThis := this.(*object.TarFile)
+	entry := args[0].(*object.String)
+
+	readData, err := tarThis.File.ReadEntry(entry.Value)
+	if err != nil {
+		return newTarError("%s", err)
+	}
+	return bytestoIntarray(readData)
+}
+
+func tarBuiltinAddEntry(this object.Object, args ...object.Object) object.Object {
+	tarThis := this.(*object.TarFile)
+	entry := args[0].(*object.String)
+	data := args[1].(*object.Array)
+	mode := args[2].(*object.Integer)
+
+	byteArr := make([]byte, len(data.Elements))
+	if err := intArrayToBytes(data, byteArr); err != nil {
+		return err
+	}
+
+	if err := tarThis.File.AddEntry(entry.Value, byteArr, mode.Value); err != nil {
+		return newTarError("%s", err)
+	}
+	return nil
+}
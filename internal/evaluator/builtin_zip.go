@@ -0,0 +1,46 @@
+package evaluator
+
+import (
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+func zipBuiltinHasEntry(this object.Object, args ...object.Object) object.Object {
+	zipThis := this.(*object.ZipFile)
+	entry := args[0].(*object.String)
+	if zipThis.File.HasEntry(entry.Value) {
+		return TRUE
+	}
+	return FALSE
+}
+
+func zipBuiltinEntries(this object.Object, _ ...object.Object) object.Object {
+	zipThis := this.(*object.ZipFile)
+	return stringsToArray(zipThis.File.Entries())
+}
+
+func zipBuiltinReadEntry(this object.Object, args ...object.Object) object.Object {
+	zipThis := this.(*object.ZipFile)
+	entry := args[0].(*object.String)
+
+	readData, err := zipThis.File.ReadEntry(entry.Value)
+	if err != nil {
+		return newZipError("%s", err)
+	}
+	return bytestoIntarray(readData)
+}
+
+func zipBuiltinAddEntry(this object.Object, args ...object.Object) object.Object {
+	zipThis := this.(*object.ZipFile)
+	entry := args[0].(*object.String)
+	data := args[1].(*object.Array)
+
+	byteArr := make([]byte, len(data.Elements))
+	if err := intArrayToBytes(data, byteArr); err != nil {
+		return err
+	}
+
+	if err := zipThis.File.AddEntry(entry.Value, byteArr); err != nil {
+		return newZipError("%s", err)
+	}
+	return nil
+}
@@ -5,24 +5,42 @@ import (
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
+	"encoding/base32"
 	hex2 "encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
+	"io"
 	"math"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 
+	"github.com/Abathargh/harlock/internal/ast"
 	"github.com/Abathargh/harlock/internal/evaluator/bytes"
 	harlockElf "github.com/Abathargh/harlock/internal/evaluator/elf"
+	harlockMacho "github.com/Abathargh/harlock/internal/evaluator/macho"
+	harlockPe "github.com/Abathargh/harlock/internal/evaluator/pe"
+	harlockTar "github.com/Abathargh/harlock/internal/evaluator/tar"
+	harlockZip "github.com/Abathargh/harlock/internal/evaluator/zip"
 	"github.com/Abathargh/harlock/internal/object"
 	"github.com/Abathargh/harlock/pkg/hex"
 )
 
 const (
+	inMemoryPerms    = 0664
+	prettyThreshold  = 8
+	hexdumpWidth     = 16
+	maxHexdumpWidth  = 256
+	maxArrayOfSize   = 1 << 24
+	backupSuffix     = ".bak"
+	readOnlyMode     = "ro"
+	readWriteMode    = "rw"
 	builtinErrorName = "error"
-	typeErrTemplate  = "'%s' requires %d parameter(s) (%s), got %s(%s) (%s) on line %d"
-	typeErrNoArgs    = "'%s' - %s on line %d"
-	runtimeErrNoArgs = "%s on line %d"
+	typeErrTemplate  = "'%s' requires %d parameter(s) (%s), got %s(%s) (%s)"
+	typeErrNoArgs    = "'%s' - %s"
 )
 
 func checkType(expected, actual object.ObjectType) bool {
@@ -66,8 +84,8 @@ func typeArgsError(builtin object.CallableBuiltin, line int, args []object.Objec
 	if len(argsTypeStr) == 0 {
 		argsTypeStr = "no args"
 	}
-	errorStr := fmt.Sprintf(typeErrTemplate, name, len(reqTypes), reqStr, name, argsValueStr, argsTypeStr, line)
-	return newError(errorStr) // args evaluation error should not be recoverable with try
+	// args evaluation error should not be recoverable with try
+	return newLineError(line, typeErrTemplate, name, len(reqTypes), reqStr, name, argsValueStr, argsTypeStr)
 }
 
 func execBuiltin(builtin object.CallableBuiltin, line int, args ...object.Object) object.Object {
@@ -122,10 +140,21 @@ exec:
 	outcome := builtin.Call(args...)
 	switch typedOutcome := outcome.(type) {
 	case *object.RuntimeError:
-		if name == builtinErrorName { // hard-coded case for the builtin error() function
-			typedOutcome.Message = fmt.Sprintf(runtimeErrNoArgs, typedOutcome.Message, line)
-		} else {
-			typedOutcome.Message = fmt.Sprintf(typeErrNoArgs, name, typedOutcome.Message, line)
+		// errors propagated from nested calls already went through here, so
+		// they keep the name of the builtin that raised them
+		if typedOutcome.Line == 0 && name != builtinErrorName { // hard-coded case for the builtin error() function
+			typedOutcome.Message = fmt.Sprintf(typeErrNoArgs, name, typedOutcome.Message)
+		}
+
+		// and the line they were raised on, if it is known
+		if typedOutcome.Line <= 0 {
+			typedOutcome.Line = line
+		}
+		return typedOutcome
+	case *object.Error:
+		// errors aborting the execution report where they happened
+		if typedOutcome.Line <= 0 {
+			typedOutcome.Line = line
 		}
 		return typedOutcome
 	default:
@@ -156,24 +185,171 @@ func builtinHex(args ...object.Object) object.Object {
 
 func builtinFromhex(args ...object.Object) object.Object {
 	hexString := args[0].(*object.String)
-	strVal := hexString.Value
-	if strings.HasPrefix(strVal, "0x") || strings.HasPrefix(strVal, "0X") {
-		strVal = strVal[2:]
+	data, err := decodeHexString(hexString.Value)
+	if err != nil {
+		return err
+	}
+	return bytestoIntarray(data)
+}
+
+func builtinBytesFromHex(args ...object.Object) object.Object {
+	hexString := args[0].(*object.String)
+	data, err := decodeHexString(hexString.Value)
+	if err != nil {
+		return err
+	}
+	return object.NewBytesFile("", inMemoryPerms, int64(len(data)), bytes.New(data))
+}
+
+func builtinToHexString(args ...object.Object) object.Object {
+	switch encoded := args[0].(type) {
+	case *object.Array:
+		byteData := make([]byte, len(encoded.Elements))
+		if err := intArrayToBytes(encoded, byteData); err != nil {
+			return err
+		}
+		return &object.String{Value: hex2.EncodeToString(byteData)}
+	case object.File:
+		return &object.String{Value: hex2.EncodeToString(encoded.AsBytes())}
+	default:
+		return newTypeError("must pass a byte array or a file (hex, elf, pe, macho, zip, tar, bytes)")
+	}
+}
+
+func builtinBase32Encode(args ...object.Object) object.Object {
+	data := args[0].(*object.Array)
+	byteData := make([]byte, len(data.Elements))
+	if err := intArrayToBytes(data, byteData); err != nil {
+		return err
+	}
+	return &object.String{Value: base32.StdEncoding.EncodeToString(byteData)}
+}
+
+func builtinBase32Decode(args ...object.Object) object.Object {
+	encoded := args[0].(*object.String)
+	data, err := base32.StdEncoding.DecodeString(encoded.Value)
+	if err != nil {
+		return newTypeError("invalid base32 string: %s", err)
+	}
+	return bytestoIntarray(data)
+}
+
+// writtenBytes converts the data argument of the write_at methods to the
+// bytes to write: data can either be a byte array or a hex string.
+func writtenBytes(data object.Object) ([]byte, *object.RuntimeError) {
+	switch typedData := data.(type) {
+	case *object.String:
+		return decodeHexString(typedData.Value)
+	case *object.Array:
+		byteArr := make([]byte, len(typedData.Elements))
+		for idx, elem := range typedData.Elements {
+			intElem, isInt := elem.(*object.Integer)
+			if !isInt || intElem.Value > maxByte || intElem.Value < 0 {
+				return nil, newTypeError("data must be an array of 1 byte positive integers "+
+					"(data[%d] = %s does not follow this constraint)", idx, elem.Inspect())
+			}
+			byteArr[idx] = byte(intElem.Value)
+		}
+		return byteArr, nil
+	default:
+		return nil, newTypeError("data must be a byte array or a hex string")
+	}
+}
+
+// checkBytesRegion returns an error if the [position, position+size) region
+// is not within the bytes file, without overflowing for huge sizes.
+func checkBytesRegion(bytesFile *object.BytesFile, position, size int64) *object.RuntimeError {
+	length := int64(bytesFile.Bytes.Len())
+	if position > length || size > length-position {
+		return newBytesError("%s", bytes.AccessOutOfBounds)
+	}
+	return nil
+}
+
+// patternBytes builds a buffer of the passed size by repeating the pattern
+// byte array, truncating the last repetition if it does not fit.
+func patternBytes(size int, pattern *object.Array) ([]byte, *object.RuntimeError) {
+	patternArr := make([]byte, len(pattern.Elements))
+	if err := intArrayToBytes(pattern, patternArr); err != nil {
+		return nil, err
+	}
+
+	filled := make([]byte, size)
+	for idx := range filled {
+		filled[idx] = patternArr[idx%len(patternArr)]
+	}
+	return filled, nil
+}
+
+// patchEdit is a single edit of a patch, writing data at offset.
+type patchEdit struct {
+	offset int64
+	data   []byte
+}
+
+// patchEdits converts the patch passed to the apply_patch methods to the
+// edits it is made of: each entry must be an [offset, data] array, where
+// data is either a byte array or a hex string, as in write_at.
+func patchEdits(patch *object.Array) ([]patchEdit, *object.RuntimeError) {
+	edits := make([]patchEdit, len(patch.Elements))
+	for idx, entry := range patch.Elements {
+		pair, isArray := entry.(*object.Array)
+		if !isArray || len(pair.Elements) != 2 {
+			return nil, newTypeError("patch entries must be [offset, data] arrays "+
+				"(patch[%d] = %s does not follow this constraint)", idx, entry.Inspect())
+		}
+
+		offset, isInt := pair.Elements[0].(*object.Integer)
+		if !isInt || offset.Value < 0 {
+			return nil, newTypeError("patch offsets must be positive integers "+
+				"(patch[%d] = %s does not follow this constraint)", idx, entry.Inspect())
+		}
+
+		data, err := writtenBytes(pair.Elements[1])
+		if err != nil {
+			return nil, err
+		}
+		edits[idx] = patchEdit{offset: offset.Value, data: data}
 	}
+	return edits, nil
+}
+
+// decodeHexString converts a hex string to the bytes it represents,
+// ignoring whitespace, separators and 0x prefixes.
+func decodeHexString(hexStr string) ([]byte, *object.RuntimeError) {
+	strVal := stripHexString(hexStr)
 
 	strLen := len(strVal)
 	if strLen%2 != 0 || strLen == 0 {
-		return newTypeError("wrong size for hex string literal")
+		return nil, newTypeError("wrong size for hex string literal")
 	}
-	arr := make([]object.Object, strLen/2)
+	data := make([]byte, strLen/2)
 	for idx := 0; idx < strLen; idx += 2 {
-		digit, err := strconv.ParseInt(strVal[idx:idx+2], 16, 64)
+		digit, err := strconv.ParseUint(strVal[idx:idx+2], 16, 8)
 		if err != nil {
-			return newTypeError("invalid hex digit %s", strVal[idx:idx+2])
+			return nil, newTypeError("invalid hex digit %s", strVal[idx:idx+2])
+		}
+		data[idx/2] = byte(digit)
+	}
+	return data, nil
+}
+
+// stripHexString removes whitespace, common byte separators and per-byte
+// 0x prefixes from a hex string, so that dumps such as "DE AD BE EF" or
+// "0xde, 0xad" can be decoded as if they were a contiguous hex string.
+func stripHexString(hexStr string) string {
+	var buf strings.Builder
+	chunks := strings.FieldsFunc(hexStr, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == ':'
+	})
+
+	for _, chunk := range chunks {
+		if strings.HasPrefix(chunk, "0x") || strings.HasPrefix(chunk, "0X") {
+			chunk = chunk[2:]
 		}
-		arr[idx/2] = &object.Integer{Value: digit}
+		buf.WriteString(chunk)
 	}
-	return &object.Array{Elements: arr}
+	return buf.String()
 }
 
 func builtinLen(args ...object.Object) object.Object {
@@ -198,6 +374,70 @@ func builtinType(args ...object.Object) object.Object {
 	return &object.String{Value: string(args[0].Type())}
 }
 
+func builtinIsNull(args ...object.Object) object.Object {
+	return getBoolReference(isNull(args[0]))
+}
+
+func builtinFreeze(args ...object.Object) object.Object {
+	frozenColl := shallowCopy(args[0])
+	frozenColl.Freeze()
+	return frozenColl
+}
+
+// shallowCopy returns a modifiable copy of the passed array, map or set,
+// whose elements are copied as references, or nil for any other object.
+func shallowCopy(obj object.Object) object.Freezable {
+	switch coll := obj.(type) {
+	case *object.Array:
+		elements := make([]object.Object, len(coll.Elements))
+		copy(elements, coll.Elements)
+		return &object.Array{Elements: elements}
+	case *object.Map:
+		mappings := make(map[object.HashKey]object.HashPair, len(coll.Mappings))
+		for key, pair := range coll.Mappings {
+			mappings[key] = pair
+		}
+		return &object.Map{Mappings: mappings}
+	case *object.Set:
+		elements := make(map[object.HashKey]object.Object, len(coll.Elements))
+		for key, elem := range coll.Elements {
+			elements[key] = elem
+		}
+		return &object.Set{Elements: elements}
+	default:
+		return nil
+	}
+}
+
+func builtinArrayOf(args ...object.Object) object.Object {
+	size := args[0].(*object.Integer)
+	if size.Value < 0 || size.Value > maxArrayOfSize {
+		return newTypeError("the array size must be a positive integer up to %d", maxArrayOfSize)
+	}
+
+	value := args[1]
+	if value == nil {
+		value = NULL
+	}
+
+	elements := make([]object.Object, size.Value)
+	for idx := range elements {
+		// collections are copied, so that each element can be modified
+		// independently of the others
+		if coll := shallowCopy(value); coll != nil {
+			elements[idx] = coll
+			continue
+		}
+		elements[idx] = value
+	}
+	return &object.Array{Elements: elements}
+}
+
+func builtinIsFrozen(args ...object.Object) object.Object {
+	coll, isFreezable := args[0].(object.Freezable)
+	return getBoolReference(isFreezable && coll.Frozen())
+}
+
 func builtinPrint(args ...object.Object) object.Object {
 	var ifcArgs []any
 	for _, arg := range args {
@@ -209,6 +449,147 @@ func builtinPrint(args ...object.Object) object.Object {
 	return nil
 }
 
+func builtinPrintHex(args ...object.Object) object.Object {
+	var data []byte
+	switch printed := args[0].(type) {
+	case *object.Array:
+		data = make([]byte, len(printed.Elements))
+		if err := intArrayToBytes(printed, data); err != nil {
+			return err
+		}
+	case object.File:
+		data = printed.AsBytes()
+	}
+
+	fmt.Println(hexRepr(data))
+	return nil
+}
+
+// hexRepr renders the passed bytes like a harlock array, with each byte
+// written as a 0x-prefixed hex literal.
+func hexRepr(data []byte) string {
+	elements := make([]string, len(data))
+	for idx, b := range data {
+		elements[idx] = fmt.Sprintf("0x%02x", b)
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+func builtinPrettyPrint(args ...object.Object) object.Object {
+	fmt.Println(pretty(args[0]))
+	return nil
+}
+
+// pretty returns a readable representation of the passed object: arrays,
+// maps and sets with more than prettyThreshold elements are rendered one
+// element per line, with the keys of maps and the elements of sets sorted.
+// Smaller collections are rendered as by repr, other objects as by print.
+func pretty(obj object.Object) string {
+	var open, closing string
+	var entries []string
+
+	switch value := obj.(type) {
+	case nil:
+		return NULL.Inspect()
+	case *object.Array:
+		if len(value.Elements) <= prettyThreshold {
+			return repr(value)
+		}
+		open, closing = "[", "]"
+		for _, elem := range value.Elements {
+			entries = append(entries, repr(elem))
+		}
+	case *object.Map:
+		if len(value.Mappings) <= prettyThreshold {
+			return repr(value)
+		}
+		open, closing = "{", "}"
+		pairs := make([]object.HashPair, 0, len(value.Mappings))
+		for _, pair := range value.Mappings {
+			pairs = append(pairs, pair)
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			return lessObject(pairs[i].Key, pairs[j].Key)
+		})
+		for _, pair := range pairs {
+			entries = append(entries, fmt.Sprintf("%s: %s", repr(pair.Key), repr(pair.Value)))
+		}
+	case *object.Set:
+		if len(value.Elements) <= prettyThreshold {
+			return repr(value)
+		}
+		open, closing = "set(", ")"
+		elements := make([]object.Object, 0, len(value.Elements))
+		for _, elem := range value.Elements {
+			elements = append(elements, elem)
+		}
+		sort.Slice(elements, func(i, j int) bool {
+			return lessObject(elements[i], elements[j])
+		})
+		for _, elem := range elements {
+			entries = append(entries, repr(elem))
+		}
+	default:
+		return obj.Inspect()
+	}
+
+	var buf strings.Builder
+	buf.WriteString(open)
+	buf.WriteString("\n")
+	for _, entry := range entries {
+		buf.WriteString("\t")
+		buf.WriteString(entry)
+		buf.WriteString(",\n")
+	}
+	buf.WriteString(closing)
+	return buf.String()
+}
+
+func builtinRepr(args ...object.Object) object.Object {
+	return &object.String{Value: repr(args[0])}
+}
+
+// repr returns an unambiguous representation of the passed object: strings
+// are quoted and the elements of maps and sets are sorted, so that the same
+// value always produces the same representation.
+func repr(obj object.Object) string {
+	switch value := obj.(type) {
+	case nil:
+		return NULL.Inspect()
+	case *object.String:
+		return ast.Quote(value.Value)
+	case *object.Array:
+		elements := make([]string, len(value.Elements))
+		for idx, elem := range value.Elements {
+			elements[idx] = repr(elem)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *object.Map:
+		var mappings []string
+		for _, pair := range value.Mappings {
+			mappings = append(mappings, fmt.Sprintf("%s: %s", repr(pair.Key), repr(pair.Value)))
+		}
+		sort.Strings(mappings)
+		return "{" + strings.Join(mappings, ", ") + "}"
+	case *object.Set:
+		var elements []string
+		for _, elem := range value.Elements {
+			elements = append(elements, repr(elem))
+		}
+		sort.Strings(elements)
+		return "set(" + strings.Join(elements, ", ") + ")"
+	case object.File:
+		return fmt.Sprintf("%s(%s, size=0x%x)", obj.Type(),
+			ast.Quote(value.Name()), len(value.AsBytes()))
+	case *object.Builtin:
+		return fmt.Sprintf("builtin(%s)", value.Name)
+	case *object.Method:
+		return fmt.Sprintf("builtin method(%s)", value.Name)
+	default:
+		return obj.Inspect()
+	}
+}
+
 func builtinSet(args ...object.Object) object.Object {
 	set := &object.Set{Elements: make(map[object.HashKey]object.Object)}
 	for _, arg := range args {
@@ -244,6 +625,48 @@ func builtinSet(args ...object.Object) object.Object {
 	return set
 }
 
+func builtinMap(args ...object.Object) object.Object {
+	pairs := args[0].(*object.Array)
+	mapping := &object.Map{Mappings: make(map[object.HashKey]object.HashPair)}
+	for idx, elem := range pairs.Elements {
+		pair, isArray := elem.(*object.Array)
+		if !isArray || len(pair.Elements) != 2 {
+			return newTypeError("each element must be a [key, value] pair "+
+				"(element %d = %s does not follow this constraint)", idx, elem.Inspect())
+		}
+
+		if err := setMapping(mapping, pair.Elements[0], pair.Elements[1]); err != nil {
+			return err
+		}
+	}
+	return mapping
+}
+
+func builtinDict(args ...object.Object) object.Object {
+	if len(args)%2 != 0 {
+		return newTypeError("expected alternating keys and values, got %d arguments", len(args))
+	}
+
+	mapping := &object.Map{Mappings: make(map[object.HashKey]object.HashPair)}
+	for idx := 0; idx < len(args); idx += 2 {
+		if err := setMapping(mapping, args[idx], args[idx+1]); err != nil {
+			return err
+		}
+	}
+	return mapping
+}
+
+// setMapping adds the key/value pair to the passed map, checking that
+// the key is hashable.
+func setMapping(mapping *object.Map, key, value object.Object) *object.RuntimeError {
+	hashableKey, isHashable := key.(object.Hashable)
+	if !isHashable {
+		return newTypeError("the passed key is not an hashable object")
+	}
+	mapping.Mappings[hashableKey.HashKey()] = object.HashPair{Key: key, Value: value}
+	return nil
+}
+
 func builtinContains(args ...object.Object) object.Object {
 	switch cont := args[0].(type) {
 	case *object.Array:
@@ -284,13 +707,32 @@ func builtinOpen(args ...object.Object) object.Object {
 	filename := args[0].(*object.String)
 	fileType := args[1].(*object.String)
 
+	readOnly := false
+	if len(args) > 2 {
+		mode, isString := args[2].(*object.String)
+		if !isString || (mode.Value != readOnlyMode && mode.Value != readWriteMode) {
+			return newTypeError("the mode must be either %q or %q", readOnlyMode, readWriteMode)
+		}
+		readOnly = mode.Value == readOnlyMode
+	}
+
 	file, err := os.Open(filename.Value)
 	if err != nil {
 		return newFileError("could not open file %q", filename.Value)
 	}
 	defer func() { _ = file.Close() }()
 
-	switch fileType.Value {
+	opened := openAs(file, fileType.Value)
+	if openedFile, isFile := opened.(object.File); isFile {
+		openedFile.SetReadOnly(readOnly)
+	}
+	return opened
+}
+
+// openAs reads the contents of the passed file as a file object
+// of the passed type.
+func openAs(file *os.File, fileType string) object.Object {
+	switch fileType {
 	case "bytes":
 		bytesFile, err := bytes.ReadAll(file)
 		if err != nil {
@@ -315,6 +757,38 @@ func builtinOpen(args ...object.Object) object.Object {
 		info, _ := file.Stat()
 		return object.NewElfFile(file.Name(), uint32(info.Mode().Perm()), elfFile)
 
+	case "pe":
+		peFile, err := harlockPe.ReadAll(file)
+		if err != nil {
+			return newFileError("%s", err)
+		}
+		info, _ := file.Stat()
+		return object.NewPeFile(file.Name(), uint32(info.Mode().Perm()), peFile)
+
+	case "macho":
+		machoFile, err := harlockMacho.ReadAll(file)
+		if err != nil {
+			return newFileError("%s", err)
+		}
+		info, _ := file.Stat()
+		return object.NewMachoFile(file.Name(), uint32(info.Mode().Perm()), machoFile)
+
+	case "zip":
+		zipFile, err := harlockZip.ReadAll(file)
+		if err != nil {
+			return newFileError("%s", err)
+		}
+		info, _ := file.Stat()
+		return object.NewZipFile(file.Name(), uint32(info.Mode().Perm()), zipFile)
+
+	case "tar":
+		tarFile, err := harlockTar.ReadAll(file)
+		if err != nil {
+			return newFileError("%s", err)
+		}
+		info, _ := file.Stat()
+		return object.NewTarFile(file.Name(), uint32(info.Mode().Perm()), tarFile)
+
 	default:
 		return newFileError("unsupported file type")
 	}
@@ -323,16 +797,53 @@ func builtinOpen(args ...object.Object) object.Object {
 func builtinSave(args ...object.Object) object.Object {
 	switch file := args[0].(type) {
 	case object.File:
+		if file.ReadOnly() {
+			return newFileError("cannot save a file opened in read-only mode")
+		}
+
+		backup := false
+		if len(args) == 2 {
+			backupObj, isBool := args[1].(*object.Boolean)
+			if !isBool {
+				return newTypeError("the backup flag must be a boolean")
+			}
+			backup = backupObj.Value
+		}
+
+		if backup {
+			if err := backupFile(file.Name()); err != nil {
+				return newFileError("could not back up the passed file")
+			}
+		}
+
 		err := os.WriteFile(file.Name(), file.AsBytes(), os.FileMode(file.Perms()))
 		if err != nil {
 			return newFileError("could not save the passed file")
 		}
 		return nil
 	default:
-		return newFileError("must pass a file (hex, elf, bytes)")
+		return newFileError("must pass a file (hex, elf, pe, macho, zip, tar, bytes)")
 	}
 }
 
+// backupFile copies the current contents of the named file, if it exists,
+// to a file with the same name and the backup suffix.
+func backupFile(name string) error {
+	info, err := os.Stat(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	contents, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name+backupSuffix, contents, info.Mode().Perm())
+}
+
 func builtinAsBytes(args ...object.Object) object.Object {
 	switch file := args[0].(type) {
 	case object.File:
@@ -343,32 +854,286 @@ func builtinAsBytes(args ...object.Object) object.Object {
 		}
 		return &object.Array{Elements: buf}
 	default:
-		return newFileError("must pass a file (hex, elf, bytes)")
+		return newFileError("must pass a file (hex, elf, pe, macho, zip, tar, bytes)")
+	}
+}
+
+func builtinToHex(args ...object.Object) object.Object {
+	var data []byte
+	switch converted := args[0].(type) {
+	case *object.Array:
+		data = make([]byte, len(converted.Elements))
+		if err := intArrayToBytes(converted, data); err != nil {
+			return err
+		}
+	case *object.BytesFile:
+		data = converted.AsBytes()
+	}
+
+	recordSize := hex.DefaultRecordSize
+	if len(args) == 2 {
+		sizeObj, isInt := args[1].(*object.Integer)
+		if !isInt {
+			return newTypeError("the record size must be an integer")
+		}
+		recordSize = int(sizeObj.Value)
+	}
+
+	hexFile, err := hex.FromBytes(data, recordSize)
+	if err != nil {
+		return newHexError("%s", err)
+	}
+	return object.NewHexFile("", inMemoryPerms, hexFile)
+}
+
+// dumpedBytes returns the bytes of a byte array or the contents of a file
+func dumpedBytes(dumped object.Object) ([]byte, *object.RuntimeError) {
+	switch typedDumped := dumped.(type) {
+	case *object.Array:
+		data := make([]byte, len(typedDumped.Elements))
+		if err := intArrayToBytes(typedDumped, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	case object.File:
+		return typedDumped.AsBytes(), nil
+	default:
+		return nil, newTypeError("must pass a byte array or a file")
+	}
+}
+
+func builtinHexdump(args ...object.Object) object.Object {
+	data, err := dumpedBytes(args[0])
+	if err != nil {
+		return err
+	}
+
+	width := hexdumpWidth
+	if len(args) == 2 {
+		widthObj, isInt := args[1].(*object.Integer)
+		if !isInt || widthObj.Value <= 0 || widthObj.Value > maxHexdumpWidth {
+			return newTypeError("the row width must be an integer in the 1..%d range", maxHexdumpWidth)
+		}
+		width = int(widthObj.Value)
+	}
+
+	var buf strings.Builder
+	for offset := 0; offset < len(data); offset += width {
+		end := len(data)
+		if end-offset > width {
+			end = offset + width
+		}
+		row := data[offset:end]
+
+		if offset != 0 {
+			buf.WriteRune('\n')
+		}
+		buf.WriteString(fmt.Sprintf("%08x ", offset))
+		for idx := 0; idx < width; idx++ {
+			if idx < len(row) {
+				buf.WriteString(fmt.Sprintf(" %02x", row[idx]))
+				continue
+			}
+			buf.WriteString("   ")
+		}
+
+		buf.WriteString("  |")
+		for _, b := range row {
+			if b < 0x20 || b > 0x7e {
+				buf.WriteRune('.')
+				continue
+			}
+			buf.WriteByte(b)
+		}
+		buf.WriteRune('|')
+	}
+	return &object.String{Value: buf.String()}
+}
+
+func builtinHexdumpDiff(args ...object.Object) object.Object {
+	first, err := dumpedBytes(args[0])
+	if err != nil {
+		return err
+	}
+
+	second, err := dumpedBytes(args[1])
+	if err != nil {
+		return err
+	}
+
+	size := len(first)
+	if len(second) > size {
+		size = len(second)
+	}
+
+	// renders a row of one of the two sides, marking the bytes that differ
+	// from the other side with '*' and padding the missing ones with '--'
+	writeSide := func(buf *strings.Builder, data, other []byte, offset int) {
+		for idx := offset; idx < offset+hexdumpWidth && idx < size; idx++ {
+			marker := ' '
+			if idx >= len(data) || idx >= len(other) || data[idx] != other[idx] {
+				marker = '*'
+			}
+			buf.WriteRune(marker)
+
+			if idx < len(data) {
+				buf.WriteString(fmt.Sprintf("%02x", data[idx]))
+				continue
+			}
+			buf.WriteString("--")
+		}
+	}
+
+	var buf strings.Builder
+	for offset := 0; offset < size; offset += hexdumpWidth {
+		if offset != 0 {
+			buf.WriteRune('\n')
+		}
+		buf.WriteString(fmt.Sprintf("%08x ", offset))
+		writeSide(&buf, first, second, offset)
+		buf.WriteString("  |")
+		writeSide(&buf, second, first, offset)
+	}
+	return &object.String{Value: buf.String()}
+}
+
+func builtinMakePatch(args ...object.Object) object.Object {
+	oldData, err := dumpedBytes(args[0])
+	if err != nil {
+		return err
+	}
+
+	newData, err := dumpedBytes(args[1])
+	if err != nil {
+		return err
+	}
+
+	// a patch can only overwrite bytes, so it cannot resize the input
+	if len(oldData) != len(newData) {
+		return newTypeError("cannot make a patch between inputs of different "+
+			"lengths (%d and %d bytes)", len(oldData), len(newData))
+	}
+
+	patch := &object.Array{Elements: []object.Object{}}
+	for start := 0; start < len(newData); start++ {
+		if oldData[start] == newData[start] {
+			continue
+		}
+
+		end := start
+		for end < len(newData) && oldData[end] != newData[end] {
+			end++
+		}
+
+		edit := []object.Object{&object.Integer{Value: int64(start)}, bytestoIntarray(newData[start:end])}
+		patch.Elements = append(patch.Elements, &object.Array{Elements: edit})
+		start = end
+	}
+	return patch
+}
+
+// printableRuns returns the runs of at least minLen printable ascii
+// characters within data, as [offset, string] pairs, where the offsets
+// are relative to base.
+func printableRuns(data []byte, base int64, minLen int) []object.Object {
+	var runs []object.Object
+	appendRun := func(start, end int) {
+		if end-start < minLen {
+			return
+		}
+		runs = append(runs, &object.Array{Elements: []object.Object{
+			&object.Integer{Value: base + int64(start)},
+			&object.String{Value: string(data[start:end])},
+		}})
 	}
+
+	start := 0
+	for idx, b := range data {
+		if b == '\t' || b >= 0x20 && b <= 0x7e {
+			continue
+		}
+		appendRun(start, idx)
+		start = idx + 1
+	}
+	appendRun(start, len(data))
+	return runs
 }
 
 func builtinHash(args ...object.Object) object.Object {
-	data := args[0].(*object.Array)
 	hashFunc := args[1].(*object.String)
+	hasher := newHasher(hashFunc.Value)
+	if hasher == nil {
+		return newTypeError("unsupported hash function %s", hashFunc.Value)
+	}
 
-	byteData := make([]byte, len(data.Elements))
-	if err := intArrayToBytes(data, byteData); err != nil {
-		return err
+	switch data := args[0].(type) {
+	case *object.BytesFile:
+		// bytes files are streamed through the hash function, so that
+		// their contents are never copied in memory
+		if _, err := io.Copy(hasher, data.Bytes.Reader()); err != nil {
+			return newBytesError("%s", err)
+		}
+	case *object.Array:
+		byteData := make([]byte, len(data.Elements))
+		if err := intArrayToBytes(data, byteData); err != nil {
+			return err
+		}
+		hasher.Write(byteData)
 	}
+	return bytestoIntarray(hasher.Sum(nil))
+}
 
-	switch hashFunc.Value {
+// newHasher returns the hash function identified by the passed name,
+// or nil if the algorithm is not supported.
+func newHasher(name string) hash.Hash {
+	switch name {
 	case "sha1":
-		sha1Sum := sha1.Sum(byteData)
-		return bytestoIntarray(sha1Sum[:])
+		return sha1.New()
 	case "sha256":
-		sha256um := sha256.Sum256(byteData)
-		return bytestoIntarray(sha256um[:])
+		return sha256.New()
 	case "md5":
-		md5Sum := md5.Sum(byteData)
-		return bytestoIntarray(md5Sum[:])
+		return md5.New()
 	default:
-		return newError("unsupported hash function %s", hashFunc.Value)
+		return nil
+	}
+}
+
+// entropyScale is the scale of the entropy estimates, which are expressed
+// in thousandths of a bit per byte, since the language has no float type.
+const entropyScale = 1000
+
+// byteHistogram counts the occurrences of each byte value written to it,
+// so that the entropy of a region can be estimated while streaming it.
+type byteHistogram struct {
+	counts [256]uint64
+	total  uint64
+}
+
+// Write implements io.Writer for byteHistogram
+func (bh *byteHistogram) Write(data []byte) (int, error) {
+	for _, b := range data {
+		bh.counts[b]++
+	}
+	bh.total += uint64(len(data))
+	return len(data), nil
+}
+
+// Entropy returns the Shannon entropy of the written bytes in thousandths
+// of a bit per byte, ranging from 0 for constant data to 8000.
+func (bh *byteHistogram) Entropy() int64 {
+	if bh.total == 0 {
+		return 0
+	}
+
+	entropy := 0.0
+	for _, count := range bh.counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(bh.total)
+		entropy -= p * math.Log2(p)
 	}
+	return int64(math.Round(entropy * entropyScale))
 }
 
 func builtinInt(args ...object.Object) object.Object {
@@ -382,6 +1147,23 @@ func builtinInt(args ...object.Object) object.Object {
 	}
 }
 
+func builtinParseInt(args ...object.Object) object.Object {
+	str := args[0].(*object.String)
+	base := args[1].(*object.Integer)
+	if base.Value < 2 || base.Value > 36 {
+		return newTypeError("unsupported base %d, must be in the 2..36 range", base.Value)
+	}
+
+	converted, err := strconv.ParseInt(str.Value, int(base.Value), 64)
+	if err != nil {
+		return newTypeError("expecting a string representation of a base %d integer, got %s",
+			base.Value, str.Value)
+	}
+	return &object.Integer{
+		Value: converted,
+	}
+}
+
 func builtinError(args ...object.Object) object.Object {
 	var ifcArgs []any
 	for _, arg := range args {
@@ -393,6 +1175,50 @@ func builtinError(args ...object.Object) object.Object {
 	return newCustomError(errorMsg)
 }
 
+func builtinAssertEq(args ...object.Object) object.Object {
+	// values of different types are never equal, rather than a type mismatch
+	if evalInfixExpression("==", args[0], args[1], 0) == TRUE {
+		return nil
+	}
+	failure := fmt.Sprintf("%s != %s", describe(args[0]), describe(args[1]))
+	return assertionError(failure, args[2:])
+}
+
+func builtinAssertType(args ...object.Object) object.Object {
+	typeName := args[1].(*object.String)
+	actualType := typeOrNull(args[0])
+	if string(actualType) == typeName.Value {
+		return nil
+	}
+	failure := fmt.Sprintf("expected a value of type %s, got %s (%s)",
+		typeName.Value, actualType, describe(args[0]))
+	return assertionError(failure, args[2:])
+}
+
+// assertionError returns the error for a failed assertion, including
+// the optional custom message passed to the assertion builtin.
+func assertionError(failure string, msg []object.Object) *object.Error {
+	if len(msg) == 0 || isNull(msg[0]) {
+		return newError("assertion failed: %s", failure)
+	}
+
+	msgStr := msg[0].Inspect()
+	return newError("assertion failed: %s (%s)", msgStr, failure)
+}
+
+// describe returns a representation of the passed object where strings
+// are quoted, so that they can be told apart from other values.
+func describe(obj object.Object) string {
+	switch obj := obj.(type) {
+	case nil:
+		return NULL.Inspect()
+	case *object.String:
+		return ast.Quote(obj.Value)
+	default:
+		return obj.Inspect()
+	}
+}
+
 func builtinAsArray(args ...object.Object) object.Object {
 	intObj := args[0].(*object.Integer)
 	sizeObj := args[1].(*object.Integer)
@@ -405,8 +1231,21 @@ func builtinAsArray(args ...object.Object) object.Object {
 		return newTypeError("cannot represent integers wider than 8 bytes or less than 1 byte")
 	}
 
-	if uint64(intVal) >= uint64(math.Pow(2, float64(8*sizeVal))) {
-		return newTypeError("cannot represent %d with %d bytes", intVal, sizeVal)
+	signed, err := isSigned(args[3:])
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case signed && sizeVal < 8:
+		limit := int64(1) << (8*sizeVal - 1)
+		if intVal < -limit || intVal >= limit {
+			return newTypeError("cannot represent %d with %d bytes as a signed integer", intVal, sizeVal)
+		}
+	case !signed:
+		if uint64(intVal) >= uint64(math.Pow(2, float64(8*sizeVal))) {
+			return newTypeError("cannot represent %d with %d bytes", intVal, sizeVal)
+		}
 	}
 
 	retArr := &object.Array{
@@ -428,6 +1267,95 @@ func builtinAsArray(args ...object.Object) object.Object {
 	return retArr
 }
 
+func builtinAsInt(args ...object.Object) object.Object {
+	arrObj := args[0].(*object.Array)
+	endianObj := args[1].(*object.String)
+
+	size := len(arrObj.Elements)
+	if size == 0 || size > 8 {
+		return newTypeError("cannot represent integers wider than 8 bytes or less than 1 byte")
+	}
+
+	signed, err := isSigned(args[2:])
+	if err != nil {
+		return err
+	}
+
+	byteData := make([]byte, size)
+	if err := intArrayToBytes(arrObj, byteData); err != nil {
+		return err
+	}
+
+	var value uint64
+	switch endianObj.Value {
+	case "little":
+		for i := size - 1; i >= 0; i-- {
+			value = value<<8 | uint64(byteData[i])
+		}
+	case "big":
+		for i := 0; i < size; i++ {
+			value = value<<8 | uint64(byteData[i])
+		}
+	default:
+		return newTypeError("invalid endianness %q", endianObj.Value)
+	}
+
+	if signed && size < 8 {
+		shift := 64 - 8*size
+		return &object.Integer{Value: int64(value<<shift) >> shift}
+	}
+	return &object.Integer{Value: int64(value)}
+}
+
+// readUint returns a method that reads an unsigned integer, size bytes
+// wide, through the passed read_at method, decoding it as as_int does
+// with the endianness passed as the second argument.
+func readUint(size int64, readAt object.MethodFunction) object.MethodFunction {
+	return func(this object.Object, args ...object.Object) object.Object {
+		readData := readAt(this, args[0], &object.Integer{Value: size})
+		byteArr, isArray := readData.(*object.Array)
+		if !isArray {
+			return readData
+		}
+		return builtinAsInt(byteArr, args[1])
+	}
+}
+
+// writeUint returns a method that writes the unsigned integer passed as
+// the second argument, encoded in size bytes as as_array does with the
+// endianness passed as the third argument, through the passed write_at method.
+func writeUint(size int64, writeAt object.MethodFunction) object.MethodFunction {
+	return func(this object.Object, args ...object.Object) object.Object {
+		encoded := builtinAsArray(args[1], &object.Integer{Value: size}, args[2])
+		if _, isArray := encoded.(*object.Array); !isArray {
+			return encoded
+		}
+		return writeAt(this, args[0], encoded)
+	}
+}
+
+// isSigned parses the optional signedness argument of the as_array
+// and as_int builtins, which defaults to unsigned.
+func isSigned(optArgs []object.Object) (bool, *object.RuntimeError) {
+	if len(optArgs) == 0 {
+		return false, nil
+	}
+
+	signedness, isString := optArgs[0].(*object.String)
+	if !isString {
+		return false, newTypeError("the signedness must be either \"signed\" or \"unsigned\"")
+	}
+
+	switch signedness.Value {
+	case "signed":
+		return true, nil
+	case "unsigned":
+		return false, nil
+	default:
+		return false, newTypeError("invalid signedness %q", signedness.Value)
+	}
+}
+
 func builtinHelp(args ...object.Object) object.Object {
 	builtinName := args[0].(*object.String)
 	name := builtinName.Value
@@ -451,6 +1379,38 @@ func builtinHelp(args ...object.Object) object.Object {
 	return newTypeError("%s is not a builtin", name)
 }
 
+// BuiltinNames returns the sorted names of the builtin functions.
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MethodNames returns the sorted names of the builtin methods of the passed
+// type. If the type is empty, the names of the methods of every type are
+// returned, without duplicates.
+func MethodNames(objType object.ObjectType) []string {
+	unique := make(map[string]struct{})
+	for methodType, methods := range builtinMethods {
+		if objType != "" && methodType != objType {
+			continue
+		}
+		for name := range methods {
+			unique[name] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func generateHelpMsg(name string, builtin object.CallableBuiltin) *object.String {
 	const lineLimit = 80
 	var builder strings.Builder
@@ -485,6 +1445,167 @@ func generateHelpMsg(name string, builtin object.CallableBuiltin) *object.String
 	}
 }
 
+func builtinMemoize(args ...object.Object) object.Object {
+	function := args[0].(*object.Function)
+	cache := make(map[string]object.Object)
+
+	argTypes := make([]object.ObjectType, len(function.Parameters))
+	for idx := range argTypes {
+		argTypes[idx] = object.AnyObj
+	}
+
+	return &object.Builtin{
+		Name:        "memoized",
+		Description: "Memoized wrapper of a function, caching its results by argument.",
+		ArgTypes:    argTypes,
+		Function: func(args ...object.Object) object.Object {
+			key, cacheable := memoKey(args)
+			if cacheable {
+				if cached, isCached := cache[key]; isCached {
+					return cached
+				}
+			}
+
+			res := callFunction("memoized()", function, args, noLineInfo)
+			if cacheable && !isError(res) && !isRuntimeError(res) {
+				cache[key] = res
+			}
+			return res
+		},
+	}
+}
+
+// memoKey builds the key identifying the passed argument tuple in the
+// cache of a memoized function. The arguments can be cached only if
+// all of them are hashable.
+func memoKey(args []object.Object) (string, bool) {
+	var buf strings.Builder
+	for _, arg := range args {
+		hashable, isHashable := arg.(object.Hashable)
+		if !isHashable {
+			return "", false
+		}
+		hash := hashable.HashKey()
+		buf.WriteString(fmt.Sprintf("%s:%d;", hash.Type, hash.Value))
+	}
+	return buf.String(), true
+}
+
+func builtinCompose(args ...object.Object) object.Object {
+	return pipeline("compose", []object.Object{args[1], args[0]})
+}
+
+func builtinPipe(args ...object.Object) object.Object {
+	if len(args) == 0 {
+		return newTypeError("pipe requires at least one function")
+	}
+	return pipeline("pipe", args)
+}
+
+// pipeline returns a one-arg builtin calling the passed one-arg callables
+// in order, each with the result of the previous one.
+func pipeline(name string, funcs []object.Object) object.Object {
+	for idx, fun := range funcs {
+		if !isUnaryCallable(fun) {
+			return newTypeError("%s requires one-arg functions (function(x) -> x), "+
+				"got %s as argument %d", name, fun.Inspect(), idx)
+		}
+	}
+
+	return &object.Builtin{
+		Name:        name + "d",
+		Description: "Combination of one-arg functions, calling them in order.",
+		ArgTypes:    []object.ObjectType{object.AnyObj},
+		Function: func(args ...object.Object) object.Object {
+			res := args[0]
+			for _, fun := range funcs {
+				res = callFunction("<anonymous callback>", fun, []object.Object{res}, noLineInfo)
+				if isError(res) || isRuntimeError(res) {
+					return res
+				}
+			}
+			return res
+		},
+	}
+}
+
+// isUnaryCallable returns whether the passed object is a
+// function or a builtin accepting exactly one argument.
+func isUnaryCallable(obj object.Object) bool {
+	switch callable := obj.(type) {
+	case *object.Function:
+		return len(callable.Parameters) == 1
+	case *object.Builtin:
+		return len(callable.GetBuiltinArgTypes()) == 1
+	default:
+		return false
+	}
+}
+
+func builtinPartial(args ...object.Object) object.Object {
+	if len(args) == 0 {
+		return newTypeError("partial requires a function to bind the arguments to")
+	}
+
+	fun := args[0]
+	bound := args[1:]
+
+	// arity is -1 for builtins taking a variable number of arguments
+	var name string
+	var arity int
+	var funArgTypes []object.ObjectType
+	switch callable := fun.(type) {
+	case *object.Function:
+		name = "<anonymous function>"
+		arity = len(callable.Parameters)
+		funArgTypes = make([]object.ObjectType, arity)
+		for idx := range funArgTypes {
+			funArgTypes[idx] = object.AnyObj
+		}
+	case *object.Builtin:
+		name = callable.Name
+		arity = len(callable.ArgTypes)
+		funArgTypes = callable.ArgTypes
+		if arity == 1 && funArgTypes[0] == object.AnyVarargs {
+			arity = -1
+		}
+	default:
+		return newTypeError("partial requires a function, got %s", fun.Type())
+	}
+
+	// the returned function takes the arguments that were not bound yet,
+	// so that the callers checking its arity see the right one
+	argTypes := funArgTypes
+	switch {
+	case arity == -1:
+	case len(bound) > arity:
+		argTypes = []object.ObjectType{}
+	default:
+		argTypes = funArgTypes[len(bound):]
+	}
+
+	return &object.Builtin{
+		Name:        fmt.Sprintf("partial(%s)", name),
+		Description: "Function with some of its arguments already bound.",
+		ArgTypes:    argTypes,
+		Function: func(args ...object.Object) object.Object {
+			if arity != -1 && len(bound) > arity {
+				return newError("'%s' takes %d argument(s), but %d were bound to it",
+					name, arity, len(bound))
+			}
+
+			allArgs := make([]object.Object, 0, len(bound)+len(args))
+			allArgs = append(allArgs, bound...)
+			allArgs = append(allArgs, args...)
+			return callFunction(name+"()", fun, allArgs, noLineInfo)
+		},
+	}
+}
+
+func builtinVersion(_ ...object.Object) object.Object {
+	return &object.String{Value: Version}
+}
+
 func intArrayToBytes(src *object.Array, dst []byte) *object.RuntimeError {
 	for idx, obj := range src.Elements {
 		intByte, isInt := obj.(*object.Integer)
@@ -506,3 +1627,11 @@ func bytestoIntarray(data []byte) *object.Array {
 	}
 	return arr
 }
+
+func stringsToArray(strs []string) *object.Array {
+	retVal := &object.Array{Elements: make([]object.Object, len(strs))}
+	for idx, str := range strs {
+		retVal.Elements[idx] = &object.String{Value: str}
+	}
+	return retVal
+}
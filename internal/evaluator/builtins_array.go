@@ -45,6 +45,51 @@ func arrayBuiltinSlice(this object.Object, args ...object.Object) object.Object
 	return &object.Array{Elements: slice}
 }
 
+func arrayBuiltinChunk(this object.Object, args ...object.Object) object.Object {
+	arrayThis := this.(*object.Array)
+
+	size := args[0].(*object.Integer).Value
+	if size <= 0 {
+		return newTypeError("the chunk size must be a positive integer")
+	}
+
+	arrayLen := int64(len(arrayThis.Elements))
+	chunks := make([]object.Object, 0, (arrayLen+size-1)/size)
+	for start := int64(0); start < arrayLen; start += size {
+		end := start + size
+		if end > arrayLen {
+			end = arrayLen
+		}
+
+		chunk := make([]object.Object, end-start)
+		copy(chunk, arrayThis.Elements[start:end])
+		chunks = append(chunks, &object.Array{Elements: chunk})
+	}
+	return &object.Array{Elements: chunks}
+}
+
+func arrayBuiltinWindow(this object.Object, args ...object.Object) object.Object {
+	arrayThis := this.(*object.Array)
+
+	size := args[0].(*object.Integer).Value
+	if size <= 0 {
+		return newTypeError("the window size must be a positive integer")
+	}
+
+	arrayLen := int64(len(arrayThis.Elements))
+	if arrayLen < size {
+		return &object.Array{Elements: []object.Object{}}
+	}
+
+	windows := make([]object.Object, arrayLen-size+1)
+	for start := range windows {
+		window := make([]object.Object, size)
+		copy(window, arrayThis.Elements[start:int64(start)+size])
+		windows[start] = &object.Array{Elements: window}
+	}
+	return &object.Array{Elements: windows}
+}
+
 func arrayBuiltinMap(this object.Object, args ...object.Object) object.Object {
 	arrayThis := this.(*object.Array)
 	fun := args[0]
@@ -72,6 +117,65 @@ func arrayBuiltinMap(this object.Object, args ...object.Object) object.Object {
 	return &object.Array{Elements: retArray}
 }
 
+func arrayBuiltinZipWith(this object.Object, args ...object.Object) object.Object {
+	arrayThis := this.(*object.Array)
+	other := args[0].(*object.Array)
+	fun := args[1]
+
+	switch callable := fun.(type) {
+	case *object.Function:
+		if len(callable.Parameters) != 2 {
+			return newTypeError("the zip_with callback requires exactly two arguments (a two-args function(x, y) -> z)")
+		}
+	case *object.Builtin:
+		if len(callable.GetBuiltinArgTypes()) != 2 {
+			return newTypeError("the zip_with callback requires exactly two arguments (a two-args function(x, y) -> z)")
+		}
+	}
+
+	size := len(arrayThis.Elements)
+	if len(other.Elements) < size {
+		size = len(other.Elements)
+	}
+
+	retArray := make([]object.Object, size)
+	for idx := range retArray {
+		res := callFunction("<anonymous callback>", fun,
+			[]object.Object{arrayThis.Elements[idx], other.Elements[idx]}, noLineInfo)
+		if isError(res) || isRuntimeError(res) {
+			return res
+		}
+
+		if res == nil {
+			return newTypeError("zip_with requires a fun taking two args and returning one value (function(x, y) -> z)")
+		}
+		retArray[idx] = res
+	}
+	return &object.Array{Elements: retArray}
+}
+
+func arrayBuiltinTap(this object.Object, args ...object.Object) object.Object {
+	arrayThis := this.(*object.Array)
+	fun := args[0]
+
+	switch callable := fun.(type) {
+	case *object.Function:
+		if len(callable.Parameters) != 1 {
+			return newTypeError("the tap callback requires exactly one argument (a one-args function(x))")
+		}
+	case *object.Builtin:
+		if len(callable.GetBuiltinArgTypes()) != 1 {
+			return newTypeError("the tap callback requires exactly one argument (a one-args function(x))")
+		}
+	}
+
+	res := callFunction("<anonymous callback>", fun, []object.Object{arrayThis}, noLineInfo)
+	if isError(res) || isRuntimeError(res) {
+		return res
+	}
+	return arrayThis
+}
+
 func arrayBuiltinReduce(this object.Object, args ...object.Object) object.Object {
 	arrayThis := this.(*object.Array)
 	argn := len(args)
@@ -0,0 +1,128 @@
+package evaluator
+
+import (
+	"math/bits"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+const maxBits = 64
+
+// bitMask validates a (lsb, width) bit range and returns the mask
+// that selects width bits, not yet shifted in the lsb position.
+func bitMask(lsb, width int64) (uint64, *object.RuntimeError) {
+	if lsb < 0 || width <= 0 || lsb+width > maxBits {
+		return 0, newTypeError("required lsb >= 0, width > 0, lsb + width <= %d", maxBits)
+	}
+
+	if width == maxBits {
+		return ^uint64(0), nil
+	}
+	return (uint64(1) << width) - 1, nil
+}
+
+func builtinGetBits(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	lsb := args[1].(*object.Integer)
+	width := args[2].(*object.Integer)
+
+	mask, err := bitMask(lsb.Value, width.Value)
+	if err != nil {
+		return err
+	}
+
+	field := (uint64(value.Value) >> lsb.Value) & mask
+	return &object.Integer{Value: int64(field)}
+}
+
+func builtinSetBits(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	lsb := args[1].(*object.Integer)
+	width := args[2].(*object.Integer)
+	field := args[3].(*object.Integer)
+
+	mask, err := bitMask(lsb.Value, width.Value)
+	if err != nil {
+		return err
+	}
+
+	if width.Value != maxBits && (field.Value < 0 || uint64(field.Value) > mask) {
+		return newTypeError("cannot represent %d with %d bits", field.Value, width.Value)
+	}
+
+	cleared := uint64(value.Value) &^ (mask << lsb.Value)
+	return &object.Integer{Value: int64(cleared | (uint64(field.Value) << lsb.Value))}
+}
+
+// widthValue validates that value can be represented with width bits
+// and returns it as an unsigned integer.
+func widthValue(value, width int64) (uint64, *object.RuntimeError) {
+	mask, err := bitMask(0, width)
+	if err != nil {
+		return 0, err
+	}
+
+	if uint64(value)&^mask != 0 {
+		return 0, newTypeError("cannot represent %d with %d bits", value, width)
+	}
+	return uint64(value), nil
+}
+
+func builtinCountOnes(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	return &object.Integer{Value: int64(bits.OnesCount64(uint64(value.Value)))}
+}
+
+func builtinCountZeros(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	width := args[1].(*object.Integer)
+
+	uValue, err := widthValue(value.Value, width.Value)
+	if err != nil {
+		return err
+	}
+	return &object.Integer{Value: width.Value - int64(bits.OnesCount64(uValue))}
+}
+
+func builtinLeadingZeros(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	width := args[1].(*object.Integer)
+
+	uValue, err := widthValue(value.Value, width.Value)
+	if err != nil {
+		return err
+	}
+	leading := bits.LeadingZeros64(uValue) - (maxBits - int(width.Value))
+	return &object.Integer{Value: int64(leading)}
+}
+
+func builtinReverseBits(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	width := args[1].(*object.Integer)
+
+	switch width.Value {
+	case 8, 16, 32, 64:
+	default:
+		return newTypeError("the width must be one of 8, 16, 32 or 64, got %d", width.Value)
+	}
+
+	uValue, err := widthValue(value.Value, width.Value)
+	if err != nil {
+		return err
+	}
+	reversed := bits.Reverse64(uValue) >> (maxBits - width.Value)
+	return &object.Integer{Value: int64(reversed)}
+}
+
+func builtinByteWidth(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	if value.Value < 0 {
+		return newTypeError("the value must be a positive integer, got %d", value.Value)
+	}
+
+	width := (bits.Len64(uint64(value.Value)) + 7) / 8
+	if width == 0 {
+		width = 1
+	}
+	return &object.Integer{Value: int64(width)}
+}
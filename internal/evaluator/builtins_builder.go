@@ -0,0 +1,66 @@
+package evaluator
+
+import (
+	"github.com/Abathargh/harlock/internal/evaluator/bytes"
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+func builtinBytesBuilder(_ ...object.Object) object.Object {
+	return &object.BytesBuilder{Data: []byte{}}
+}
+
+func builderBuiltinWriteU8(this object.Object, args ...object.Object) object.Object {
+	builder := this.(*object.BytesBuilder)
+	value := args[0].(*object.Integer)
+	if value.Value < 0 || value.Value > maxByte {
+		return newTypeError("the value must be a byte")
+	}
+	builder.Data = append(builder.Data, byte(value.Value))
+	return nil
+}
+
+func builderBuiltinWriteU16(this object.Object, args ...object.Object) object.Object {
+	builder := this.(*object.BytesBuilder)
+	return builderAppend(builder, builtinAsArray(args[0], &object.Integer{Value: 2}, args[1]))
+}
+
+func builderBuiltinWriteBytes(this object.Object, args ...object.Object) object.Object {
+	builder := this.(*object.BytesBuilder)
+	return builderAppend(builder, args[0])
+}
+
+func builderBuiltinWriteString(this object.Object, args ...object.Object) object.Object {
+	builder := this.(*object.BytesBuilder)
+	return builderAppend(builder, stringBuiltinEncode(args[0], args[1]))
+}
+
+func builderBuiltinSize(this object.Object, _ ...object.Object) object.Object {
+	builder := this.(*object.BytesBuilder)
+	return &object.Integer{Value: int64(len(builder.Data))}
+}
+
+func builderBuiltinBuild(this object.Object, _ ...object.Object) object.Object {
+	builder := this.(*object.BytesBuilder)
+
+	// the file gets its own copy, so that it is not
+	// affected by the writes following the build
+	data := make([]byte, len(builder.Data))
+	copy(data, builder.Data)
+	return object.NewBytesFile("", inMemoryPerms, int64(len(data)), bytes.New(data))
+}
+
+// builderAppend appends the passed byte array, which is the outcome of
+// encoding the arguments of a write method, to the builder buffer. Any
+// other object is an error raised while encoding, which is returned as is.
+func builderAppend(builder *object.BytesBuilder, encoded object.Object) object.Object {
+	if _, isArray := encoded.(*object.Array); !isArray {
+		return encoded
+	}
+
+	data, err := writtenBytes(encoded)
+	if err != nil {
+		return err
+	}
+	builder.Data = append(builder.Data, data...)
+	return nil
+}
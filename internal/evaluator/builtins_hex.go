@@ -1,9 +1,18 @@
 package evaluator
 
-import "github.com/Abathargh/harlock/internal/object"
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"strings"
+
+	"github.com/Abathargh/harlock/internal/object"
+	"github.com/Abathargh/harlock/pkg/hex"
+)
 
 const (
 	maxByte = (1 << 8) - 1
+	maxWord = (1 << 16) - 1
 )
 
 func hexBuiltinRecord(this object.Object, args ...object.Object) object.Object {
@@ -17,6 +26,23 @@ func hexBuiltinRecord(this object.Object, args ...object.Object) object.Object {
 	return &object.String{Value: readData.AsString()}
 }
 
+func hexBuiltinRecords(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	start := args[0].(*object.Integer)
+	end := args[1].(*object.Integer)
+	records, err := hexThis.File.Records(int(start.Value), int(end.Value))
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	retVal := &object.Array{Elements: make([]object.Object, len(records))}
+	for idx, record := range records {
+		retVal.Elements[idx] = &object.String{Value: record.AsString()}
+	}
+	return retVal
+}
+
 func hexBuiltinSize(this object.Object, _ ...object.Object) object.Object {
 	hexThis := this.(*object.HexFile)
 	size := hexThis.File.Size()
@@ -50,22 +76,66 @@ func hexBuiltinReadAt(this object.Object, args ...object.Object) object.Object {
 	return retVal
 }
 
+func hexBuiltinRegionHash(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	pos := args[0].(*object.Integer)
+	size := args[1].(*object.Integer)
+	algo := args[2].(*object.String)
+	if pos.Value < 0 || size.Value < 0 {
+		return newTypeError("position and size must be positive integers")
+	}
+
+	hasher := newHasher(algo.Value)
+	if hasher == nil {
+		return newTypeError("unsupported hash function %s", algo.Value)
+	}
+
+	region, err := hexThis.File.ReadAt(uint32(pos.Value), int(size.Value))
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	hasher.Write(region)
+	return bytestoIntarray(hasher.Sum(nil))
+}
+
+func hexBuiltinEntropy(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	pos := args[0].(*object.Integer)
+	size := args[1].(*object.Integer)
+	if pos.Value < 0 || size.Value < 0 {
+		return newHexError("position and size must be positive integers")
+	}
+
+	region, err := hexThis.File.ReadAt(uint32(pos.Value), int(size.Value))
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	var histogram byteHistogram
+	_, _ = histogram.Write(region)
+	return &object.Integer{Value: histogram.Entropy()}
+}
+
+func hexBuiltinInsertAt(_ object.Object, _ ...object.Object) object.Object {
+	return newHexError("inserting data is not supported for hex files, since it " +
+		"would shift the addresses of the following records: use write_at to " +
+		"overwrite data, or convert the file to a bytes file")
+}
+
 func hexBuiltinWriteAt(this object.Object, args ...object.Object) object.Object {
 	hexThis := this.(*object.HexFile)
 
 	pos := args[0].(*object.Integer)
-	data := args[1].(*object.Array)
 	if pos.Value < 0 {
 		return newTypeError("address must be a positive integer")
 	}
 
-	byteArr := make([]byte, len(data.Elements))
-	for idx, elem := range data.Elements {
-		intElem, isInt := elem.(*object.Integer)
-		if !isInt || intElem.Value > maxByte || intElem.Value < 0 {
-			return newTypeError("data must be an array of 1 byte positive integers")
-		}
-		byteArr[idx] = byte(intElem.Value)
+	byteArr, typeErr := writtenBytes(args[1])
+	if typeErr != nil {
+		return typeErr
 	}
 
 	err := hexThis.File.WriteAt(uint32(pos.Value), byteArr)
@@ -74,3 +144,245 @@ func hexBuiltinWriteAt(this object.Object, args ...object.Object) object.Object
 	}
 	return nil
 }
+
+func hexBuiltinCopyRegion(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	src := args[0].(*object.Integer)
+	dst := args[1].(*object.Integer)
+	size := args[2].(*object.Integer)
+	if src.Value < 0 || dst.Value < 0 || size.Value < 0 {
+		return newTypeError("addresses and size must be positive integers")
+	}
+
+	// ReadAt returns a copy of the region, so that overlapping
+	// regions are not corrupted while writing
+	region, err := hexThis.File.ReadAt(uint32(src.Value), int(size.Value))
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	err = hexThis.File.WriteAt(uint32(dst.Value), region)
+	if err != nil {
+		return newHexError("%s", err)
+	}
+	return nil
+}
+
+func hexBuiltinFindAll(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+	pattern := args[0].(*object.Array)
+
+	patternArr := make([]byte, len(pattern.Elements))
+	if err := intArrayToBytes(pattern, patternArr); err != nil {
+		return err
+	}
+
+	addresses, err := hexThis.File.FindAll(patternArr)
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	retVal := &object.Array{Elements: make([]object.Object, len(addresses))}
+	for idx, address := range addresses {
+		retVal.Elements[idx] = &object.Integer{Value: int64(address)}
+	}
+	return retVal
+}
+
+func hexBuiltinScanStrings(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	minLen := args[0].(*object.Integer)
+	if minLen.Value < 1 {
+		return newTypeError("the minimum length must be a positive integer")
+	}
+
+	blocks, err := hexThis.File.DataBlocks()
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	retVal := &object.Array{Elements: []object.Object{}}
+	for _, block := range blocks {
+		runs := printableRuns(block.Data, int64(block.Address), int(minLen.Value))
+		retVal.Elements = append(retVal.Elements, runs...)
+	}
+	return retVal
+}
+
+func hexBuiltinFillPattern(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	pos := args[0].(*object.Integer)
+	size := args[1].(*object.Integer)
+	pattern := args[2].(*object.Array)
+	if pos.Value < 0 || size.Value < 0 {
+		return newTypeError("address and size must be positive integers")
+	}
+
+	if len(pattern.Elements) == 0 {
+		return newHexError("the fill pattern cannot be empty")
+	}
+
+	if err := checkHexRegion(hexThis, pos.Value, size.Value); err != nil {
+		return err
+	}
+
+	filled, typeErr := patternBytes(int(size.Value), pattern)
+	if typeErr != nil {
+		return typeErr
+	}
+
+	err := hexThis.File.WriteAt(uint32(pos.Value), filled)
+	if err != nil {
+		return newHexError("%s", err)
+	}
+	return nil
+}
+
+func hexBuiltinApplyPatch(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	edits, typeErr := patchEdits(args[0].(*object.Array))
+	if typeErr != nil {
+		return typeErr
+	}
+
+	// all the edits are checked before writing, so that
+	// an invalid one leaves the file untouched
+	for idx, edit := range edits {
+		if edit.offset > math.MaxUint32 {
+			return newHexError("patch[%d]: %s", idx, hex.AccessOutOfBounds)
+		}
+
+		if _, err := hexThis.File.ReadAt(uint32(edit.offset), len(edit.data)); err != nil {
+			return newHexError("patch[%d]: %s", idx, err)
+		}
+	}
+
+	for _, edit := range edits {
+		if err := hexThis.File.WriteAt(uint32(edit.offset), edit.data); err != nil {
+			return newHexError("%s", err)
+		}
+	}
+	return nil
+}
+
+// checkHexRegion returns an error if the [pos, pos+size) region exceeds the
+// address range of the hex file, without overflowing for huge sizes. Regions
+// within the range may still span gaps, which are detected when accessed.
+func checkHexRegion(hexFile *object.HexFile, pos, size int64) *object.RuntimeError {
+	_, maxAddr, err := hexFile.File.AddressRange()
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	if pos > int64(maxAddr) || size > int64(maxAddr)-pos+1 {
+		return newHexError("%s", hex.AccessOutOfBounds)
+	}
+	return nil
+}
+
+func hexBuiltinAbsAddress(_ object.Object, args ...object.Object) object.Object {
+	segment := args[0].(*object.Integer)
+	offset := args[1].(*object.Integer)
+	if segment.Value < 0 || segment.Value > maxWord || offset.Value < 0 || offset.Value > maxWord {
+		return newTypeError("segment and offset must be 2 bytes positive integers")
+	}
+
+	address := hex.AbsoluteAddress(uint16(segment.Value), uint16(offset.Value))
+	return &object.Integer{Value: int64(address)}
+}
+
+func hexBuiltinRecordAddress(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	idx := args[0].(*object.Integer)
+	address, err := hexThis.File.RecordAddress(int(idx.Value))
+	if err != nil {
+		return newHexError("%s", err)
+	}
+	return &object.Integer{Value: int64(address)}
+}
+
+func hexBuiltinExtendedLinearBase(this object.Object, _ ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	bases, err := hexThis.File.BaseAddresses()
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	mappings := make(map[object.HashKey]object.HashPair)
+	for idx, base := range bases {
+		key := &object.Integer{Value: int64(idx)}
+		mappings[key.HashKey()] = object.HashPair{Key: key, Value: &object.Integer{Value: int64(base)}}
+	}
+	return &object.Map{Mappings: mappings}
+}
+
+func hexBuiltinMinAddress(this object.Object, _ ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+	minAddr, _, err := hexThis.File.AddressRange()
+	if err != nil {
+		return newHexError("%s", err)
+	}
+	return &object.Integer{Value: int64(minAddr)}
+}
+
+func hexBuiltinMaxAddress(this object.Object, _ ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+	_, maxAddr, err := hexThis.File.AddressRange()
+	if err != nil {
+		return newHexError("%s", err)
+	}
+	return &object.Integer{Value: int64(maxAddr)}
+}
+
+func hexBuiltinClone(this object.Object, _ ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+	clone := object.NewHexFile(hexThis.Name(), hexThis.Perms(), hexThis.File.Clone())
+	clone.SetReadOnly(hexThis.ReadOnly())
+	return clone
+}
+
+func hexBuiltinEquals(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+	other := args[0].(*object.HexFile)
+	return getBoolReference(bytes.Equal(hexThis.AsBytes(), other.AsBytes()))
+}
+
+func hexBuiltinWriteRecord(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+	recordStr := args[0].(*object.String)
+
+	reader := bufio.NewReader(strings.NewReader(strings.TrimSpace(recordStr.Value) + "\n"))
+	record, err := hex.ParseRecord(reader)
+	if err != nil {
+		return newHexError("%s", err)
+	}
+
+	if reader.Buffered() != 0 {
+		return newHexError("%s", hex.WrongRecordFormatErr)
+	}
+
+	if err := hexThis.File.InsertRecord(record); err != nil {
+		return newHexError("%s", err)
+	}
+	return nil
+}
+
+func hexBuiltinFillUnused(this object.Object, args ...object.Object) object.Object {
+	hexThis := this.(*object.HexFile)
+
+	fill := args[0].(*object.Integer)
+	if fill.Value < 0 || fill.Value > maxByte {
+		return newTypeError("the fill value must be a byte")
+	}
+
+	if err := hexThis.File.FillUnused(byte(fill.Value)); err != nil {
+		return newHexError("%s", err)
+	}
+	return nil
+}
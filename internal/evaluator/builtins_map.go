@@ -1,6 +1,10 @@
 package evaluator
 
-import "github.com/Abathargh/harlock/internal/object"
+import (
+	"sort"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
 
 func mapBuiltinSet(this object.Object, args ...object.Object) object.Object {
 	mapThis := this.(*object.Map)
@@ -25,3 +29,52 @@ func mapBuiltinPop(this object.Object, args ...object.Object) object.Object {
 	delete(mapThis.Mappings, hashableKey.HashKey())
 	return nil
 }
+
+func mapBuiltinEntries(this object.Object, _ ...object.Object) object.Object {
+	pairs := sortedPairs(this.(*object.Map))
+	entries := make([]object.Object, len(pairs))
+	for idx, pair := range pairs {
+		entries[idx] = &object.Array{Elements: []object.Object{pair.Key, pair.Value}}
+	}
+	return &object.Array{Elements: entries}
+}
+
+func mapBuiltinToArray(this object.Object, _ ...object.Object) object.Object {
+	pairs := sortedPairs(this.(*object.Map))
+	values := make([]object.Object, len(pairs))
+	for idx, pair := range pairs {
+		values[idx] = pair.Value
+	}
+	return &object.Array{Elements: values}
+}
+
+// sortedPairs returns the pairs of the map, sorted by key.
+func sortedPairs(mapObj *object.Map) []object.HashPair {
+	pairs := make([]object.HashPair, 0, len(mapObj.Mappings))
+	for _, pair := range mapObj.Mappings {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return lessObject(pairs[i].Key, pairs[j].Key)
+	})
+	return pairs
+}
+
+// lessObject defines a stable order for hashable objects: objects are
+// grouped by type first, and then ordered by value within each type.
+func lessObject(a, b object.Object) bool {
+	if a.Type() != b.Type() {
+		return a.Type() < b.Type()
+	}
+
+	switch aValue := a.(type) {
+	case *object.Integer:
+		return aValue.Value < b.(*object.Integer).Value
+	case *object.Boolean:
+		return !aValue.Value && b.(*object.Boolean).Value
+	case *object.String:
+		return aValue.Value < b.(*object.String).Value
+	default:
+		return a.Inspect() < b.Inspect()
+	}
+}
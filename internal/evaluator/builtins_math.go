@@ -0,0 +1,98 @@
+package evaluator
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+func builtinClamp(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	lower := args[1].(*object.Integer)
+	upper := args[2].(*object.Integer)
+
+	if lower.Value > upper.Value {
+		return newTypeError("the lower bound (%d) must not be greater than the upper bound (%d)",
+			lower.Value, upper.Value)
+	}
+
+	switch {
+	case value.Value < lower.Value:
+		return &object.Integer{Value: lower.Value}
+	case value.Value > upper.Value:
+		return &object.Integer{Value: upper.Value}
+	default:
+		return &object.Integer{Value: value.Value}
+	}
+}
+
+func builtinGcd(args ...object.Object) object.Object {
+	a := args[0].(*object.Integer)
+	b := args[1].(*object.Integer)
+
+	result := gcd(absValue(a.Value), absValue(b.Value))
+	if result > math.MaxInt64 {
+		return newTypeError("gcd(%d, %d) overflows an integer", a.Value, b.Value)
+	}
+	return &object.Integer{Value: int64(result)}
+}
+
+func builtinLcm(args ...object.Object) object.Object {
+	a := args[0].(*object.Integer)
+	b := args[1].(*object.Integer)
+
+	absA, absB := absValue(a.Value), absValue(b.Value)
+	if absA == 0 || absB == 0 {
+		return &object.Integer{Value: 0}
+	}
+
+	hi, result := bits.Mul64(absA/gcd(absA, absB), absB)
+	if hi != 0 || result > math.MaxInt64 {
+		return newTypeError("lcm(%d, %d) overflows an integer", a.Value, b.Value)
+	}
+	return &object.Integer{Value: int64(result)}
+}
+
+func builtinAlignUp(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	alignment := args[1].(*object.Integer)
+	if !isPowerOfTwo(alignment.Value) {
+		return newTypeError("the alignment must be a power of two, got %d", alignment.Value)
+	}
+
+	mask := alignment.Value - 1
+	if value.Value > math.MaxInt64-mask {
+		return newTypeError("aligning %d to %d overflows an integer", value.Value, alignment.Value)
+	}
+	return &object.Integer{Value: (value.Value + mask) &^ mask}
+}
+
+func builtinAlignDown(args ...object.Object) object.Object {
+	value := args[0].(*object.Integer)
+	alignment := args[1].(*object.Integer)
+	if !isPowerOfTwo(alignment.Value) {
+		return newTypeError("the alignment must be a power of two, got %d", alignment.Value)
+	}
+	return &object.Integer{Value: value.Value &^ (alignment.Value - 1)}
+}
+
+func isPowerOfTwo(n int64) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// absValue returns the absolute value of n as an unsigned integer, so
+// that the absolute value of the minimum integer can be represented.
+func absValue(n int64) uint64 {
+	if n < 0 {
+		return uint64(-n)
+	}
+	return uint64(n)
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
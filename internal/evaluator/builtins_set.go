@@ -1,6 +1,10 @@
 package evaluator
 
-import "github.com/Abathargh/harlock/internal/object"
+import (
+	"sort"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
 
 func setBuiltinAdd(this object.Object, args ...object.Object) object.Object {
 	setThis := this.(*object.Set)
@@ -27,3 +31,43 @@ func setBuiltinRemove(this object.Object, args ...object.Object) object.Object {
 	delete(setThis.Elements, key)
 	return nil
 }
+
+func setBuiltinToArray(this object.Object, _ ...object.Object) object.Object {
+	setThis := this.(*object.Set)
+
+	elements := make([]object.Object, 0, len(setThis.Elements))
+	for _, elem := range setThis.Elements {
+		elements = append(elements, elem)
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		return lessObject(elements[i], elements[j])
+	})
+	return &object.Array{Elements: elements}
+}
+
+func setBuiltinIsSubset(this object.Object, args ...object.Object) object.Object {
+	setThis := this.(*object.Set)
+	other := args[0].(*object.Set)
+	return getBoolReference(isSubset(setThis, other))
+}
+
+func setBuiltinIsSuperset(this object.Object, args ...object.Object) object.Object {
+	setThis := this.(*object.Set)
+	other := args[0].(*object.Set)
+	return getBoolReference(isSubset(other, setThis))
+}
+
+// isSubset returns whether every element of the first set is also
+// contained in the second one.
+func isSubset(set, other *object.Set) bool {
+	if len(set.Elements) > len(other.Elements) {
+		return false
+	}
+
+	for key := range set.Elements {
+		if _, found := other.Elements[key]; !found {
+			return false
+		}
+	}
+	return true
+}
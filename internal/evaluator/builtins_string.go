@@ -0,0 +1,175 @@
+package evaluator
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+const (
+	defaultPadding = " "
+
+	asciiEncoding  = "ascii"
+	utf8Encoding   = "utf8"
+	latin1Encoding = "latin1"
+
+	maxASCII  = 0x7f
+	maxLatin1 = 0xff
+)
+
+func stringBuiltinSubstring(this object.Object, args ...object.Object) object.Object {
+	str := this.(*object.String)
+	start := args[0].(*object.Integer).Value
+	end := args[1].(*object.Integer).Value
+
+	substr, ok := substring(str.Value, start, end)
+	if !ok {
+		return newTypeError("indices [%d:%d] out of range for a string of length %d",
+			start, end, len(str.Value))
+	}
+	return &object.String{Value: substr}
+}
+
+func stringBuiltinPadStart(this object.Object, args ...object.Object) object.Object {
+	return padString(this.(*object.String), true, args...)
+}
+
+func stringBuiltinPadEnd(this object.Object, args ...object.Object) object.Object {
+	return padString(this.(*object.String), false, args...)
+}
+
+func padString(str *object.String, atStart bool, args ...object.Object) object.Object {
+	width := args[0].(*object.Integer).Value
+	if width < 0 {
+		return newTypeError("the width must be a positive integer")
+	}
+
+	fill := defaultPadding
+	if len(args) > 1 {
+		fillArg, isString := args[1].(*object.String)
+		if !isString || utf8.RuneCountInString(fillArg.Value) != 1 {
+			return newTypeError("the fill value must be a one-character string")
+		}
+		fill = fillArg.Value
+	}
+
+	missing := int(width) - utf8.RuneCountInString(str.Value)
+	if missing <= 0 {
+		return &object.String{Value: str.Value}
+	}
+
+	padding := strings.Repeat(fill, missing)
+	if atStart {
+		return &object.String{Value: padding + str.Value}
+	}
+	return &object.String{Value: str.Value + padding}
+}
+
+func stringBuiltinEncode(this object.Object, args ...object.Object) object.Object {
+	str := this.(*object.String)
+	encoding := args[0].(*object.String).Value
+
+	var data []byte
+	switch encoding {
+	case asciiEncoding, latin1Encoding:
+		limit := rune(maxASCII)
+		if encoding == latin1Encoding {
+			limit = maxLatin1
+		}
+
+		data = make([]byte, 0, len(str.Value))
+		for idx, r := range str.Value {
+			if r > limit {
+				return newTypeError("character %q at index %d cannot be encoded as %s",
+					r, idx, encoding)
+			}
+			data = append(data, byte(r))
+		}
+	case utf8Encoding:
+		data = []byte(str.Value)
+	default:
+		return unsupportedEncoding(encoding)
+	}
+
+	elements := make([]object.Object, len(data))
+	for idx, b := range data {
+		elements[idx] = &object.Integer{Value: int64(b)}
+	}
+	return &object.Array{Elements: elements}
+}
+
+// substring returns the [start:end) byte range of str, if the indices are
+// within its bounds.
+func substring(str string, start, end int64) (string, bool) {
+	if start < 0 || end < start || end > int64(len(str)) {
+		return "", false
+	}
+	return str[start:end], true
+}
+
+func builtinToString(args ...object.Object) object.Object {
+	data := make([]byte, len(args[0].(*object.Array).Elements))
+	if err := intArrayToBytes(args[0].(*object.Array), data); err != nil {
+		return err
+	}
+
+	encoding := utf8Encoding
+	if len(args) > 1 {
+		encodingObj, isString := args[1].(*object.String)
+		if !isString {
+			return newTypeError("the encoding must be a string")
+		}
+		encoding = encodingObj.Value
+	}
+
+	if len(args) > 2 {
+		nullTerminated, isBool := args[2].(*object.Boolean)
+		if !isBool {
+			return newTypeError("the null-terminated flag must be a bool")
+		}
+
+		if nullIdx := bytes.IndexByte(data, 0); nullTerminated.Value && nullIdx != -1 {
+			data = data[:nullIdx]
+		}
+	}
+
+	decoded, err := decodeString(data, encoding)
+	if err != nil {
+		return err
+	}
+	return &object.String{Value: decoded}
+}
+
+// decodeString decodes the passed bytes using the passed encoding,
+// failing on any byte sequence that is invalid for that encoding.
+func decodeString(data []byte, encoding string) (string, *object.RuntimeError) {
+	switch encoding {
+	case asciiEncoding:
+		for idx, b := range data {
+			if b > maxASCII {
+				return "", newTypeError("invalid ascii byte 0x%02x at index %d", b, idx)
+			}
+		}
+		return string(data), nil
+	case utf8Encoding:
+		if !utf8.Valid(data) {
+			return "", newTypeError("invalid utf8 byte sequence")
+		}
+		return string(data), nil
+	case latin1Encoding:
+		runes := make([]rune, len(data))
+		for idx, b := range data {
+			runes[idx] = rune(b)
+		}
+		return string(runes), nil
+	default:
+		return "", unsupportedEncoding(encoding)
+	}
+}
+
+func unsupportedEncoding(encoding string) *object.RuntimeError {
+	return newTypeError("unsupported encoding %q, expected one of %q, %q, %q",
+		encoding, asciiEncoding, utf8Encoding, latin1Encoding)
+}
@@ -1,11 +1,23 @@
 package bytes
 
-import "io"
+import (
+	"bytes"
+	"io"
+)
 
 type File struct {
 	bytes []byte
 }
 
+// New constructs a new File holding a copy of the passed data
+func New(data []byte) *File {
+	contents := make([]byte, len(data))
+	copy(contents, data)
+	return &File{
+		bytes: contents,
+	}
+}
+
 // ReadAll constructs a new File from a reader stream
 func ReadAll(reader io.Reader) (*File, error) {
 	contents, err := io.ReadAll(reader)
@@ -17,6 +29,32 @@ func ReadAll(reader io.Reader) (*File, error) {
 	}, nil
 }
 
+// Clone returns a copy of the bytes file, which can be modified
+// independently of the original one
+func (bf *File) Clone() *File {
+	return New(bf.bytes)
+}
+
+// View returns a File sharing the underlying storage of the bytes file
+// in the [start, end) range: changes to the original are reflected in
+// the view, which cannot grow past the range end. Inserting or removing
+// data from the original moves it to a new storage, detaching its views,
+// which keep the contents they had at that time.
+func (bf *File) View(start, end int) (*File, error) {
+	if start < 0 || start > end || end > len(bf.bytes) {
+		return nil, AccessOutOfBounds
+	}
+	return &File{
+		bytes: bf.bytes[start:end:end],
+	}, nil
+}
+
+// Reader returns a reader over the contents of the bytes file, which
+// does not copy them
+func (bf *File) Reader() io.Reader {
+	return bytes.NewReader(bf.bytes)
+}
+
 // WriteAt implements random access in write mode for a bytes file
 func (bf *File) WriteAt(position int, data []byte) error {
 	if position+len(data) > len(bf.bytes) {
@@ -26,16 +64,90 @@ func (bf *File) WriteAt(position int, data []byte) error {
 	return nil
 }
 
+// InsertAt inserts data at the passed position, shifting the following
+// bytes towards the end of the file, which grows accordingly. The file
+// gets a new storage, so the views taken from it are detached.
+func (bf *File) InsertAt(position int, data []byte) error {
+	if position < 0 || position > len(bf.bytes) {
+		return AccessOutOfBounds
+	}
+
+	contents := make([]byte, 0, len(bf.bytes)+len(data))
+	contents = append(contents, bf.bytes[:position]...)
+	contents = append(contents, data...)
+	contents = append(contents, bf.bytes[position:]...)
+	bf.bytes = contents
+	return nil
+}
+
+// RemoveRange deletes size bytes starting from the passed position,
+// shifting the following bytes towards the start of the file, which
+// shrinks accordingly. The file gets a new storage, so the views taken
+// from it are detached.
+func (bf *File) RemoveRange(position int, size int) error {
+	if position < 0 || size < 0 || position > len(bf.bytes) || size > len(bf.bytes)-position {
+		return AccessOutOfBounds
+	}
+
+	contents := make([]byte, 0, len(bf.bytes)-size)
+	contents = append(contents, bf.bytes[:position]...)
+	contents = append(contents, bf.bytes[position+size:]...)
+	bf.bytes = contents
+	return nil
+}
+
+// Len returns the size of the bytes file
+func (bf *File) Len() int {
+	return len(bf.bytes)
+}
+
 // ReadAt implements random access in read mode for a bytes file
 func (bf *File) ReadAt(position int, size int) ([]byte, error) {
 	if size <= 0 {
 		return nil, nil
 	}
 
-	if position+size > len(bf.bytes) {
+	if position > len(bf.bytes) || size > len(bf.bytes)-position {
 		return nil, AccessOutOfBounds
 	}
 	buf := make([]byte, size)
 	copy(buf, bf.bytes[position:position+size])
 	return buf, nil
 }
+
+// FindAll returns the offsets of all the non-overlapping
+// occurrences of pattern within the bytes file
+func (bf *File) FindAll(pattern []byte) ([]int, error) {
+	if len(pattern) == 0 {
+		return nil, EmptyPatternErr
+	}
+
+	offsets := []int{}
+	for start := 0; ; {
+		idx := bytes.Index(bf.bytes[start:], pattern)
+		if idx < 0 {
+			return offsets, nil
+		}
+		offsets = append(offsets, start+idx)
+		start += idx + len(pattern)
+	}
+}
+
+// Replace substitutes every non-overlapping occurrence of old with
+// replacement, in place, returning the number of replaced occurrences.
+// The two patterns must have the same length.
+func (bf *File) Replace(old, replacement []byte) (int, error) {
+	if len(old) != len(replacement) {
+		return 0, PatternLengthErr
+	}
+
+	offsets, err := bf.FindAll(old)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, offset := range offsets {
+		copy(bf.bytes[offset:], replacement)
+	}
+	return len(offsets), nil
+}
@@ -3,6 +3,7 @@ package bytes
 import (
 	"bytes"
 	"errors"
+	"math"
 	"testing"
 )
 
@@ -64,6 +65,7 @@ func TestFile_ReadAt(t *testing.T) {
 		{[]byte{0xca, 0xff, 0xe0}, 1, 3, AccessOutOfBounds, nil},
 		{[]byte{0xca, 0xff, 0xe0}, 2, 3, AccessOutOfBounds, nil},
 		{[]byte{0xca, 0xff, 0xe0}, 3, 3, AccessOutOfBounds, nil},
+		{[]byte{0xca, 0xff, 0xe0}, 1, math.MaxInt, AccessOutOfBounds, nil},
 	}
 
 	for idx, testCase := range tests {
@@ -91,3 +93,194 @@ func TestFile_ReadAt(t *testing.T) {
 		}
 	}
 }
+
+func TestFile_FindAll(t *testing.T) {
+	tests := []struct {
+		input    []byte
+		pattern  []byte
+		err      error
+		expected []int
+	}{
+		{[]byte{0xde, 0xad, 0x00, 0xde, 0xad}, []byte{0xde, 0xad}, nil, []int{0, 3}},
+		{[]byte{0xaa, 0xaa, 0xaa}, []byte{0xaa, 0xaa}, nil, []int{0}},
+		{[]byte{0x01, 0x02, 0x03}, []byte{0x02, 0x01}, nil, []int{}},
+		{[]byte{0x01, 0x02, 0x03}, []byte{}, EmptyPatternErr, nil},
+	}
+
+	for idx, testCase := range tests {
+		offsets, err := New(testCase.input).FindAll(testCase.pattern)
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("case %d: expected err %v, got %v", idx, testCase.err, err)
+			continue
+		}
+
+		if len(offsets) != len(testCase.expected) {
+			t.Errorf("case %d: expected %v, got %v", idx, testCase.expected, offsets)
+			continue
+		}
+
+		for oIdx, offset := range offsets {
+			if offset != testCase.expected[oIdx] {
+				t.Errorf("case %d: expected %v, got %v", idx, testCase.expected, offsets)
+				break
+			}
+		}
+	}
+}
+
+func TestFile_Replace(t *testing.T) {
+	tests := []struct {
+		input       []byte
+		old         []byte
+		replacement []byte
+		err         error
+		count       int
+		expected    []byte
+	}{
+		{[]byte{0x00, 0xca, 0xfe}, []byte{0xca, 0xfe}, []byte{0xbe, 0xef}, nil, 1, []byte{0x00, 0xbe, 0xef}},
+		{[]byte{0xaa, 0xaa, 0xaa, 0xaa}, []byte{0xaa}, []byte{0xbb}, nil, 4, []byte{0xbb, 0xbb, 0xbb, 0xbb}},
+		{[]byte{0x01, 0x02}, []byte{0x03}, []byte{0x04}, nil, 0, []byte{0x01, 0x02}},
+		{[]byte{0x01, 0x02}, []byte{0x01, 0x02}, []byte{0x03}, PatternLengthErr, 0, []byte{0x01, 0x02}},
+		{[]byte{0x01, 0x02}, []byte{}, []byte{}, EmptyPatternErr, 0, []byte{0x01, 0x02}},
+	}
+
+	for idx, testCase := range tests {
+		file := New(testCase.input)
+		count, err := file.Replace(testCase.old, testCase.replacement)
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("case %d: expected err %v, got %v", idx, testCase.err, err)
+			continue
+		}
+
+		if count != testCase.count {
+			t.Errorf("case %d: expected %d replacements, got %d", idx, testCase.count, count)
+		}
+
+		if !bytes.Equal(file.bytes, testCase.expected) {
+			t.Errorf("case %d: expected %v, got %v", idx, testCase.expected, file.bytes)
+		}
+	}
+}
+
+func TestFile_View(t *testing.T) {
+	tests := []struct {
+		start    int
+		end      int
+		err      error
+		expected []byte
+	}{
+		{1, 3, nil, []byte{0x02, 0x03}},
+		{0, 4, nil, []byte{0x01, 0x02, 0x03, 0x04}},
+		{2, 2, nil, []byte{}},
+		{3, 5, AccessOutOfBounds, nil},
+		{3, 1, AccessOutOfBounds, nil},
+		{-1, 1, AccessOutOfBounds, nil},
+	}
+
+	for idx, testCase := range tests {
+		file := New([]byte{0x01, 0x02, 0x03, 0x04})
+		view, err := file.View(testCase.start, testCase.end)
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("case %d: expected err %v, got %v", idx, testCase.err, err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if !bytes.Equal(view.bytes, testCase.expected) {
+			t.Errorf("case %d: expected %v, got %v", idx, testCase.expected, view.bytes)
+		}
+
+		// the view must reflect the changes to the original file
+		if len(testCase.expected) > 0 {
+			_ = file.WriteAt(testCase.start, []byte{0xff})
+			if view.bytes[0] != 0xff {
+				t.Errorf("case %d: expected the view to share the file storage", idx)
+			}
+		}
+	}
+}
+
+func TestFile_InsertAt(t *testing.T) {
+	tests := []struct {
+		position int
+		data     []byte
+		err      error
+		expected []byte
+	}{
+		{2, []byte{0xaa, 0xbb}, nil, []byte{0x01, 0x02, 0xaa, 0xbb, 0x03, 0x04}},
+		{4, []byte{0xaa}, nil, []byte{0x01, 0x02, 0x03, 0x04, 0xaa}},
+		{0, []byte{0xaa}, nil, []byte{0xaa, 0x01, 0x02, 0x03, 0x04}},
+		{1, []byte{}, nil, []byte{0x01, 0x02, 0x03, 0x04}},
+		{5, []byte{0xaa}, AccessOutOfBounds, []byte{0x01, 0x02, 0x03, 0x04}},
+		{-1, []byte{0xaa}, AccessOutOfBounds, []byte{0x01, 0x02, 0x03, 0x04}},
+	}
+
+	for idx, testCase := range tests {
+		file := New([]byte{0x01, 0x02, 0x03, 0x04})
+		err := file.InsertAt(testCase.position, testCase.data)
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("case %d: expected err %v, got %v", idx, testCase.err, err)
+			continue
+		}
+
+		if file.Len() != len(testCase.expected) || !bytes.Equal(file.bytes, testCase.expected) {
+			t.Errorf("case %d: expected %v, got %v", idx, testCase.expected, file.bytes)
+		}
+	}
+}
+
+func TestFile_ResizingDetachesViews(t *testing.T) {
+	file := New([]byte{0x01, 0x02, 0x03, 0x04})
+	inserted, _ := file.View(0, 4)
+	if err := file.InsertAt(0, []byte{0x09}); err != nil {
+		t.Fatalf("unexpected error inserting: %v", err)
+	}
+
+	removed, _ := file.View(0, 5)
+	if err := file.RemoveRange(0, 1); err != nil {
+		t.Fatalf("unexpected error removing: %v", err)
+	}
+
+	_ = file.WriteAt(0, []byte{0xff})
+	if !bytes.Equal(inserted.bytes, []byte{0x01, 0x02, 0x03, 0x04}) {
+		t.Errorf("expected the view to keep its contents after InsertAt, got %v", inserted.bytes)
+	}
+
+	if !bytes.Equal(removed.bytes, []byte{0x09, 0x01, 0x02, 0x03, 0x04}) {
+		t.Errorf("expected the view to keep its contents after RemoveRange, got %v", removed.bytes)
+	}
+}
+
+func TestFile_RemoveRange(t *testing.T) {
+	tests := []struct {
+		position int
+		size     int
+		err      error
+		expected []byte
+	}{
+		{1, 2, nil, []byte{0x01, 0x04}},
+		{2, 2, nil, []byte{0x01, 0x02}},
+		{0, 4, nil, []byte{}},
+		{1, 0, nil, []byte{0x01, 0x02, 0x03, 0x04}},
+		{3, 2, AccessOutOfBounds, []byte{0x01, 0x02, 0x03, 0x04}},
+		{-1, 1, AccessOutOfBounds, []byte{0x01, 0x02, 0x03, 0x04}},
+		{math.MaxInt, 2, AccessOutOfBounds, []byte{0x01, 0x02, 0x03, 0x04}},
+		{1, math.MaxInt, AccessOutOfBounds, []byte{0x01, 0x02, 0x03, 0x04}},
+	}
+
+	for idx, testCase := range tests {
+		file := New([]byte{0x01, 0x02, 0x03, 0x04})
+		err := file.RemoveRange(testCase.position, testCase.size)
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("case %d: expected err %v, got %v", idx, testCase.err, err)
+			continue
+		}
+
+		if file.Len() != len(testCase.expected) || !bytes.Equal(file.bytes, testCase.expected) {
+			t.Errorf("case %d: expected %v, got %v", idx, testCase.expected, file.bytes)
+		}
+	}
+}
@@ -18,4 +18,6 @@ func CustomError(original FileError, msg string, args ...any) error {
 
 const (
 	AccessOutOfBounds = FileError("cannot access the hex file out of the length of the encoded program")
+	EmptyPatternErr   = FileError("the pattern to search for cannot be empty")
+	PatternLengthErr  = FileError("the replacement must have the same length as the pattern")
 )
@@ -4,8 +4,52 @@ import (
 	"bytes"
 	"debug/elf"
 	"io"
+	"math"
 )
 
+// offsets of the section header related fields within the elf header and
+// of the fields within a section header, for both elf classes
+const (
+	shName  = 0x00
+	shType  = 0x04
+	shFlags = 0x08
+
+	shoff32       = 0x20
+	shentsize32   = 0x2e
+	shnum32       = 0x30
+	shstrndx32    = 0x32
+	shOffset32    = 0x10
+	shSize32      = 0x14
+	shAddralign32 = 0x20
+
+	shoff64       = 0x28
+	shentsize64   = 0x3a
+	shnum64       = 0x3c
+	shstrndx64    = 0x3e
+	shOffset64    = 0x18
+	shSize64      = 0x20
+	shAddralign64 = 0x30
+
+	shdrSize32 = 0x28
+	shdrSize64 = 0x40
+)
+
+// Relocation represents an entry of a relocation section
+type Relocation struct {
+	Offset uint64
+	Symbol string
+	Type   uint32
+}
+
+// DynamicEntry represents a tag/value pair of the dynamic section; for the
+// tags whose value is an offset into the dynamic string table, such as
+// DT_NEEDED or DT_SONAME, the referenced string is stored in Name
+type DynamicEntry struct {
+	Tag   elf.DynTag
+	Value uint64
+	Name  string
+}
+
 // File represents the contents of an elf binary file
 type File struct {
 	file  *elf.File
@@ -37,6 +81,13 @@ func (ef *File) AsBytes() []byte {
 	return buf
 }
 
+// Clone returns a copy of the elf file, which can be modified
+// independently of the original one. The copy is parsed again,
+// so that it does not share its reader with the original file.
+func (ef *File) Clone() (*File, error) {
+	return ReadAll(bytes.NewReader(ef.bytes))
+}
+
 // HasSection returns whether an elf file has a section named 'name'
 func (ef *File) HasSection(name string) bool {
 	return ef.file.Section(name) != nil
@@ -99,3 +150,278 @@ func (ef *File) SectionSize(name string) (uint64, error) {
 	}
 	return section.Size, nil
 }
+
+// Relocations returns the entries of the relocation sections that apply
+// to the section with the passed name, if it exists
+func (ef *File) Relocations(name string) ([]Relocation, error) {
+	section := ef.file.Section(name)
+	if section == nil {
+		return nil, NoSuchSectionErr
+	}
+
+	relocations := []Relocation{}
+	for _, relSection := range ef.file.Sections {
+		if relSection.Type != elf.SHT_REL && relSection.Type != elf.SHT_RELA {
+			continue
+		}
+
+		if int(relSection.Info) >= len(ef.file.Sections) ||
+			ef.file.Sections[relSection.Info] != section {
+			continue
+		}
+
+		entries, err := ef.readRelocations(relSection)
+		if err != nil {
+			return nil, err
+		}
+		relocations = append(relocations, entries...)
+	}
+	return relocations, nil
+}
+
+// readRelocations decodes the entries of a rel or rela section, resolving
+// the names of the symbols they refer to through its linked symbol table
+func (ef *File) readRelocations(relSection *elf.Section) ([]Relocation, error) {
+	var symbols []elf.Symbol
+	if int(relSection.Link) < len(ef.file.Sections) &&
+		ef.file.Sections[relSection.Link].Type == elf.SHT_DYNSYM {
+		symbols, _ = ef.file.DynamicSymbols()
+	} else {
+		symbols, _ = ef.file.Symbols()
+	}
+
+	start := relSection.Offset
+	end := start + relSection.Size
+	if end > uint64(len(ef.bytes)) {
+		return nil, InvalidRelocationsErr
+	}
+
+	var entrySize uint64
+	is64 := ef.file.Class == elf.ELFCLASS64
+	switch {
+	case is64 && relSection.Type == elf.SHT_RELA:
+		entrySize = 24
+	case is64:
+		entrySize = 16
+	case relSection.Type == elf.SHT_RELA:
+		entrySize = 12
+	default:
+		entrySize = 8
+	}
+
+	if relSection.Size%entrySize != 0 {
+		return nil, InvalidRelocationsErr
+	}
+
+	order := ef.file.ByteOrder
+	relocations := make([]Relocation, 0, relSection.Size/entrySize)
+	for entry := start; entry < end; entry += entrySize {
+		var relocation Relocation
+		var symIndex uint32
+		if is64 {
+			info := order.Uint64(ef.bytes[entry+8:])
+			relocation.Offset = order.Uint64(ef.bytes[entry:])
+			relocation.Type = elf.R_TYPE64(info)
+			symIndex = elf.R_SYM64(info)
+		} else {
+			info := order.Uint32(ef.bytes[entry+4:])
+			relocation.Offset = uint64(order.Uint32(ef.bytes[entry:]))
+			relocation.Type = elf.R_TYPE32(info)
+			symIndex = elf.R_SYM32(info)
+		}
+
+		// the symbol tables returned by debug/elf skip the null symbol
+		if symIndex > 0 && int(symIndex) <= len(symbols) {
+			relocation.Symbol = symbols[symIndex-1].Name
+		}
+		relocations = append(relocations, relocation)
+	}
+	return relocations, nil
+}
+
+// Dynamic returns the entries of the dynamic section, up to the terminating
+// DT_NULL one; files with no dynamic section have no entries
+func (ef *File) Dynamic() ([]DynamicEntry, error) {
+	entries := []DynamicEntry{}
+	section := ef.file.SectionByType(elf.SHT_DYNAMIC)
+	if section == nil {
+		return entries, nil
+	}
+
+	start := section.Offset
+	end := start + section.Size
+	if end > uint64(len(ef.bytes)) {
+		return nil, InvalidDynamicErr
+	}
+
+	var strtab []byte
+	if int(section.Link) < len(ef.file.Sections) {
+		link := ef.file.Sections[section.Link]
+		if link.Offset+link.Size > uint64(len(ef.bytes)) {
+			return nil, InvalidDynamicErr
+		}
+		strtab = ef.bytes[link.Offset : link.Offset+link.Size]
+	}
+
+	entrySize := uint64(8)
+	is64 := ef.file.Class == elf.ELFCLASS64
+	if is64 {
+		entrySize = 16
+	}
+
+	order := ef.file.ByteOrder
+	for entry := start; entry+entrySize <= end; entry += entrySize {
+		var dynEntry DynamicEntry
+		if is64 {
+			dynEntry.Tag = elf.DynTag(order.Uint64(ef.bytes[entry:]))
+			dynEntry.Value = order.Uint64(ef.bytes[entry+8:])
+		} else {
+			dynEntry.Tag = elf.DynTag(order.Uint32(ef.bytes[entry:]))
+			dynEntry.Value = uint64(order.Uint32(ef.bytes[entry+4:]))
+		}
+
+		if dynEntry.Tag == elf.DT_NULL {
+			break
+		}
+
+		switch dynEntry.Tag {
+		case elf.DT_NEEDED, elf.DT_SONAME, elf.DT_RPATH, elf.DT_RUNPATH:
+			if dynEntry.Value >= uint64(len(strtab)) {
+				return nil, InvalidDynamicErr
+			}
+			name := strtab[dynEntry.Value:]
+			if nul := bytes.IndexByte(name, 0); nul != -1 {
+				name = name[:nul]
+			}
+			dynEntry.Name = string(name)
+		}
+		entries = append(entries, dynEntry)
+	}
+	return entries, nil
+}
+
+// AddSection appends a new progbits section with the passed name, contents
+// and flags to the elf file. The section data, an updated copy of the section
+// header string table and the new section header table are appended at the
+// end of the file, so that every pre-existing offset stays valid.
+func (ef *File) AddSection(name string, data []byte, flags uint64) error {
+	if ef.file.Section(name) != nil {
+		return DuplicateSectionErr
+	}
+
+	order := ef.file.ByteOrder
+	is64 := ef.file.Class == elf.ELFCLASS64
+	if !is64 && flags > math.MaxUint32 {
+		return InvalidFlagsErr
+	}
+
+	var shoff uint64
+	var shentsize, shnum, shstrndx uint16
+	if is64 {
+		shoff = order.Uint64(ef.bytes[shoff64:])
+		shentsize = order.Uint16(ef.bytes[shentsize64:])
+		shnum = order.Uint16(ef.bytes[shnum64:])
+		shstrndx = order.Uint16(ef.bytes[shstrndx64:])
+	} else {
+		shoff = uint64(order.Uint32(ef.bytes[shoff32:]))
+		shentsize = order.Uint16(ef.bytes[shentsize32:])
+		shnum = order.Uint16(ef.bytes[shnum32:])
+		shstrndx = order.Uint16(ef.bytes[shstrndx32:])
+	}
+
+	if shstrndx == uint16(elf.SHN_UNDEF) || int(shstrndx) >= len(ef.file.Sections) {
+		return NoStringTableErr
+	}
+
+	// the new section header gets written field by field, so each entry
+	// must be at least as big as a section header of the file class
+	shdrSize := uint16(shdrSize32)
+	if is64 {
+		shdrSize = shdrSize64
+	}
+	if shentsize < shdrSize {
+		return OutOfBoundsErr
+	}
+
+	headersSize := uint64(shnum) * uint64(shentsize)
+	if shoff+headersSize > uint64(len(ef.bytes)) {
+		return OutOfBoundsErr
+	}
+
+	headers := make([]byte, headersSize, headersSize+uint64(shentsize))
+	copy(headers, ef.bytes[shoff:shoff+headersSize])
+
+	// the new string table is the old one plus the new name
+	strtab := ef.file.Sections[shstrndx]
+	fileSize := uint64(len(ef.bytes))
+	if strtab.Offset > fileSize || strtab.Size > fileSize-strtab.Offset {
+		return OutOfBoundsErr
+	}
+
+	strtabData := make([]byte, strtab.Size)
+	copy(strtabData, ef.bytes[strtab.Offset:strtab.Offset+strtab.Size])
+	nameIdx := uint32(len(strtabData))
+	strtabData = append(strtabData, name...)
+	strtabData = append(strtabData, 0)
+
+	newBytes := make([]byte, len(ef.bytes))
+	copy(newBytes, ef.bytes)
+
+	dataOffset := uint64(len(newBytes))
+	newBytes = append(newBytes, data...)
+
+	strtabOffset := uint64(len(newBytes))
+	newBytes = append(newBytes, strtabData...)
+
+	align := 4
+	if is64 {
+		align = 8
+	}
+	for len(newBytes)%align != 0 {
+		newBytes = append(newBytes, 0)
+	}
+	newShoff := uint64(len(newBytes))
+
+	// update the string table header to point to its new copy
+	strtabHeader := headers[uint64(shstrndx)*uint64(shentsize):]
+	newHeader := make([]byte, shentsize)
+	order.PutUint32(newHeader[shName:], nameIdx)
+	order.PutUint32(newHeader[shType:], uint32(elf.SHT_PROGBITS))
+	if is64 {
+		order.PutUint64(strtabHeader[shOffset64:], strtabOffset)
+		order.PutUint64(strtabHeader[shSize64:], uint64(len(strtabData)))
+
+		order.PutUint64(newHeader[shFlags:], flags)
+		order.PutUint64(newHeader[shOffset64:], dataOffset)
+		order.PutUint64(newHeader[shSize64:], uint64(len(data)))
+		order.PutUint64(newHeader[shAddralign64:], 1)
+	} else {
+		order.PutUint32(strtabHeader[shOffset32:], uint32(strtabOffset))
+		order.PutUint32(strtabHeader[shSize32:], uint32(len(strtabData)))
+
+		order.PutUint32(newHeader[shFlags:], uint32(flags))
+		order.PutUint32(newHeader[shOffset32:], uint32(dataOffset))
+		order.PutUint32(newHeader[shSize32:], uint32(len(data)))
+		order.PutUint32(newHeader[shAddralign32:], 1)
+	}
+
+	newBytes = append(newBytes, headers...)
+	newBytes = append(newBytes, newHeader...)
+
+	if is64 {
+		order.PutUint64(newBytes[shoff64:], newShoff)
+		order.PutUint16(newBytes[shnum64:], shnum+1)
+	} else {
+		order.PutUint32(newBytes[shoff32:], uint32(newShoff))
+		order.PutUint16(newBytes[shnum32:], shnum+1)
+	}
+
+	elfFile, err := elf.NewFile(bytes.NewReader(newBytes))
+	if err != nil {
+		return FileOpenErr
+	}
+
+	ef.file = elfFile
+	ef.bytes = newBytes
+	return nil
+}
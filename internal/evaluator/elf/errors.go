@@ -17,7 +17,12 @@ func CustomError(original FileError, msg string, args ...any) error {
 }
 
 const (
-	FileOpenErr      = FileError("cannot open the file with the passed file name")
-	NoSuchSectionErr = FileError("there is no such section in the passed elf file")
-	OutOfBoundsErr   = FileError("attempting to write out of the section bounds")
+	FileOpenErr           = FileError("cannot open the file with the passed file name")
+	NoSuchSectionErr      = FileError("there is no such section in the passed elf file")
+	OutOfBoundsErr        = FileError("attempting to write out of the section bounds")
+	DuplicateSectionErr   = FileError("the passed elf file already contains a section with this name")
+	NoStringTableErr      = FileError("the passed elf file has no section header string table")
+	InvalidRelocationsErr = FileError("the passed elf file contains a malformed relocation section")
+	InvalidDynamicErr     = FileError("the passed elf file contains a malformed dynamic section")
+	InvalidFlagsErr       = FileError("the section flags do not fit in a 32-bit elf file")
 )
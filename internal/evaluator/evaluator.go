@@ -13,6 +13,14 @@ type MethodMapping map[string]*object.Method
 
 const noLineInfo = -1
 
+// traceTemplate is the format of the trace entry
+// logged for each evaluated top-level statement
+const traceTemplate = "trace: %s => %s\n"
+
+// Version is the interpreter version exposed to scripts through the
+// version builtin. It is set by the interpreter package.
+var Version = ""
+
 var (
 	NULL  = &object.Null{}
 	TRUE  = &object.Boolean{Value: true}
@@ -20,6 +28,15 @@ var (
 
 	builtins       map[string]*object.Builtin
 	builtinMethods map[object.ObjectType]MethodMapping
+
+	// constants are read-only values available to every script, which
+	// cannot be re-defined through a var statement
+	constants = map[string]object.Object{
+		"MAX_U8":  &object.Integer{Value: math.MaxUint8},
+		"MAX_U16": &object.Integer{Value: math.MaxUint16},
+		"MAX_U32": &object.Integer{Value: math.MaxUint32},
+		"MAX_I64": &object.Integer{Value: math.MaxInt64},
+	}
 )
 
 func init() {
@@ -37,12 +54,106 @@ func init() {
 	}
 
 	// Builtin: from_hex(string) -> array
-	// Converts a hex-string with to an array of bytes
+	// Converts a hex-string with to an array of bytes. Whitespace, commas,
+	// colons and 0x prefixes for each byte are ignored.
 	builtins["from_hex"] = &object.Builtin{
-		Name:        "from_hex",
-		Description: "Converts a hex-string with to an array of bytes.",
-		ArgTypes:    []object.ObjectType{object.StringObj},
-		Function:    builtinFromhex,
+		Name: "from_hex",
+		Description: "Converts a hex-string with to an array of bytes. " +
+			"Whitespace, commas, colons and 0x prefixes for each byte are ignored.",
+		ArgTypes: []object.ObjectType{object.StringObj},
+		Function: builtinFromhex,
+	}
+
+	// Builtin: bytes_from_hex(string) -> bytes_file
+	// Builds an in-memory bytes file from a hex-string. The file has no
+	// name, so it cannot be saved.
+	builtins["bytes_from_hex"] = &object.Builtin{
+		Name: "bytes_from_hex",
+		Description: "Builds an in-memory bytes file from a hex-string. The " +
+			"file has no name, so it cannot be saved.",
+		ArgTypes: []object.ObjectType{object.StringObj},
+		Function: builtinBytesFromHex,
+	}
+
+	// Builtin: bytes_builder() -> bytes_builder
+	// Returns an empty builder, which constructs a bytes file by appending
+	// data sequentially through its write methods.
+	builtins["bytes_builder"] = &object.Builtin{
+		Name: "bytes_builder",
+		Description: "Returns an empty builder, which constructs a bytes file " +
+			"by appending data sequentially through its write methods.",
+		ArgTypes: []object.ObjectType{},
+		Function: builtinBytesBuilder,
+	}
+
+	// Builtin: to_hex_string(array|hex_file|elf_file|pe_file|macho_file|zip_file|tar_file|bytes_file) -> string
+	// Converts a byte array or the contents of a file to a hex-string.
+	builtins["to_hex_string"] = &object.Builtin{
+		Name: "to_hex_string",
+		Description: "Converts a byte array or the contents of a file to a " +
+			"hex-string.",
+		ArgTypes: []object.ObjectType{
+			object.OrType(object.ArrayObj, object.HexObj, object.ElfObj,
+				object.PeObj, object.MachoObj, object.ZipObj, object.TarObj,
+				object.BytesObj),
+		},
+		Function: builtinToHexString,
+	}
+
+	// Builtin: base32_encode(array) -> string
+	// Encodes a byte array to a padded base32 string, using the standard
+	// RFC 4648 alphabet.
+	builtins["base32_encode"] = &object.Builtin{
+		Name: "base32_encode",
+		Description: "Encodes a byte array to a padded base32 string, using " +
+			"the standard RFC 4648 alphabet.",
+		ArgTypes: []object.ObjectType{object.ArrayObj},
+		Function: builtinBase32Encode,
+	}
+
+	// Builtin: base32_decode(string) -> array
+	// Decodes a padded base32 string, using the standard RFC 4648 alphabet,
+	// to an array of bytes.
+	builtins["base32_decode"] = &object.Builtin{
+		Name: "base32_decode",
+		Description: "Decodes a padded base32 string, using the standard " +
+			"RFC 4648 alphabet, to an array of bytes.",
+		ArgTypes: []object.ObjectType{object.StringObj},
+		Function: builtinBase32Decode,
+	}
+
+	// Builtin: to_hex(array|bytes_file [, int]) -> hex_file
+	// Encodes the passed byte array or bytes file into an in-memory hex file,
+	// starting from address 0. Each data record holds 16 bytes, unless a
+	// different size in the 1..255 range is passed as the optional second
+	// argument.
+	builtins["to_hex"] = &object.Builtin{
+		Name: "to_hex",
+		Description: "Encodes the passed byte array or bytes file into an " +
+			"in-memory hex file, starting from address 0. Each data record " +
+			"holds 16 bytes, unless a different size in the 1..255 range is " +
+			"passed as the optional second argument.",
+		ArgTypes: []object.ObjectType{
+			object.OrType(object.ArrayObj, object.BytesObj),
+			object.AnyOptional,
+		},
+		Function: builtinToHex,
+	}
+
+	// Builtin: to_string(array [, string [, bool]]) -> string
+	// Decodes the passed byte array to a string, using the optional encoding
+	// ("utf8" by default, "ascii" or "latin1"). Bytes that are invalid for the
+	// encoding result in an error. If the optional bool is true, the decoding
+	// stops at the first null byte.
+	builtins["to_string"] = &object.Builtin{
+		Name: "to_string",
+		Description: "Decodes the passed byte array to a string, using the " +
+			"optional encoding (\"utf8\" by default, \"ascii\" or \"latin1\"). " +
+			"Bytes that are invalid for the encoding result in an error. If the " +
+			"optional bool is true, the decoding stops at the first null byte.",
+		ArgTypes: []object.ObjectType{object.ArrayObj, object.AnyOptional,
+			object.AnyOptional},
+		Function: builtinToString,
 	}
 
 	// Builtin: len(string|array|map|set) -> int
@@ -70,6 +181,28 @@ func init() {
 		Function: builtinSet,
 	}
 
+	// Builtin: map(array) -> map
+	// Builds a map starting from the passed array of [key, value]
+	// pairs. If a key is repeated, the last value is kept.
+	builtins["map"] = &object.Builtin{
+		Name: "map",
+		Description: "Builds a map starting from the passed array of " +
+			"[key, value] pairs. If a key is repeated, the last value is kept.",
+		ArgTypes: []object.ObjectType{object.ArrayObj},
+		Function: builtinMap,
+	}
+
+	// Builtin: dict(...) -> map
+	// Builds a map starting from the passed arguments, interpreted
+	// as alternating keys and values.
+	builtins["dict"] = &object.Builtin{
+		Name: "dict",
+		Description: "Builds a map starting from the passed arguments, " +
+			"interpreted as alternating keys and values.",
+		ArgTypes: []object.ObjectType{object.AnyVarargs},
+		Function: builtinDict,
+	}
+
 	// Builtin: type(any) -> string
 	// Returns the type of the object as a string.
 	builtins["type"] = &object.Builtin{
@@ -79,25 +212,97 @@ func init() {
 		Function:    builtinType,
 	}
 
-	// Builtin: open(string, string) -> file
+	// Builtin: is_null(any) -> bool
+	// Returns whether the passed object is null or a missing value, such as
+	// the result of a function that returns nothing.
+	builtins["is_null"] = &object.Builtin{
+		Name: "is_null",
+		Description: "Returns whether the passed object is null or a missing " +
+			"value, such as the result of a function that returns nothing.",
+		ArgTypes: []object.ObjectType{object.AnyObj},
+		Function: builtinIsNull,
+	}
+
+	// Builtin: array_of(int, any) -> array
+	// Returns an array containing arg[0] copies of the passed value, where
+	// arg[0] can be at most 16M. Arrays, maps and sets are copied, so that
+	// each element can be modified independently of the others.
+	builtins["array_of"] = &object.Builtin{
+		Name: "array_of",
+		Description: "Returns an array containing arg[0] copies of the " +
+			"passed value, where arg[0] can be at most 16M. Arrays, maps and " +
+			"sets are copied, so that each element can be modified " +
+			"independently of the others.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.AnyObj},
+		Function: builtinArrayOf,
+	}
+
+	// Builtin: freeze(array|map|set) -> array|map|set
+	// Returns a frozen copy of the passed collection, which rejects the
+	// methods that would modify it. The elements are copied as references.
+	builtins["freeze"] = &object.Builtin{
+		Name: "freeze",
+		Description: "Returns a frozen copy of the passed collection, which " +
+			"rejects the methods that would modify it. The elements are " +
+			"copied as references.",
+		ArgTypes: []object.ObjectType{
+			object.OrType(object.ArrayObj, object.MapObj, object.SetObj),
+		},
+		Function: builtinFreeze,
+	}
+
+	// Builtin: is_frozen(any) -> bool
+	// Returns whether the passed object is a frozen collection.
+	builtins["is_frozen"] = &object.Builtin{
+		Name:        "is_frozen",
+		Description: "Returns whether the passed object is a frozen collection.",
+		ArgTypes:    []object.ObjectType{object.AnyObj},
+		Function:    builtinIsFrozen,
+	}
+
+	// Builtin: repr(any) -> string
+	// Returns an unambiguous representation of the object as a string,
+	// quoting strings and sorting the contents of maps and sets.
+	builtins["repr"] = &object.Builtin{
+		Name: "repr",
+		Description: "Returns an unambiguous representation of the object as " +
+			"a string, quoting strings and sorting the contents of maps and sets.",
+		ArgTypes: []object.ObjectType{object.AnyObj},
+		Function: builtinRepr,
+	}
+
+	// Builtin: open(string, string [, string]) -> file
 	// Attempts to open a file with the name of the first
 	// argument, with the file type specified by the second argument.
+	// The optional mode can be "rw" (the default) or "ro": files opened
+	// in read-only mode cannot be modified or saved.
 	builtins["open"] = &object.Builtin{
 		Name: "open",
 		Description: "Attempts to open a file with the name of the first " +
-			"argument, with the file type specified by the second argument.",
-		ArgTypes: []object.ObjectType{object.StringObj, object.StringObj},
+			"argument, with the file type specified by the second argument. " +
+			"The optional mode can be \"rw\" (the default) or \"ro\": files " +
+			"opened in read-only mode cannot be modified or saved.",
+		ArgTypes: []object.ObjectType{object.StringObj, object.StringObj,
+			object.AnyOptional},
 		Function: builtinOpen,
 	}
 
-	// Builtin: save(hex_file|elf_file|bytes_file) -> no return
-	// Saves a previously opened file's contents unto the original file.
+	// Builtin: save(hex_file|elf_file|pe_file|macho_file|zip_file|tar_file|bytes_file [, bool]) -> no return
+	// Saves a previously opened file's contents unto the original file. If
+	// the optional second argument is true, the current contents of the
+	// original file are first copied to a file with the same name and the
+	// .bak suffix.
 	builtins["save"] = &object.Builtin{
 		Name: "save",
 		Description: "Saves a previously opened file's contents unto the " +
-			"original file.",
+			"original file. If the optional second argument is true, the " +
+			"current contents of the original file are first copied to a " +
+			"file with the same name and the .bak suffix.",
 		ArgTypes: []object.ObjectType{
-			object.OrType(object.HexObj, object.ElfObj, object.BytesObj),
+			object.OrType(object.HexObj, object.ElfObj, object.PeObj,
+				object.MachoObj, object.ZipObj, object.TarObj,
+				object.BytesObj),
+			object.AnyOptional,
 		},
 		Function: builtinSave,
 	}
@@ -113,14 +318,45 @@ func init() {
 		Function: builtinPrint,
 	}
 
-	// Builtin: as_bytes(hex_file|elf_file|bytes_file) -> array
+	// Builtin: pretty_print(any) -> no return
+	// Prints the passed object in a readable form: arrays, maps and sets
+	// with more than 8 elements are printed one element per line, with the
+	// keys of maps and the elements of sets sorted.
+	builtins["pretty_print"] = &object.Builtin{
+		Name: "pretty_print",
+		Description: "Prints the passed object in a readable form: arrays, " +
+			"maps and sets with more than 8 elements are printed one element " +
+			"per line, with the keys of maps and the elements of sets sorted.",
+		ArgTypes: []object.ObjectType{object.AnyObj},
+		Function: builtinPrettyPrint,
+	}
+
+	// Builtin: print_hex(array|file) -> no return
+	// Prints the passed byte array or the contents of the passed file as an
+	// array of 0x-prefixed hex bytes, followed by a newline character.
+	builtins["print_hex"] = &object.Builtin{
+		Name: "print_hex",
+		Description: "Prints the passed byte array or the contents of the " +
+			"passed file as an array of 0x-prefixed hex bytes, followed by a " +
+			"newline character.",
+		ArgTypes: []object.ObjectType{
+			object.OrType(object.ArrayObj, object.HexObj, object.ElfObj,
+				object.PeObj, object.MachoObj, object.ZipObj, object.TarObj,
+				object.BytesObj),
+		},
+		Function: builtinPrintHex,
+	}
+
+	// Builtin: as_bytes(hex_file|elf_file|pe_file|macho_file|zip_file|tar_file|bytes_file) -> array
 	// Returns an array containing the passed file as a stream of bytes.
 	builtins["as_bytes"] = &object.Builtin{
 		Name: "as_bytes",
 		Description: "Returns an array containing the passed file as a stream " +
 			"of bytes.",
 		ArgTypes: []object.ObjectType{
-			object.OrType(object.HexObj, object.ElfObj, object.BytesObj),
+			object.OrType(object.HexObj, object.ElfObj, object.PeObj,
+				object.MachoObj, object.ZipObj, object.TarObj,
+				object.BytesObj),
 		},
 		Function: builtinAsBytes,
 	}
@@ -137,14 +373,18 @@ func init() {
 		Function: builtinContains,
 	}
 
-	// Builtin: hash(array, string) -> array
+	// Builtin: hash(array|bytes_file, string) -> array
 	// Returns an array containing the computed hash of the passed
-	// array, using the specified algorithm.
+	// array or bytes file, using the specified algorithm. Bytes files
+	// are streamed through the hash function without being copied.
 	builtins["hash"] = &object.Builtin{
 		Name: "hash",
 		Description: "Returns an array containing the computed hash of the " +
-			"passed array, using the specified algorithm.",
-		ArgTypes: []object.ObjectType{object.ArrayObj, object.StringObj},
+			"passed array or bytes file, using the specified algorithm. " +
+			"Bytes files are streamed through the hash function without " +
+			"being copied.",
+		ArgTypes: []object.ObjectType{object.OrType(object.ArrayObj, object.BytesObj),
+			object.StringObj},
 		Function: builtinHash,
 	}
 
@@ -158,6 +398,18 @@ func init() {
 		Function: builtinInt,
 	}
 
+	// Builtin: parse_int(string, int) -> int
+	// Converts a string representing an integer in the arg[1] base, with
+	// no prefix, to an actual integer. The base must be in the 2..36 range.
+	builtins["parse_int"] = &object.Builtin{
+		Name: "parse_int",
+		Description: "Converts a string representing an integer in the arg[1] " +
+			"base, with no prefix, to an actual integer. The base must be in " +
+			"the 2..36 range.",
+		ArgTypes: []object.ObjectType{object.StringObj, object.IntegerObj},
+		Function: builtinParseInt,
+	}
+
 	// Builtin: error(...any) -> error
 	// Creates a custom error that can be used in code.
 	builtins["error"] = &object.Builtin{
@@ -167,18 +419,60 @@ func init() {
 		Function:    builtinError,
 	}
 
-	// Builtin: as_array(int, int, string) -> array
+	// Builtin: assert_eq(any, any [, string]) -> no return
+	// Aborts the execution if the two values are not equal, reporting both
+	// of them and the optional message.
+	builtins["assert_eq"] = &object.Builtin{
+		Name: "assert_eq",
+		Description: "Aborts the execution if the two values are not equal, " +
+			"reporting both of them and the optional message.",
+		ArgTypes: []object.ObjectType{object.AnyObj, object.AnyObj,
+			object.AnyOptional},
+		Function: builtinAssertEq,
+	}
+
+	// Builtin: assert_type(any, string [, string]) -> no return
+	// Aborts the execution if the value is not of the type with the passed
+	// name, as returned by type(), reporting the optional message.
+	builtins["assert_type"] = &object.Builtin{
+		Name: "assert_type",
+		Description: "Aborts the execution if the value is not of the type " +
+			"with the passed name, as returned by type(), reporting the " +
+			"optional message.",
+		ArgTypes: []object.ObjectType{object.AnyObj, object.StringObj,
+			object.AnyOptional},
+		Function: builtinAssertType,
+	}
+
+	// Builtin: as_array(int, int, string [, string]) -> array
 	// Converts an integer to its representation as an array of bytes of specific
-	// size and endianness.
+	// size and endianness. Passing "signed" as the optional final argument
+	// encodes negative integers in two's complement.
 	builtins["as_array"] = &object.Builtin{
 		Name: "as_array",
 		Description: "Converts an integer to its representation as an array of " +
-			"bytes of specific size and endianness.",
+			"bytes of specific size and endianness. Passing \"signed\" as the " +
+			"optional final argument encodes negative integers in two's complement.",
 		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
-			object.StringObj},
+			object.StringObj, object.AnyOptional},
 		Function: builtinAsArray,
 	}
 
+	// Builtin: as_int(array, string [, string]) -> int
+	// Converts an array of bytes with the specified endianness to the integer
+	// it represents. Passing "signed" as the optional final argument decodes
+	// the bytes as a two's complement integer, sign-extending it.
+	builtins["as_int"] = &object.Builtin{
+		Name: "as_int",
+		Description: "Converts an array of bytes with the specified endianness " +
+			"to the integer it represents. Passing \"signed\" as the optional " +
+			"final argument decodes the bytes as a two's complement integer, " +
+			"sign-extending it.",
+		ArgTypes: []object.ObjectType{object.ArrayObj, object.StringObj,
+			object.AnyOptional},
+		Function: builtinAsInt,
+	}
+
 	// Builtin: help(string) -> array
 	// Shows an help message for the specified builtin
 	builtins["help"] = &object.Builtin{
@@ -189,6 +483,265 @@ func init() {
 		Function: builtinHelp,
 	}
 
+	// Builtin: hexdump(array|hex_file|elf_file|pe_file|macho_file|zip_file|tar_file|bytes_file [, int]) -> string
+	// Returns a canonical offset/hex/ASCII dump of the passed byte array or
+	// file. Each row contains 16 bytes, unless a different row width, up to
+	// 256 bytes, is passed as the optional second argument.
+	builtins["hexdump"] = &object.Builtin{
+		Name: "hexdump",
+		Description: "Returns a canonical offset/hex/ASCII dump of the passed " +
+			"byte array or file. Each row contains 16 bytes, unless a different " +
+			"row width, up to 256 bytes, is passed as the optional second " +
+			"argument.",
+		ArgTypes: []object.ObjectType{
+			object.OrType(object.ArrayObj, object.HexObj, object.ElfObj,
+				object.PeObj, object.MachoObj, object.ZipObj, object.TarObj,
+				object.BytesObj),
+			object.AnyOptional,
+		},
+		Function: builtinHexdump,
+	}
+
+	// Builtin: hex_dump_diff(array|hex_file|elf_file|pe_file|macho_file|zip_file|tar_file|bytes_file, array|hex_file|elf_file|pe_file|macho_file|zip_file|tar_file|bytes_file) -> string
+	// Returns a side-by-side hex dump of the two passed byte arrays or files,
+	// aligned by offset, where the bytes that differ are marked with '*'. The
+	// shorter input is padded with '--'.
+	builtins["hex_dump_diff"] = &object.Builtin{
+		Name: "hex_dump_diff",
+		Description: "Returns a side-by-side hex dump of the two passed byte " +
+			"arrays or files, aligned by offset, where the bytes that differ " +
+			"are marked with '*'. The shorter input is padded with '--'.",
+		ArgTypes: []object.ObjectType{
+			object.OrType(object.ArrayObj, object.HexObj, object.ElfObj,
+				object.PeObj, object.MachoObj, object.ZipObj, object.TarObj,
+				object.BytesObj),
+			object.OrType(object.ArrayObj, object.HexObj, object.ElfObj,
+				object.PeObj, object.MachoObj, object.ZipObj, object.TarObj,
+				object.BytesObj),
+		},
+		Function: builtinHexdumpDiff,
+	}
+
+	// Builtin: make_patch(array|bytes_file, array|bytes_file) -> array
+	// Returns the patch that transforms the arg[0] byte array or bytes file
+	// into the arg[1] one, in the format accepted by apply_patch: an array of
+	// [offset, byte_array] edits, where adjacent changed bytes are coalesced in
+	// a single edit. The two inputs must have the same length.
+	builtins["make_patch"] = &object.Builtin{
+		Name: "make_patch",
+		Description: "Returns the patch that transforms the arg[0] byte array " +
+			"or bytes file into the arg[1] one, in the format accepted by " +
+			"apply_patch: an array of [offset, byte_array] edits, where adjacent " +
+			"changed bytes are coalesced in a single edit. The two inputs must " +
+			"have the same length.",
+		ArgTypes: []object.ObjectType{
+			object.OrType(object.ArrayObj, object.BytesObj),
+			object.OrType(object.ArrayObj, object.BytesObj),
+		},
+		Function: builtinMakePatch,
+	}
+
+	// Builtin: clamp(int, int, int) -> int
+	// Returns the arg[0] integer bounded to the [arg[1], arg[2]] interval.
+	builtins["clamp"] = &object.Builtin{
+		Name: "clamp",
+		Description: "Returns the arg[0] integer bounded to the [arg[1], arg[2]] " +
+			"interval.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+			object.IntegerObj},
+		Function: builtinClamp,
+	}
+
+	// Builtin: gcd(int, int) -> int
+	// Returns the greatest common divisor of the absolute values of the two
+	// integers. gcd(0, 0) is 0.
+	builtins["gcd"] = &object.Builtin{
+		Name: "gcd",
+		Description: "Returns the greatest common divisor of the absolute " +
+			"values of the two integers. gcd(0, 0) is 0.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj},
+		Function: builtinGcd,
+	}
+
+	// Builtin: lcm(int, int) -> int
+	// Returns the least common multiple of the absolute values of the two
+	// integers, or 0 if any of them is 0.
+	builtins["lcm"] = &object.Builtin{
+		Name: "lcm",
+		Description: "Returns the least common multiple of the absolute " +
+			"values of the two integers, or 0 if any of them is 0.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj},
+		Function: builtinLcm,
+	}
+
+	// Builtin: align_up(int, int) -> int
+	// Rounds the arg[0] integer up to the closest multiple of the arg[1]
+	// alignment, which must be a power of two.
+	builtins["align_up"] = &object.Builtin{
+		Name: "align_up",
+		Description: "Rounds the arg[0] integer up to the closest multiple " +
+			"of the arg[1] alignment, which must be a power of two.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj},
+		Function: builtinAlignUp,
+	}
+
+	// Builtin: align_down(int, int) -> int
+	// Rounds the arg[0] integer down to the closest multiple of the arg[1]
+	// alignment, which must be a power of two.
+	builtins["align_down"] = &object.Builtin{
+		Name: "align_down",
+		Description: "Rounds the arg[0] integer down to the closest multiple " +
+			"of the arg[1] alignment, which must be a power of two.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj},
+		Function: builtinAlignDown,
+	}
+
+	// Builtin: get_bits(int, int, int) -> int
+	// Extracts the bit field of arg[2] width starting from the arg[1] least
+	// significant bit of the arg[0] integer.
+	builtins["get_bits"] = &object.Builtin{
+		Name: "get_bits",
+		Description: "Extracts the bit field of arg[2] width starting from the " +
+			"arg[1] least significant bit of the arg[0] integer.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+			object.IntegerObj},
+		Function: builtinGetBits,
+	}
+
+	// Builtin: set_bits(int, int, int, int) -> int
+	// Returns a copy of the arg[0] integer where the bit field of arg[2] width
+	// starting from the arg[1] least significant bit is replaced by arg[3].
+	builtins["set_bits"] = &object.Builtin{
+		Name: "set_bits",
+		Description: "Returns a copy of the arg[0] integer where the bit field " +
+			"of arg[2] width starting from the arg[1] least significant bit is " +
+			"replaced by arg[3].",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+			object.IntegerObj, object.IntegerObj},
+		Function: builtinSetBits,
+	}
+
+	// Builtin: count_ones(int) -> int
+	// Returns the number of bits set to one in the 64 bit representation of
+	// the passed integer.
+	builtins["count_ones"] = &object.Builtin{
+		Name: "count_ones",
+		Description: "Returns the number of bits set to one in the 64 bit " +
+			"representation of the passed integer.",
+		ArgTypes: []object.ObjectType{object.IntegerObj},
+		Function: builtinCountOnes,
+	}
+
+	// Builtin: count_zeros(int, int) -> int
+	// Returns the number of bits set to zero in the representation of the
+	// arg[0] integer with arg[1] bits.
+	builtins["count_zeros"] = &object.Builtin{
+		Name: "count_zeros",
+		Description: "Returns the number of bits set to zero in the " +
+			"representation of the arg[0] integer with arg[1] bits.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj},
+		Function: builtinCountZeros,
+	}
+
+	// Builtin: leading_zeros(int, int) -> int
+	// Returns the number of leading zero bits in the representation of the
+	// arg[0] integer with arg[1] bits.
+	builtins["leading_zeros"] = &object.Builtin{
+		Name: "leading_zeros",
+		Description: "Returns the number of leading zero bits in the " +
+			"representation of the arg[0] integer with arg[1] bits.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj},
+		Function: builtinLeadingZeros,
+	}
+
+	// Builtin: reverse_bits(int, int) -> int
+	// Reverses the order of the bits of the arg[0] integer, within a width
+	// of arg[1] bits, which can be one of 8, 16, 32 or 64.
+	builtins["reverse_bits"] = &object.Builtin{
+		Name: "reverse_bits",
+		Description: "Reverses the order of the bits of the arg[0] integer, " +
+			"within a width of arg[1] bits, which can be one of 8, 16, 32 or 64.",
+		ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj},
+		Function: builtinReverseBits,
+	}
+
+	// Builtin: byte_width(int) -> int
+	// Returns the minimum number of bytes needed to represent the passed
+	// positive integer as an unsigned value, which is at least 1.
+	builtins["byte_width"] = &object.Builtin{
+		Name: "byte_width",
+		Description: "Returns the minimum number of bytes needed to represent " +
+			"the passed positive integer as an unsigned value, which is at least 1.",
+		ArgTypes: []object.ObjectType{object.IntegerObj},
+		Function: builtinByteWidth,
+	}
+
+	// Builtin: memoize(function) -> builtin
+	// Returns a wrapper of the passed function caching its results by the
+	// passed arguments, so that repeated calls with the same arguments do
+	// not call the function again. Calls with non-hashable arguments
+	// bypass the cache.
+	builtins["memoize"] = &object.Builtin{
+		Name: "memoize",
+		Description: "Returns a wrapper of the passed function caching its " +
+			"results by the passed arguments, so that repeated calls with the " +
+			"same arguments do not call the function again. Calls with " +
+			"non-hashable arguments bypass the cache.",
+		ArgTypes: []object.ObjectType{object.FunctionObj},
+		Function: builtinMemoize,
+	}
+
+	// Builtin: compose(function, function) -> builtin
+	// Returns the composition of the two passed one-arg functions, which
+	// calls arg[0] with the result of calling arg[1], as in f(g(x)).
+	builtins["compose"] = &object.Builtin{
+		Name: "compose",
+		Description: "Returns the composition of the two passed one-arg " +
+			"functions, which calls arg[0] with the result of calling arg[1], " +
+			"as in f(g(x)).",
+		ArgTypes: []object.ObjectType{
+			object.OrType(object.FunctionObj, object.BuiltinObj),
+			object.OrType(object.FunctionObj, object.BuiltinObj),
+		},
+		Function: builtinCompose,
+	}
+
+	// Builtin: pipe(...) -> builtin
+	// Returns a function that passes its argument through the passed one-arg
+	// functions from left to right, each called with the result of the
+	// previous one.
+	builtins["pipe"] = &object.Builtin{
+		Name: "pipe",
+		Description: "Returns a function that passes its argument through the " +
+			"passed one-arg functions from left to right, each called with the " +
+			"result of the previous one.",
+		ArgTypes: []object.ObjectType{object.AnyVarargs},
+		Function: builtinPipe,
+	}
+
+	// Builtin: partial(function, ...) -> builtin
+	// Returns a function that calls arg[0] with the remaining arguments bound
+	// as its first arguments, followed by the ones passed when calling it.
+	// Calling it with more arguments than arg[0] takes is an error.
+	builtins["partial"] = &object.Builtin{
+		Name: "partial",
+		Description: "Returns a function that calls arg[0] with the remaining " +
+			"arguments bound as its first arguments, followed by the ones " +
+			"passed when calling it. Calling it with more arguments than " +
+			"arg[0] takes is an error.",
+		ArgTypes: []object.ObjectType{object.AnyVarargs},
+		Function: builtinPartial,
+	}
+
+	// Builtin: version() -> string
+	// Returns the version of the interpreter running the script.
+	builtins["version"] = &object.Builtin{
+		Name:        "version",
+		Description: "Returns the version of the interpreter running the script.",
+		ArgTypes:    []object.ObjectType{},
+		Function:    builtinVersion,
+	}
+
 	builtinMethods = make(map[object.ObjectType]MethodMapping)
 	builtinMethods[object.ArrayObj] = MethodMapping{
 		// Builtin: array.map(function) -> array
@@ -204,6 +757,23 @@ func init() {
 			MethodFunc: arrayBuiltinMap,
 		},
 
+		// Builtin: array.zip_with(array, function) -> array
+		// Applies the passed two-args function to the pairs of elements with
+		// the same index in the array and in arg[0], and returns a new array
+		// with the results, as long as the shorter of the two.
+		"zip_with": &object.Method{
+			Name: "array.zip_with",
+			Description: "Applies the passed two-args function to the pairs of " +
+				"elements with the same index in the array and in arg[0], and " +
+				"returns a new array with the results, as long as the shorter of " +
+				"the two.",
+			ArgTypes: []object.ObjectType{
+				object.ArrayObj,
+				object.OrType(object.FunctionObj, object.BuiltinObj),
+			},
+			MethodFunc: arrayBuiltinZipWith,
+		},
+
 		// Builtin: array.pop() -> array
 		// Removes the last element from the array and returns a copy of the
 		// new array.
@@ -213,6 +783,7 @@ func init() {
 				"returns a copy of the new array.",
 			ArgTypes:   []object.ObjectType{},
 			MethodFunc: arrayBuiltinPop,
+			Mutating:   true,
 		},
 
 		// Builtin: array.push(any) -> array
@@ -224,6 +795,7 @@ func init() {
 				"the new array. The original array remains unchanged.",
 			ArgTypes:   []object.ObjectType{object.AnyObj},
 			MethodFunc: arrayBuiltinPush,
+			Mutating:   true,
 		},
 
 		// Builtin: array.slice(int, int) -> array
@@ -240,6 +812,48 @@ func init() {
 			MethodFunc: arrayBuiltinSlice,
 		},
 
+		// Builtin: array.chunk(int) -> array
+		// Splits the array into sub-arrays of arg[0] elements each, the last
+		// of which may be shorter. Lists/Maps/Sets/Files are copied as
+		// references.
+		"chunk": &object.Method{
+			Name: "array.chunk",
+			Description: "Splits the array into sub-arrays of arg[0] elements " +
+				"each, the last of which may be shorter. Lists/Maps/Sets/Files are " +
+				"copied as references.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj},
+			MethodFunc: arrayBuiltinChunk,
+		},
+
+		// Builtin: array.window(int) -> array
+		// Returns every overlapping sub-array of arg[0] consecutive elements,
+		// or an empty array if the array is shorter than that. Lists/Maps/
+		// Sets/Files are copied as references.
+		"window": &object.Method{
+			Name: "array.window",
+			Description: "Returns every overlapping sub-array of arg[0] " +
+				"consecutive elements, or an empty array if the array is shorter " +
+				"than that. Lists/Maps/Sets/Files are copied as references.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj},
+			MethodFunc: arrayBuiltinWindow,
+		},
+
+		// Builtin: array.tap(function) -> array
+		// Calls the passed function with the array for its side effects, such
+		// as printing intermediate results in a chain of calls, and returns the
+		// array unchanged. The value returned by the function is ignored.
+		"tap": &object.Method{
+			Name: "array.tap",
+			Description: "Calls the passed function with the array for its " +
+				"side effects, such as printing intermediate results in a chain " +
+				"of calls, and returns the array unchanged. The value returned by " +
+				"the function is ignored.",
+			ArgTypes: []object.ObjectType{
+				object.OrType(object.FunctionObj, object.BuiltinObj),
+			},
+			MethodFunc: arrayBuiltinTap,
+		},
+
 		// Builtin: array.reduce(function [, any]) -> any
 		// Applies the passed function to each element of the array; the first
 		// argument gets used as the result of the previous iteration. An
@@ -265,6 +879,7 @@ func init() {
 				"This mutates the map.",
 			ArgTypes:   []object.ObjectType{object.AnyObj, object.AnyObj},
 			MethodFunc: mapBuiltinSet,
+			Mutating:   true,
 		},
 
 		// Builtin: map.pop(any) -> no return
@@ -275,6 +890,28 @@ func init() {
 				"This mutates the map.",
 			ArgTypes:   []object.ObjectType{object.AnyObj},
 			MethodFunc: mapBuiltinPop,
+			Mutating:   true,
+		},
+
+		// Builtin: map.entries() -> array
+		// Returns the [key, value] pairs of the map as an array, sorted by key.
+		// Keys of different types are grouped by type, then sorted by value.
+		"entries": &object.Method{
+			Name: "map.entries",
+			Description: "Returns the [key, value] pairs of the map as an array, " +
+				"sorted by key. Keys of different types are grouped by type, " +
+				"then sorted by value.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: mapBuiltinEntries,
+		},
+
+		// Builtin: map.to_array() -> array
+		// Returns the values of the map as an array, sorted by their keys.
+		"to_array": &object.Method{
+			Name:        "map.to_array",
+			Description: "Returns the values of the map as an array, sorted by their keys.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  mapBuiltinToArray,
 		},
 	}
 
@@ -286,6 +923,7 @@ func init() {
 			Description: "Adds the element to the set. This mutates the set.",
 			ArgTypes:    []object.ObjectType{object.AnyObj},
 			MethodFunc:  setBuiltinAdd,
+			Mutating:    true,
 		},
 
 		// Builtin: set.remove(any) -> no return
@@ -297,6 +935,93 @@ func init() {
 				"This mutates the set.",
 			ArgTypes:   []object.ObjectType{object.AnyObj},
 			MethodFunc: setBuiltinRemove,
+			Mutating:   true,
+		},
+
+		// Builtin: set.to_array() -> array
+		// Returns the elements of the set as a sorted array. Elements of
+		// different types are grouped by type, then sorted by value.
+		"to_array": &object.Method{
+			Name: "set.to_array",
+			Description: "Returns the elements of the set as a sorted array. " +
+				"Elements of different types are grouped by type, then sorted " +
+				"by value.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: setBuiltinToArray,
+		},
+
+		// Builtin: set.is_subset(set) -> bool
+		// Returns whether every element of the set is also contained in the
+		// passed set.
+		"is_subset": &object.Method{
+			Name: "set.is_subset",
+			Description: "Returns whether every element of the set is also " +
+				"contained in the passed set.",
+			ArgTypes:   []object.ObjectType{object.SetObj},
+			MethodFunc: setBuiltinIsSubset,
+		},
+
+		// Builtin: set.is_superset(set) -> bool
+		// Returns whether every element of the passed set is also contained
+		// in the set.
+		"is_superset": &object.Method{
+			Name: "set.is_superset",
+			Description: "Returns whether every element of the passed set is " +
+				"also contained in the set.",
+			ArgTypes:   []object.ObjectType{object.SetObj},
+			MethodFunc: setBuiltinIsSuperset,
+		},
+	}
+
+	builtinMethods[object.StringObj] = MethodMapping{
+		// Builtin: string.substring(int, int) -> string
+		// Returns the part of the string between the arg[0] (included) and
+		// arg[1] (excluded) byte indices.
+		"substring": &object.Method{
+			Name: "string.substring",
+			Description: "Returns the part of the string between the arg[0] " +
+				"(included) and arg[1] (excluded) byte indices.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.IntegerObj},
+			MethodFunc: stringBuiltinSubstring,
+		},
+
+		// Builtin: string.pad_start(int [, string]) -> string
+		// Returns a copy of the string padded at the start up to the passed
+		// width, using the optional one-character fill string (a space by
+		// default).
+		"pad_start": &object.Method{
+			Name: "string.pad_start",
+			Description: "Returns a copy of the string padded at the start up " +
+				"to the passed width, using the optional one-character fill " +
+				"string (a space by default).",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.AnyOptional},
+			MethodFunc: stringBuiltinPadStart,
+		},
+
+		// Builtin: string.pad_end(int [, string]) -> string
+		// Returns a copy of the string padded at the end up to the passed
+		// width, using the optional one-character fill string (a space by
+		// default).
+		"pad_end": &object.Method{
+			Name: "string.pad_end",
+			Description: "Returns a copy of the string padded at the end up " +
+				"to the passed width, using the optional one-character fill " +
+				"string (a space by default).",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.AnyOptional},
+			MethodFunc: stringBuiltinPadEnd,
+		},
+
+		// Builtin: string.encode(string) -> array
+		// Returns the bytes of the string encoded using the passed encoding
+		// ("utf8", "ascii" or "latin1"). Characters that cannot be represented
+		// in the encoding result in an error.
+		"encode": &object.Method{
+			Name: "string.encode",
+			Description: "Returns the bytes of the string encoded using the " +
+				"passed encoding (\"utf8\", \"ascii\" or \"latin1\"). Characters " +
+				"that cannot be represented in the encoding result in an error.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: stringBuiltinEncode,
 		},
 	}
 
@@ -312,6 +1037,18 @@ func init() {
 			MethodFunc: hexBuiltinRecord,
 		},
 
+		// Builtin: hex.records(int, int) -> array
+		// Returns the records whose index is within the [arg[0], arg[1])
+		// interval as strings, if it is a valid one, or an error.
+		"records": &object.Method{
+			Name: "hex.records",
+			Description: "Returns the records whose index is within the " +
+				"[arg[0], arg[1]) interval as strings, if it is a valid one, " +
+				"or an error.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.IntegerObj},
+			MethodFunc: hexBuiltinRecords,
+		},
+
 		// Builtin: hex.size(int) -> int
 		// Returns the size of the file as a number of records it contains.
 		"size": &object.Method{
@@ -336,18 +1073,119 @@ func init() {
 			MethodFunc: hexBuiltinReadAt,
 		},
 
-		// Builtin: hex.write_at(int, array) -> no return
-		// Attempts to write the contents of the arg[1] byte array to the  arg[0]
-		// position. This mutates the hex file object but not the copy on disk.
-		// Call the save() function to make the changes persistent.
+		// Builtin: hex.read_u16(int, string) -> int
+		// Reads the 2 bytes wide unsigned integer found at the arg[0] position,
+		// decoding it with the arg[1] endianness ("little" or "big").
+		"read_u16": &object.Method{
+			Name: "hex.read_u16",
+			Description: "Reads the 2 bytes wide unsigned integer found at " +
+				"the arg[0] position, decoding it with the arg[1] endianness " +
+				"(\"little\" or \"big\").",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.StringObj},
+			MethodFunc: readUint(2, hexBuiltinReadAt),
+		},
+
+		// Builtin: hex.read_u32(int, string) -> int
+		// Reads the 4 bytes wide unsigned integer found at the arg[0] position,
+		// decoding it with the arg[1] endianness ("little" or "big").
+		"read_u32": &object.Method{
+			Name: "hex.read_u32",
+			Description: "Reads the 4 bytes wide unsigned integer found at " +
+				"the arg[0] position, decoding it with the arg[1] endianness " +
+				"(\"little\" or \"big\").",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.StringObj},
+			MethodFunc: readUint(4, hexBuiltinReadAt),
+		},
+
+		// Builtin: hex.read_u64(int, string) -> int
+		// Reads the 8 bytes wide unsigned integer found at the arg[0] position,
+		// decoding it with the arg[1] endianness ("little" or "big").
+		"read_u64": &object.Method{
+			Name: "hex.read_u64",
+			Description: "Reads the 8 bytes wide unsigned integer found at " +
+				"the arg[0] position, decoding it with the arg[1] endianness " +
+				"(\"little\" or \"big\").",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.StringObj},
+			MethodFunc: readUint(8, hexBuiltinReadAt),
+		},
+
+		// Builtin: hex.write_at(int, array|string) -> no return
+		// Attempts to write the contents of the arg[1] byte array, or the bytes
+		// represented by the arg[1] hex string, to the arg[0] position. This
+		// mutates the hex file object but not the copy on disk. Call the save()
+		// function to make the changes persistent.
 		"write_at": &object.Method{
 			Name: "hex.write_at",
 			Description: "Attempts to write the contents of the arg[1] byte " +
-				"array to the  arg[0] position. This mutates the hex file object " +
-				"but not the copy on disk. Call the save() function to make the " +
-				"changes persistent.",
-			ArgTypes:   []object.ObjectType{object.IntegerObj, object.ArrayObj},
+				"array, or the bytes represented by the arg[1] hex string, to the " +
+				"arg[0] position. This mutates the hex file object but not the " +
+				"copy on disk. Call the save() function to make the changes " +
+				"persistent.",
+			ArgTypes: []object.ObjectType{object.IntegerObj,
+				object.OrType(object.ArrayObj, object.StringObj)},
 			MethodFunc: hexBuiltinWriteAt,
+			Mutating:   true,
+		},
+
+		// Builtin: hex.write_u16(int, int, string) -> no return
+		// Writes the arg[1] unsigned integer to the arg[0] position, encoded in
+		// 2 bytes with the arg[2] endianness ("little" or "big"). This mutates
+		// the hex file object but not the copy on disk.
+		"write_u16": &object.Method{
+			Name: "hex.write_u16",
+			Description: "Writes the arg[1] unsigned integer to the arg[0] " +
+				"position, encoded in 2 bytes with the arg[2] endianness " +
+				"(\"little\" or \"big\"). This mutates the hex file object " +
+				"but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.StringObj},
+			MethodFunc: writeUint(2, hexBuiltinWriteAt),
+			Mutating:   true,
+		},
+
+		// Builtin: hex.write_u32(int, int, string) -> no return
+		// Writes the arg[1] unsigned integer to the arg[0] position, encoded in
+		// 4 bytes with the arg[2] endianness ("little" or "big"). This mutates
+		// the hex file object but not the copy on disk.
+		"write_u32": &object.Method{
+			Name: "hex.write_u32",
+			Description: "Writes the arg[1] unsigned integer to the arg[0] " +
+				"position, encoded in 4 bytes with the arg[2] endianness " +
+				"(\"little\" or \"big\"). This mutates the hex file object " +
+				"but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.StringObj},
+			MethodFunc: writeUint(4, hexBuiltinWriteAt),
+			Mutating:   true,
+		},
+
+		// Builtin: hex.write_u64(int, int, string) -> no return
+		// Writes the arg[1] unsigned integer to the arg[0] position, encoded in
+		// 8 bytes with the arg[2] endianness ("little" or "big"). This mutates
+		// the hex file object but not the copy on disk.
+		"write_u64": &object.Method{
+			Name: "hex.write_u64",
+			Description: "Writes the arg[1] unsigned integer to the arg[0] " +
+				"position, encoded in 8 bytes with the arg[2] endianness " +
+				"(\"little\" or \"big\"). This mutates the hex file object " +
+				"but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.StringObj},
+			MethodFunc: writeUint(8, hexBuiltinWriteAt),
+			Mutating:   true,
+		},
+
+		// Builtin: hex.insert_at(int, array) -> no return
+		// Not supported for hex files, since shifting the data would change the
+		// addresses of the following records: always returns an error.
+		"insert_at": &object.Method{
+			Name: "hex.insert_at",
+			Description: "Not supported for hex files, since shifting the data " +
+				"would change the addresses of the following records: always " +
+				"returns an error.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.ArrayObj},
+			MethodFunc: hexBuiltinInsertAt,
+			Mutating:   true,
 		},
 
 		// Builtin: hex.binary_size(int) -> int
@@ -361,6 +1199,220 @@ func init() {
 			ArgTypes:   []object.ObjectType{},
 			MethodFunc: hexBuiltinBinarySize,
 		},
+
+		// Builtin: hex.abs_address(int, int) -> int
+		// Returns the absolute address corresponding to the arg[0] segment and
+		// the arg[1] offset, computed as segment * 16 + offset.
+		"abs_address": &object.Method{
+			Name: "hex.abs_address",
+			Description: "Returns the absolute address corresponding to the " +
+				"arg[0] segment and the arg[1] offset, computed as segment * 16 + " +
+				"offset.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.IntegerObj},
+			MethodFunc: hexBuiltinAbsAddress,
+		},
+
+		// Builtin: hex.record_address(int) -> int
+		// Returns the absolute start address of the nth record, taking into
+		// account the extended address records that precede it.
+		"record_address": &object.Method{
+			Name: "hex.record_address",
+			Description: "Returns the absolute start address of the nth record, " +
+				"taking into account the extended address records that precede it.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj},
+			MethodFunc: hexBuiltinRecordAddress,
+		},
+
+		// Builtin: hex.extended_linear_base() -> map
+		// Returns the base address transitions established by the extended
+		// segment and extended linear address records, as a map from the index
+		// of each of these records to the base address it sets.
+		"extended_linear_base": &object.Method{
+			Name: "hex.extended_linear_base",
+			Description: "Returns the base address transitions established by " +
+				"the extended segment and extended linear address records, as a " +
+				"map from the index of each of these records to the base address " +
+				"it sets.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: hexBuiltinExtendedLinearBase,
+		},
+
+		// Builtin: hex.min_address() -> int
+		// Returns the lowest absolute byte address covered by the data records
+		// of the hex file. Fails if the file does not contain any data.
+		"min_address": &object.Method{
+			Name: "hex.min_address",
+			Description: "Returns the lowest absolute byte address covered by " +
+				"the data records of the hex file. Fails if the file does not " +
+				"contain any data.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: hexBuiltinMinAddress,
+		},
+
+		// Builtin: hex.max_address() -> int
+		// Returns the highest absolute byte address covered by the data records
+		// of the hex file. Fails if the file does not contain any data.
+		"max_address": &object.Method{
+			Name: "hex.max_address",
+			Description: "Returns the highest absolute byte address covered by " +
+				"the data records of the hex file. Fails if the file does not " +
+				"contain any data.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: hexBuiltinMaxAddress,
+		},
+
+		// Builtin: hex.find_all(array) -> array
+		// Returns the absolute addresses of all the non-overlapping occurrences of the
+		// arg[0] byte pattern within the hex file.
+		"find_all": &object.Method{
+			Name: "hex.find_all",
+			Description: "Returns the absolute addresses of all the non-overlapping " +
+				"occurrences of the arg[0] byte pattern within the hex file.",
+			ArgTypes:   []object.ObjectType{object.ArrayObj},
+			MethodFunc: hexBuiltinFindAll,
+		},
+
+		// Builtin: hex.scan_strings(int) -> array
+		// Returns the runs of at least arg[0] printable ascii characters
+		// within the data of the hex file, as [address, string] pairs.
+		"scan_strings": &object.Method{
+			Name: "hex.scan_strings",
+			Description: "Returns the runs of at least arg[0] printable ascii " +
+				"characters within the data of the hex file, as [address, string] " +
+				"pairs.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj},
+			MethodFunc: hexBuiltinScanStrings,
+		},
+
+		// Builtin: hex.region_hash(int, int, string) -> array
+		// Returns an array containing the hash of the arg[1] bytes starting
+		// from the arg[0] address, computed with the arg[2] algorithm.
+		"region_hash": &object.Method{
+			Name: "hex.region_hash",
+			Description: "Returns an array containing the hash of the arg[1] " +
+				"bytes starting from the arg[0] address, computed with the " +
+				"arg[2] algorithm.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.StringObj},
+			MethodFunc: hexBuiltinRegionHash,
+		},
+
+		// Builtin: hex.entropy(int, int) -> int (thousandths of a bit per byte)
+		// Returns the Shannon entropy of the arg[1] bytes starting from the
+		// arg[0] address, in thousandths of a bit per byte: constant data
+		// scores 0, while compressed or encrypted data approaches 8000.
+		"entropy": &object.Method{
+			Name: "hex.entropy",
+			Description: "Returns the Shannon entropy of the arg[1] bytes " +
+				"starting from the arg[0] address, in thousandths of a bit per " +
+				"byte: constant data scores 0, while compressed or encrypted " +
+				"data approaches 8000.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.IntegerObj},
+			MethodFunc: hexBuiltinEntropy,
+		},
+
+		// Builtin: hex.copy_region(int, int, int) -> no return
+		// Copies arg[2] bytes from the arg[0] position to the arg[1] position,
+		// even if the two regions overlap. This mutates the hex file object
+		// but not the copy on disk.
+		"copy_region": &object.Method{
+			Name: "hex.copy_region",
+			Description: "Copies arg[2] bytes from the arg[0] position to the " +
+				"arg[1] position, even if the two regions overlap. This mutates " +
+				"the hex file object but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.IntegerObj},
+			MethodFunc: hexBuiltinCopyRegion,
+			Mutating:   true,
+		},
+
+		// Builtin: hex.fill_pattern(int, int, array) -> no return
+		// Fills arg[1] bytes starting from the arg[0] position by repeating the
+		// arg[2] byte pattern, truncating the last repetition if it does not
+		// fit. This mutates the hex file object but not the copy on disk.
+		"fill_pattern": &object.Method{
+			Name: "hex.fill_pattern",
+			Description: "Fills arg[1] bytes starting from the arg[0] position " +
+				"by repeating the arg[2] byte pattern, truncating the last " +
+				"repetition if it does not fit. This mutates the hex file " +
+				"object but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.ArrayObj},
+			MethodFunc: hexBuiltinFillPattern,
+			Mutating:   true,
+		},
+
+		// Builtin: hex.apply_patch(array) -> no return
+		// Applies the arg[0] patch, an array of [offset, data] edits where data
+		// is a byte array or a hex string, as in write_at. All the edits are
+		// checked before writing, so that an invalid one leaves the file
+		// untouched. This mutates the hex file object but not the copy on disk.
+		"apply_patch": &object.Method{
+			Name: "hex.apply_patch",
+			Description: "Applies the arg[0] patch, an array of [offset, data] " +
+				"edits where data is a byte array or a hex string, as in " +
+				"write_at. All the edits are checked before writing, so that an " +
+				"invalid one leaves the file untouched. This mutates the hex " +
+				"file object but not the copy on disk.",
+			ArgTypes:   []object.ObjectType{object.ArrayObj},
+			MethodFunc: hexBuiltinApplyPatch,
+			Mutating:   true,
+		},
+
+		// Builtin: hex.fill_unused(int) -> no return
+		// Covers the gaps between the data of the hex file, from its lowest to
+		// its highest address, with data records holding the arg[0] byte. This
+		// mutates the hex file object but not the copy on disk.
+		"fill_unused": &object.Method{
+			Name: "hex.fill_unused",
+			Description: "Covers the gaps between the data of the hex file, " +
+				"from its lowest to its highest address, with data records " +
+				"holding the arg[0] byte. This mutates the hex file object but " +
+				"not the copy on disk.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj},
+			MethodFunc: hexBuiltinFillUnused,
+			Mutating:   true,
+		},
+
+		// Builtin: hex.clone() -> hex_file
+		// Returns an independent in-memory copy of the hex file: changes to the
+		// copy do not affect the original file object, and vice versa.
+		"clone": &object.Method{
+			Name: "hex.clone",
+			Description: "Returns an independent in-memory copy of the hex " +
+				"file: changes to the copy do not affect the original file " +
+				"object, and vice versa.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: hexBuiltinClone,
+		},
+
+		// Builtin: hex.equals(hex_file) -> bool
+		// Returns whether the contents of the hex file are identical to the
+		// ones of the passed hex file.
+		"equals": &object.Method{
+			Name: "hex.equals",
+			Description: "Returns whether the contents of the hex file are " +
+				"identical to the ones of the passed hex file.",
+			ArgTypes:   []object.ObjectType{object.HexObj},
+			MethodFunc: hexBuiltinEquals,
+		},
+
+		// Builtin: hex.write_record(string) -> no return
+		// Parses the passed string as a hex record and inserts it before the
+		// EOF record of the file. EOF records and invalid records are rejected.
+		// This mutates the hex file object but not the copy on disk. Call the
+		// save() function to make the changes persistent.
+		"write_record": &object.Method{
+			Name: "hex.write_record",
+			Description: "Parses the passed string as a hex record and inserts " +
+				"it before the EOF record of the file. EOF records and invalid " +
+				"records are rejected. This mutates the hex file object but not " +
+				"the copy on disk. Call the save() function to make the changes " +
+				"persistent.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: hexBuiltinWriteRecord,
+			Mutating:   true,
+		},
 	}
 
 	builtinMethods[object.ElfObj] = MethodMapping{
@@ -415,6 +1467,47 @@ func init() {
 			MethodFunc: elfBuiltinReadSection,
 		},
 
+		// Builtin: elf.relocations(string) -> array
+		// Returns the relocation entries that apply to the specified section,
+		// if it exists, as maps with the "offset", "symbol" and "type" keys,
+		// where the type is the machine-specific relocation type number.
+		"relocations": &object.Method{
+			Name: "elf.relocations",
+			Description: "Returns the relocation entries that apply to the " +
+				"specified section, if it exists, as maps with the \"offset\", " +
+				"\"symbol\" and \"type\" keys, where the type is the " +
+				"machine-specific relocation type number.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: elfBuiltinRelocations,
+		},
+
+		// Builtin: elf.dynamic() -> array
+		// Returns the entries of the dynamic section as maps with the "tag"
+		// and "value" keys, where the value is a string for tags referencing
+		// the dynamic string table, such as DT_NEEDED. Files with no dynamic
+		// section return an empty array.
+		"dynamic": &object.Method{
+			Name: "elf.dynamic",
+			Description: "Returns the entries of the dynamic section as maps " +
+				"with the \"tag\" and \"value\" keys, where the value is a " +
+				"string for tags referencing the dynamic string table, such as " +
+				"DT_NEEDED. Files with no dynamic section return an empty array.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: elfBuiltinDynamic,
+		},
+
+		// Builtin: elf.clone() -> elf_file
+		// Returns an independent in-memory copy of the elf file: changes to the
+		// copy do not affect the original file object, and vice versa.
+		"clone": &object.Method{
+			Name: "elf.clone",
+			Description: "Returns an independent in-memory copy of the elf " +
+				"file: changes to the copy do not affect the original file " +
+				"object, and vice versa.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: elfBuiltinClone,
+		},
+
 		// Builtin: elf.write_section(string, array, int) -> no return
 		// Attempts to write the contents of the arg[1] byte array to the arg[0]
 		// section with arg[2] offset. This mutates the elf file object but not
@@ -429,6 +1522,305 @@ func init() {
 			ArgTypes: []object.ObjectType{object.StringObj, object.ArrayObj,
 				object.IntegerObj},
 			MethodFunc: elfBuiltinWriteSection,
+			Mutating:   true,
+		},
+
+		// Builtin: elf.add_section(string, array, int) -> no return
+		// Appends a new section named arg[0] to the elf file, containing the
+		// arg[1] byte array and with the arg[2] flags. This mutates the elf
+		// file object but not the copy on disk. Call the save() function to
+		// make the changes persistent.
+		"add_section": &object.Method{
+			Name: "elf.add_section",
+			Description: "Appends a new section named arg[0] to the elf file, " +
+				"containing the arg[1] byte array and with the arg[2] flags. This " +
+				"mutates the elf file object but not the copy on disk. Call the " +
+				"save() function to make the changes persistent.",
+			ArgTypes: []object.ObjectType{object.StringObj, object.ArrayObj,
+				object.IntegerObj},
+			MethodFunc: elfBuiltinAddSection,
+			Mutating:   true,
+		},
+	}
+
+	builtinMethods[object.PeObj] = MethodMapping{
+		// Builtin: pe.has_section(string) -> bool
+		// Returns whether the pe file contains a section with the passed name
+		// or not.
+		"has_section": &object.Method{
+			Name: "pe.has_section",
+			Description: "Returns whether the pe file contains a section with " +
+				"the passed name or not.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: peBuiltinHasSection,
+		},
+
+		// Builtin: pe.sections() -> array
+		// Returns an array containing the section names as strings.
+		"sections": &object.Method{
+			Name:        "pe.sections",
+			Description: "Returns an array containing the section names as strings.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  peBuiltinSections,
+		},
+
+		// Builtin: pe.section_address(string) -> int
+		// Returns the relative virtual address of the specified section, if it
+		// exists.
+		"section_address": &object.Method{
+			Name: "pe.section_address",
+			Description: "Returns the relative virtual address of the specified " +
+				"section, if it exists.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: peBuiltinSectionAddress,
+		},
+
+		// Builtin: pe.section_size(string) -> int
+		// Returns the size of the raw data of the specified section, if it
+		// exists.
+		"section_size": &object.Method{
+			Name: "pe.section_size",
+			Description: "Returns the size of the raw data of the specified " +
+				"section, if it exists.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: peBuiltinSectionSize,
+		},
+
+		// Builtin: pe.read_section(string) -> array
+		// Attempts to read the raw contents of the specified section, if it
+		// exists, and returns it as a byte array.
+		"read_section": &object.Method{
+			Name: "pe.read_section",
+			Description: "Attempts to read the raw contents of the specified " +
+				"section, if it exists, and returns it as a byte array.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: peBuiltinReadSection,
+		},
+
+		// Builtin: pe.write_section(string, array, int) -> no return
+		// Attempts to write the contents of the arg[1] byte array to the arg[0]
+		// section with arg[2] offset. This mutates the pe file object but not
+		// the copy on disk. Call the save() function to make the changes
+		// persistent.
+		"write_section": &object.Method{
+			Name: "pe.write_section",
+			Description: "Attempts to write the contents of the arg[1] byte " +
+				"array to the arg[0] section with arg[2] offset. This mutates the " +
+				"pe file object but not the copy on disk. Call the save() function " +
+				"to make the changes persistent.",
+			ArgTypes: []object.ObjectType{object.StringObj, object.ArrayObj,
+				object.IntegerObj},
+			MethodFunc: peBuiltinWriteSection,
+			Mutating:   true,
+		},
+	}
+
+	builtinMethods[object.MachoObj] = MethodMapping{
+		// Builtin: macho.has_section(string) -> bool
+		// Returns whether the macho file contains a section with the passed
+		// name or not.
+		"has_section": &object.Method{
+			Name: "macho.has_section",
+			Description: "Returns whether the macho file contains a section " +
+				"with the passed name or not.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: machoBuiltinHasSection,
+		},
+
+		// Builtin: macho.sections() -> array
+		// Returns an array containing the section names as strings.
+		"sections": &object.Method{
+			Name:        "macho.sections",
+			Description: "Returns an array containing the section names as strings.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  machoBuiltinSections,
+		},
+
+		// Builtin: macho.section_address(string) -> int
+		// Returns the address of the specified section, if it exists.
+		"section_address": &object.Method{
+			Name: "macho.section_address",
+			Description: "Returns the address of the specified section, if it " +
+				"exists.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: machoBuiltinSectionAddress,
+		},
+
+		// Builtin: macho.section_size(string) -> int
+		// Returns the size of the specified section, if it exists.
+		"section_size": &object.Method{
+			Name:        "macho.section_size",
+			Description: "Returns the size of the specified section, if it exists.",
+			ArgTypes:    []object.ObjectType{object.StringObj},
+			MethodFunc:  machoBuiltinSectionSize,
+		},
+
+		// Builtin: macho.read_section(string) -> array
+		// Attempts to read the contents of the specified section, if it exists,
+		// and returns it as a byte array.
+		"read_section": &object.Method{
+			Name: "macho.read_section",
+			Description: "Attempts to read the contents of the specified " +
+				"section, if it exists, and returns it as a byte array.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: machoBuiltinReadSection,
+		},
+
+		// Builtin: macho.write_section(string, array, int) -> no return
+		// Attempts to write the contents of the arg[1] byte array to the arg[0]
+		// section with arg[2] offset. This mutates the macho file object but
+		// not the copy on disk. Call the save() function to make the changes
+		// persistent.
+		"write_section": &object.Method{
+			Name: "macho.write_section",
+			Description: "Attempts to write the contents of the arg[1] byte " +
+				"array to the arg[0] section with arg[2] offset. This mutates the " +
+				"macho file object but not the copy on disk. Call the save() " +
+				"function to make the changes persistent.",
+			ArgTypes: []object.ObjectType{object.StringObj, object.ArrayObj,
+				object.IntegerObj},
+			MethodFunc: machoBuiltinWriteSection,
+			Mutating:   true,
+		},
+
+		// Builtin: macho.segments() -> array
+		// Returns an array containing the segment names as strings.
+		"segments": &object.Method{
+			Name:        "macho.segments",
+			Description: "Returns an array containing the segment names as strings.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  machoBuiltinSegments,
+		},
+
+		// Builtin: macho.segment_address(string) -> int
+		// Returns the virtual address of the specified segment, if it exists.
+		"segment_address": &object.Method{
+			Name: "macho.segment_address",
+			Description: "Returns the virtual address of the specified segment, " +
+				"if it exists.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: machoBuiltinSegmentAddress,
+		},
+
+		// Builtin: macho.segment_size(string) -> int
+		// Returns the virtual memory size of the specified segment, if it
+		// exists.
+		"segment_size": &object.Method{
+			Name: "macho.segment_size",
+			Description: "Returns the virtual memory size of the specified " +
+				"segment, if it exists.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: machoBuiltinSegmentSize,
+		},
+
+		// Builtin: macho.entry() -> int
+		// Returns the address of the entry point specified by the LC_MAIN
+		// command, relative to the __TEXT segment address.
+		"entry": &object.Method{
+			Name: "macho.entry",
+			Description: "Returns the address of the entry point specified by " +
+				"the LC_MAIN command, relative to the __TEXT segment address.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: machoBuiltinEntry,
+		},
+	}
+
+	builtinMethods[object.ZipObj] = MethodMapping{
+		// Builtin: zip.has_entry(string) -> bool
+		// Returns whether the zip file contains an entry with the passed name
+		// or not.
+		"has_entry": &object.Method{
+			Name: "zip.has_entry",
+			Description: "Returns whether the zip file contains an entry with " +
+				"the passed name or not.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: zipBuiltinHasEntry,
+		},
+
+		// Builtin: zip.entries() -> array
+		// Returns an array containing the entry names as strings.
+		"entries": &object.Method{
+			Name:        "zip.entries",
+			Description: "Returns an array containing the entry names as strings.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  zipBuiltinEntries,
+		},
+
+		// Builtin: zip.read_entry(string) -> array
+		// Attempts to read the uncompressed contents of the specified entry, if
+		// it exists, and returns it as a byte array.
+		"read_entry": &object.Method{
+			Name: "zip.read_entry",
+			Description: "Attempts to read the uncompressed contents of the " +
+				"specified entry, if it exists, and returns it as a byte array.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: zipBuiltinReadEntry,
+		},
+
+		// Builtin: zip.add_entry(string, array) -> no return
+		// Appends a new entry named arg[0] to the zip file, containing the
+		// arg[1] byte array. This mutates the zip file object but not the copy
+		// on disk. Call the save() function to make the changes persistent.
+		"add_entry": &object.Method{
+			Name: "zip.add_entry",
+			Description: "Appends a new entry named arg[0] to the zip file, " +
+				"containing the arg[1] byte array. This mutates the zip file " +
+				"object but not the copy on disk. Call the save() function to " +
+				"make the changes persistent.",
+			ArgTypes:   []object.ObjectType{object.StringObj, object.ArrayObj},
+			MethodFunc: zipBuiltinAddEntry,
+			Mutating:   true,
+		},
+	}
+
+	builtinMethods[object.TarObj] = MethodMapping{
+		// Builtin: tar.has_entry(string) -> bool
+		// Returns whether the tar file contains an entry with the passed name
+		// or not.
+		"has_entry": &object.Method{
+			Name: "tar.has_entry",
+			Description: "Returns whether the tar file contains an entry with " +
+				"the passed name or not.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: tarBuiltinHasEntry,
+		},
+
+		// Builtin: tar.entries() -> array
+		// Returns an array containing the entry names as strings.
+		"entries": &object.Method{
+			Name:        "tar.entries",
+			Description: "Returns an array containing the entry names as strings.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  tarBuiltinEntries,
+		},
+
+		// Builtin: tar.read_entry(string) -> array
+		// Attempts to read the contents of the specified entry, if it exists,
+		// and returns it as a byte array.
+		"read_entry": &object.Method{
+			Name: "tar.read_entry",
+			Description: "Attempts to read the contents of the specified entry, " +
+				"if it exists, and returns it as a byte array.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: tarBuiltinReadEntry,
+		},
+
+		// Builtin: tar.add_entry(string, array, int) -> no return
+		// Appends a new regular file entry named arg[0] to the tar file,
+		// containing the arg[1] byte array and with the arg[2] permission mode.
+		// This mutates the tar file object but not the copy on disk. Call the
+		// save() function to make the changes persistent.
+		"add_entry": &object.Method{
+			Name: "tar.add_entry",
+			Description: "Appends a new regular file entry named arg[0] to the " +
+				"tar file, containing the arg[1] byte array and with the arg[2] " +
+				"permission mode. This mutates the tar file object but not the " +
+				"copy on disk. Call the save() function to make the changes " +
+				"persistent.",
+			ArgTypes: []object.ObjectType{object.StringObj, object.ArrayObj,
+				object.IntegerObj},
+			MethodFunc: tarBuiltinAddEntry,
+			Mutating:   true,
 		},
 	}
 
@@ -447,18 +1839,474 @@ func init() {
 			MethodFunc: bytesBuiltinReadAt,
 		},
 
-		// Builtin: bytes.write_at(int, array) -> no return
-		// Attempts to write the contents of the arg[1] byte array to the  arg[0]
-		// position. This mutates the bytes file object but not the copy on disk.
-		// Call the save() function to make the changes persistent.
+		// Builtin: bytes.read_u16(int, string) -> int
+		// Reads the 2 bytes wide unsigned integer found at the arg[0] position,
+		// decoding it with the arg[1] endianness ("little" or "big").
+		"read_u16": &object.Method{
+			Name: "bytes.read_u16",
+			Description: "Reads the 2 bytes wide unsigned integer found at " +
+				"the arg[0] position, decoding it with the arg[1] endianness " +
+				"(\"little\" or \"big\").",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.StringObj},
+			MethodFunc: readUint(2, bytesBuiltinReadAt),
+		},
+
+		// Builtin: bytes.read_u32(int, string) -> int
+		// Reads the 4 bytes wide unsigned integer found at the arg[0] position,
+		// decoding it with the arg[1] endianness ("little" or "big").
+		"read_u32": &object.Method{
+			Name: "bytes.read_u32",
+			Description: "Reads the 4 bytes wide unsigned integer found at " +
+				"the arg[0] position, decoding it with the arg[1] endianness " +
+				"(\"little\" or \"big\").",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.StringObj},
+			MethodFunc: readUint(4, bytesBuiltinReadAt),
+		},
+
+		// Builtin: bytes.read_u64(int, string) -> int
+		// Reads the 8 bytes wide unsigned integer found at the arg[0] position,
+		// decoding it with the arg[1] endianness ("little" or "big").
+		"read_u64": &object.Method{
+			Name: "bytes.read_u64",
+			Description: "Reads the 8 bytes wide unsigned integer found at " +
+				"the arg[0] position, decoding it with the arg[1] endianness " +
+				"(\"little\" or \"big\").",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.StringObj},
+			MethodFunc: readUint(8, bytesBuiltinReadAt),
+		},
+
+		// Builtin: bytes.write_at(int, array|string) -> no return
+		// Attempts to write the contents of the arg[1] byte array, or the bytes
+		// represented by the arg[1] hex string, to the arg[0] position. This
+		// mutates the bytes file object but not the copy on disk. Call the save()
+		// function to make the changes persistent.
 		"write_at": &object.Method{
 			Name: "bytes.write_at",
 			Description: "Attempts to write the contents of the arg[1] byte " +
-				"array to the  arg[0] position. This mutates the bytes file object " +
-				"but not the copy on disk. Call the save() function to make the " +
-				"changes persistent.",
-			ArgTypes:   []object.ObjectType{object.IntegerObj, object.ArrayObj},
+				"array, or the bytes represented by the arg[1] hex string, to the " +
+				"arg[0] position. This mutates the bytes file object but not the " +
+				"copy on disk. Call the save() function to make the changes " +
+				"persistent.",
+			ArgTypes: []object.ObjectType{object.IntegerObj,
+				object.OrType(object.ArrayObj, object.StringObj)},
 			MethodFunc: bytesBuiltinWriteAt,
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.write_u16(int, int, string) -> no return
+		// Writes the arg[1] unsigned integer to the arg[0] position, encoded in
+		// 2 bytes with the arg[2] endianness ("little" or "big"). This mutates
+		// the bytes file object but not the copy on disk.
+		"write_u16": &object.Method{
+			Name: "bytes.write_u16",
+			Description: "Writes the arg[1] unsigned integer to the arg[0] " +
+				"position, encoded in 2 bytes with the arg[2] endianness " +
+				"(\"little\" or \"big\"). This mutates the bytes file object " +
+				"but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.StringObj},
+			MethodFunc: writeUint(2, bytesBuiltinWriteAt),
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.write_u32(int, int, string) -> no return
+		// Writes the arg[1] unsigned integer to the arg[0] position, encoded in
+		// 4 bytes with the arg[2] endianness ("little" or "big"). This mutates
+		// the bytes file object but not the copy on disk.
+		"write_u32": &object.Method{
+			Name: "bytes.write_u32",
+			Description: "Writes the arg[1] unsigned integer to the arg[0] " +
+				"position, encoded in 4 bytes with the arg[2] endianness " +
+				"(\"little\" or \"big\"). This mutates the bytes file object " +
+				"but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.StringObj},
+			MethodFunc: writeUint(4, bytesBuiltinWriteAt),
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.write_u64(int, int, string) -> no return
+		// Writes the arg[1] unsigned integer to the arg[0] position, encoded in
+		// 8 bytes with the arg[2] endianness ("little" or "big"). This mutates
+		// the bytes file object but not the copy on disk.
+		"write_u64": &object.Method{
+			Name: "bytes.write_u64",
+			Description: "Writes the arg[1] unsigned integer to the arg[0] " +
+				"position, encoded in 8 bytes with the arg[2] endianness " +
+				"(\"little\" or \"big\"). This mutates the bytes file object " +
+				"but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.StringObj},
+			MethodFunc: writeUint(8, bytesBuiltinWriteAt),
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.insert_at(int, array) -> no return
+		// Inserts the arg[1] bytes at the arg[0] position, shifting the
+		// following bytes and growing the file. The views taken from the
+		// file are detached from it, keeping their current contents. This
+		// mutates the bytes file object but not the copy on disk.
+		"insert_at": &object.Method{
+			Name: "bytes.insert_at",
+			Description: "Inserts the arg[1] bytes at the arg[0] position, " +
+				"shifting the following bytes and growing the file. The views " +
+				"taken from the file are detached from it, keeping their " +
+				"current contents. This mutates the bytes file object but not " +
+				"the copy on disk.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.ArrayObj},
+			MethodFunc: bytesBuiltinInsertAt,
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.remove_range(int, int) -> no return
+		// Deletes arg[1] bytes starting from the arg[0] position, shifting the
+		// following bytes and shrinking the file. The views taken from the
+		// file are detached from it, keeping their current contents. This
+		// mutates the bytes file object but not the copy on disk.
+		"remove_range": &object.Method{
+			Name: "bytes.remove_range",
+			Description: "Deletes arg[1] bytes starting from the arg[0] " +
+				"position, shifting the following bytes and shrinking the " +
+				"file. The views taken from the file are detached from it, " +
+				"keeping their current contents. This mutates the bytes file " +
+				"object but not the copy on disk.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.IntegerObj},
+			MethodFunc: bytesBuiltinRemoveRange,
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.find_all(array) -> array
+		// Returns the offsets of all the non-overlapping occurrences of the
+		// arg[0] byte pattern within the bytes file.
+		"find_all": &object.Method{
+			Name: "bytes.find_all",
+			Description: "Returns the offsets of all the non-overlapping " +
+				"occurrences of the arg[0] byte pattern within the bytes file.",
+			ArgTypes:   []object.ObjectType{object.ArrayObj},
+			MethodFunc: bytesBuiltinFindAll,
+		},
+
+		// Builtin: bytes.scan_strings(int) -> array
+		// Returns the runs of at least arg[0] printable ascii characters
+		// within the bytes file, as [offset, string] pairs.
+		"scan_strings": &object.Method{
+			Name: "bytes.scan_strings",
+			Description: "Returns the runs of at least arg[0] printable ascii " +
+				"characters within the bytes file, as [offset, string] pairs.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj},
+			MethodFunc: bytesBuiltinScanStrings,
+		},
+
+		// Builtin: bytes.replace(array, array) -> int
+		// Replaces every non-overlapping occurrence of the arg[0] byte pattern
+		// with the arg[1] one, which must have the same length, returning the
+		// number of replaced occurrences. This mutates the bytes file object
+		// but not the copy on disk.
+		"replace": &object.Method{
+			Name: "bytes.replace",
+			Description: "Replaces every non-overlapping occurrence of the " +
+				"arg[0] byte pattern with the arg[1] one, which must have the " +
+				"same length, returning the number of replaced occurrences. " +
+				"This mutates the bytes file object but not the copy on disk.",
+			ArgTypes:   []object.ObjectType{object.ArrayObj, object.ArrayObj},
+			MethodFunc: bytesBuiltinReplace,
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.copy_region(int, int, int) -> no return
+		// Copies arg[2] bytes from the arg[0] position to the arg[1] position,
+		// even if the two regions overlap. This mutates the bytes file object
+		// but not the copy on disk.
+		"copy_region": &object.Method{
+			Name: "bytes.copy_region",
+			Description: "Copies arg[2] bytes from the arg[0] position to the " +
+				"arg[1] position, even if the two regions overlap. This mutates " +
+				"the bytes file object but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.IntegerObj},
+			MethodFunc: bytesBuiltinCopyRegion,
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.fill_pattern(int, int, array) -> no return
+		// Fills arg[1] bytes starting from the arg[0] position by repeating the
+		// arg[2] byte pattern, truncating the last repetition if it does not
+		// fit. This mutates the bytes file object but not the copy on disk.
+		"fill_pattern": &object.Method{
+			Name: "bytes.fill_pattern",
+			Description: "Fills arg[1] bytes starting from the arg[0] position " +
+				"by repeating the arg[2] byte pattern, truncating the last " +
+				"repetition if it does not fit. This mutates the bytes file " +
+				"object but not the copy on disk.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.ArrayObj},
+			MethodFunc: bytesBuiltinFillPattern,
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.apply_patch(array) -> no return
+		// Applies the arg[0] patch, an array of [offset, data] edits where data
+		// is a byte array or a hex string, as in write_at. All the edits are
+		// checked before writing, so that an invalid one leaves the file
+		// untouched. This mutates the bytes file object but not the copy on disk.
+		"apply_patch": &object.Method{
+			Name: "bytes.apply_patch",
+			Description: "Applies the arg[0] patch, an array of [offset, data] " +
+				"edits where data is a byte array or a hex string, as in " +
+				"write_at. All the edits are checked before writing, so that an " +
+				"invalid one leaves the file untouched. This mutates the bytes " +
+				"file object but not the copy on disk.",
+			ArgTypes:   []object.ObjectType{object.ArrayObj},
+			MethodFunc: bytesBuiltinApplyPatch,
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.clone() -> bytes_file
+		// Returns an independent in-memory copy of the bytes file: changes to the
+		// copy do not affect the original file object, and vice versa.
+		"clone": &object.Method{
+			Name: "bytes.clone",
+			Description: "Returns an independent in-memory copy of the bytes " +
+				"file: changes to the copy do not affect the original file " +
+				"object, and vice versa.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: bytesBuiltinClone,
+		},
+
+		// Builtin: bytes.view(int, int) -> bytes_file
+		// Returns a read-only view over the [arg[0], arg[1]) range of the bytes
+		// file, sharing its storage without copying it: later changes to the
+		// original are reflected in the view, which cannot be modified, until
+		// insert_at or remove_range detach it from the original.
+		"view": &object.Method{
+			Name: "bytes.view",
+			Description: "Returns a read-only view over the [arg[0], arg[1]) " +
+				"range of the bytes file, sharing its storage without copying " +
+				"it: later changes to the original are reflected in the view, " +
+				"which cannot be modified, until insert_at or remove_range " +
+				"detach it from the original.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.IntegerObj},
+			MethodFunc: bytesBuiltinView,
+		},
+
+		// Builtin: bytes.equals(bytes_file) -> bool
+		// Returns whether the contents of the bytes file are identical to the
+		// ones of the passed bytes file.
+		"equals": &object.Method{
+			Name: "bytes.equals",
+			Description: "Returns whether the contents of the bytes file are " +
+				"identical to the ones of the passed bytes file.",
+			ArgTypes:   []object.ObjectType{object.BytesObj},
+			MethodFunc: bytesBuiltinEquals,
+		},
+
+		// Builtin: bytes.sum(int, int, int) -> int
+		// Returns the sum of the arg[1] bytes starting from the arg[0]
+		// position, truncated to arg[2] bits (one of 8, 16 or 32).
+		"sum": &object.Method{
+			Name: "bytes.sum",
+			Description: "Returns the sum of the arg[1] bytes starting from the " +
+				"arg[0] position, truncated to arg[2] bits (one of 8, 16 or 32).",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.IntegerObj},
+			MethodFunc: bytesBuiltinSum,
+		},
+
+		// Builtin: bytes.complement_checksum(int, int, int) -> int
+		// Returns the two's complement of bytes.sum over the same region, i.e.
+		// the arg[2] bits value that makes the region sum to zero when added
+		// to it.
+		"complement_checksum": &object.Method{
+			Name: "bytes.complement_checksum",
+			Description: "Returns the two's complement of bytes.sum over the " +
+				"same region, i.e. the arg[2] bits value that makes the region " +
+				"sum to zero when added to it.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.IntegerObj},
+			MethodFunc: bytesBuiltinComplementChecksum,
+		},
+
+		// Builtin: bytes.region_hash(int, int, string) -> array
+		// Returns an array containing the hash of the arg[1] bytes starting
+		// from the arg[0] position, computed with the arg[2] algorithm.
+		"region_hash": &object.Method{
+			Name: "bytes.region_hash",
+			Description: "Returns an array containing the hash of the arg[1] " +
+				"bytes starting from the arg[0] position, computed with the " +
+				"arg[2] algorithm.",
+			ArgTypes: []object.ObjectType{object.IntegerObj, object.IntegerObj,
+				object.StringObj},
+			MethodFunc: bytesBuiltinRegionHash,
+		},
+
+		// Builtin: bytes.entropy(int, int) -> int (thousandths of a bit per byte)
+		// Returns the Shannon entropy of the arg[1] bytes starting from the
+		// arg[0] position, in thousandths of a bit per byte: constant data
+		// scores 0, while compressed or encrypted data approaches 8000.
+		"entropy": &object.Method{
+			Name: "bytes.entropy",
+			Description: "Returns the Shannon entropy of the arg[1] bytes " +
+				"starting from the arg[0] position, in thousandths of a bit per " +
+				"byte: constant data scores 0, while compressed or encrypted " +
+				"data approaches 8000.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.IntegerObj},
+			MethodFunc: bytesBuiltinEntropy,
+		},
+
+		// Builtin: bytes.map(function) -> no return
+		// Applies the passed function to each byte of the file, replacing it
+		// with the returned value, which must be a 1 byte positive integer.
+		// This mutates the bytes file object but not the copy on disk.
+		"map": &object.Method{
+			Name: "bytes.map",
+			Description: "Applies the passed function to each byte of the file, " +
+				"replacing it with the returned value, which must be a 1 byte " +
+				"positive integer. This mutates the bytes file object but not the " +
+				"copy on disk.",
+			ArgTypes: []object.ObjectType{
+				object.OrType(object.FunctionObj, object.BuiltinObj),
+			},
+			MethodFunc: bytesBuiltinMap,
+			Mutating:   true,
+		},
+
+		// Builtin: bytes.cursor() -> bytes_cursor
+		// Returns a cursor at the start of the bytes file, which reads it
+		// sequentially, advancing past the data it reads.
+		"cursor": &object.Method{
+			Name: "bytes.cursor",
+			Description: "Returns a cursor at the start of the bytes file, " +
+				"which reads it sequentially, advancing past the data it reads.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: bytesBuiltinCursor,
+		},
+	}
+
+	builtinMethods[object.BytesCursorObj] = MethodMapping{
+		// Builtin: cursor.read_u8() -> int
+		// Reads the byte at the cursor position, advancing past it.
+		"read_u8": &object.Method{
+			Name:        "cursor.read_u8",
+			Description: "Reads the byte at the cursor position, advancing past it.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  cursorBuiltinReadU8,
+		},
+
+		// Builtin: cursor.read_u16(string) -> int
+		// Reads the 2 bytes wide unsigned integer at the cursor position,
+		// decoding it with the arg[0] endianness ("little" or "big"), and
+		// advances past it.
+		"read_u16": &object.Method{
+			Name: "cursor.read_u16",
+			Description: "Reads the 2 bytes wide unsigned integer at the cursor " +
+				"position, decoding it with the arg[0] endianness (\"little\" or " +
+				"\"big\"), and advances past it.",
+			ArgTypes:   []object.ObjectType{object.StringObj},
+			MethodFunc: cursorBuiltinReadU16,
+		},
+
+		// Builtin: cursor.read_bytes(int) -> array
+		// Reads arg[0] bytes starting from the cursor position, advancing
+		// past them.
+		"read_bytes": &object.Method{
+			Name: "cursor.read_bytes",
+			Description: "Reads arg[0] bytes starting from the cursor position, " +
+				"advancing past them.",
+			ArgTypes:   []object.ObjectType{object.IntegerObj},
+			MethodFunc: cursorBuiltinReadBytes,
+		},
+
+		// Builtin: cursor.skip(int) -> no return
+		// Moves the cursor by arg[0] bytes, backwards if negative.
+		"skip": &object.Method{
+			Name:        "cursor.skip",
+			Description: "Moves the cursor by arg[0] bytes, backwards if negative.",
+			ArgTypes:    []object.ObjectType{object.IntegerObj},
+			MethodFunc:  cursorBuiltinSkip,
+		},
+
+		// Builtin: cursor.seek(int) -> no return
+		// Moves the cursor to the arg[0] position.
+		"seek": &object.Method{
+			Name:        "cursor.seek",
+			Description: "Moves the cursor to the arg[0] position.",
+			ArgTypes:    []object.ObjectType{object.IntegerObj},
+			MethodFunc:  cursorBuiltinSeek,
+		},
+
+		// Builtin: cursor.tell() -> int
+		// Returns the current position of the cursor.
+		"tell": &object.Method{
+			Name:        "cursor.tell",
+			Description: "Returns the current position of the cursor.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  cursorBuiltinTell,
+		},
+	}
+
+	builtinMethods[object.BytesBuilderObj] = MethodMapping{
+		// Builtin: builder.write_u8(int) -> no return
+		// Appends the arg[0] byte to the builder.
+		"write_u8": &object.Method{
+			Name:        "builder.write_u8",
+			Description: "Appends the arg[0] byte to the builder.",
+			ArgTypes:    []object.ObjectType{object.IntegerObj},
+			MethodFunc:  builderBuiltinWriteU8,
+		},
+
+		// Builtin: builder.write_u16(int, string) -> no return
+		// Appends the arg[0] unsigned integer to the builder, encoded in 2
+		// bytes with the arg[1] endianness ("little" or "big").
+		"write_u16": &object.Method{
+			Name: "builder.write_u16",
+			Description: "Appends the arg[0] unsigned integer to the builder, " +
+				"encoded in 2 bytes with the arg[1] endianness (\"little\" or " +
+				"\"big\").",
+			ArgTypes:   []object.ObjectType{object.IntegerObj, object.StringObj},
+			MethodFunc: builderBuiltinWriteU16,
+		},
+
+		// Builtin: builder.write_bytes(array) -> no return
+		// Appends the arg[0] byte array to the builder.
+		"write_bytes": &object.Method{
+			Name:        "builder.write_bytes",
+			Description: "Appends the arg[0] byte array to the builder.",
+			ArgTypes:    []object.ObjectType{object.ArrayObj},
+			MethodFunc:  builderBuiltinWriteBytes,
+		},
+
+		// Builtin: builder.write_string(string, string) -> no return
+		// Appends the arg[0] string to the builder, encoded with the arg[1]
+		// encoding ("utf8", "ascii" or "latin1"), as string.encode does.
+		"write_string": &object.Method{
+			Name: "builder.write_string",
+			Description: "Appends the arg[0] string to the builder, encoded " +
+				"with the arg[1] encoding (\"utf8\", \"ascii\" or \"latin1\"), as " +
+				"string.encode does.",
+			ArgTypes:   []object.ObjectType{object.StringObj, object.StringObj},
+			MethodFunc: builderBuiltinWriteString,
+		},
+
+		// Builtin: builder.size() -> int
+		// Returns the number of bytes appended to the builder.
+		"size": &object.Method{
+			Name:        "builder.size",
+			Description: "Returns the number of bytes appended to the builder.",
+			ArgTypes:    []object.ObjectType{},
+			MethodFunc:  builderBuiltinSize,
+		},
+
+		// Builtin: builder.build() -> bytes_file
+		// Returns an in-memory bytes file with the contents of the builder.
+		// The file has no name, so it cannot be saved, and it is not affected
+		// by later writes to the builder.
+		"build": &object.Method{
+			Name: "builder.build",
+			Description: "Returns an in-memory bytes file with the contents of " +
+				"the builder. The file has no name, so it cannot be saved, and it " +
+				"is not affected by later writes to the builder.",
+			ArgTypes:   []object.ObjectType{},
+			MethodFunc: builderBuiltinBuild,
 		},
 	}
 }
@@ -473,8 +2321,12 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return &object.Integer{Value: currentNode.Value}
 	case *ast.Boolean:
 		return getBoolReference(currentNode.Value)
+	case *ast.Null:
+		return NULL
 	case *ast.StringLiteral:
 		return &object.String{Value: currentNode.Value}
+	case *ast.InterpolatedString:
+		return evalInterpolatedString(currentNode, env)
 	case *ast.PrefixExpression:
 		right := Eval(currentNode.RightExpression, env)
 		if isError(right) {
@@ -486,6 +2338,13 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(left) {
 			return left
 		}
+		// the right operand of ?? is evaluated only when needed
+		if currentNode.Operator == "??" {
+			if !isNull(left) {
+				return left
+			}
+			return Eval(currentNode.RightExpression, env)
+		}
 		right := Eval(currentNode.RightExpression, env)
 		if isError(right) {
 			return right
@@ -495,6 +2354,8 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalBlockStatement(currentNode, env)
 	case *ast.IfExpression:
 		return evalIfExpression(currentNode, env)
+	case *ast.DoWhileExpression:
+		return evalDoWhileExpression(currentNode, env)
 	case *ast.ReturnStatement:
 		if currentNode.ReturnValue != nil {
 			returnValue := Eval(currentNode.ReturnValue, env)
@@ -505,12 +2366,16 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		}
 		return &object.ReturnValue{Value: NULL}
 	case *ast.VarStatement:
+		if _, isConstant := constants[currentNode.Name.Value]; isConstant {
+			return newLineError(currentNode.LineNumber, "cannot re-define the %s constant", currentNode.Name.Value)
+		}
+
 		varValue := Eval(currentNode.Value, env)
 		if isError(varValue) {
 			return varValue
 		}
-		if varValue == nil || varValue == NULL {
-			return NULL
+		if varValue == nil {
+			varValue = NULL
 		}
 		if varValue.Type() == object.ReturnValueObj {
 			unwrapped := unwrapReturnValue(varValue)
@@ -519,6 +2384,12 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			}
 		}
 		env.Set(currentNode.Name.Value, varValue)
+	case *ast.BreakStatement:
+		brk := &object.Break{Line: currentNode.LineNumber}
+		if currentNode.Label != nil {
+			brk.Label = currentNode.Label.Value
+		}
+		return brk
 	case *ast.NoOp:
 		// do nothing
 	case *ast.Identifier:
@@ -528,6 +2399,9 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		functionBody := currentNode.Body
 		return &object.Function{Parameters: parameters, Body: functionBody, Env: env}
 	case *ast.CallExpression:
+		if err := env.Err(); err != nil {
+			return newLineError(currentNode.LineNumber, "execution interrupted: %s", err)
+		}
 		functionCall := Eval(currentNode.Function, env)
 		args := evalExpressions(currentNode.Arguments, env, currentNode.LineNumber)
 		if len(args) == 1 && isError(args[0]) {
@@ -550,11 +2424,31 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return index
 		}
 		return evalIndexExpression(left, index, currentNode.LineNumber)
+	case *ast.SliceExpression:
+		left := Eval(currentNode.Left, env)
+		if isError(left) {
+			return left
+		}
+		start := Eval(currentNode.Start, env)
+		if isError(start) {
+			return start
+		}
+		end := Eval(currentNode.End, env)
+		if isError(end) {
+			return end
+		}
+		return evalSliceExpression(left, start, end, currentNode.LineNumber)
 	case *ast.MapLiteral:
 		return evalMapLiteral(currentNode, env)
 	case *ast.MethodCallExpression:
 		return evalMethodExpression(currentNode, env)
 	case *ast.TryExpression:
+		// try only propagates runtime errors to the caller, i.e. the
+		// errors returned by builtins and methods when passed invalid
+		// values or when a file operation fails, so that they can be
+		// handled there. Plain errors signal a broken program (type
+		// mismatches, wrong arg count, undefined identifiers, etc.) and
+		// always abort the execution.
 		exprValue := Eval(currentNode.Expression, env)
 		if isRuntimeError(exprValue) {
 			return &object.ReturnValue{Value: exprValue}
@@ -568,16 +2462,46 @@ func evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	var result object.Object
 	for _, statement := range program.Statements {
 		result = Eval(statement, env)
+		if trace := env.Trace(); trace != nil {
+			traced := NULL.Inspect()
+			if result != nil {
+				traced = result.Inspect()
+			}
+			_, _ = fmt.Fprintf(trace, traceTemplate, statement.String(), traced)
+		}
+
 		switch actualResult := result.(type) {
 		case *object.ReturnValue:
 			return actualResult.Value
 		case *object.Error:
 			return actualResult
+		case *object.Break:
+			return unmatchedBreakError(actualResult)
 		}
 	}
 	return result
 }
 
+func evalInterpolatedString(interpolated *ast.InterpolatedString, env *object.Environment) object.Object {
+	var buf strings.Builder
+	for _, part := range interpolated.Parts {
+		value := Eval(part, env)
+		if isError(value) || isRuntimeError(value) {
+			return value
+		}
+
+		switch value := value.(type) {
+		case nil:
+			buf.WriteString(NULL.Inspect())
+		case *object.String:
+			buf.WriteString(value.Value)
+		default:
+			buf.WriteString(value.Inspect())
+		}
+	}
+	return &object.String{Value: buf.String()}
+}
+
 func evalPrefixExpression(operator string, right object.Object, line int) object.Object {
 	switch operator {
 	case "!":
@@ -587,13 +2511,17 @@ func evalPrefixExpression(operator string, right object.Object, line int) object
 	case "~":
 		return evalBitwiseNotExpression(right, line)
 	default:
-		return newError("unknown operator: %s%s on line %d", operator, right.Type(), line)
+		return newLineError(line, "unknown operator: %s%s", operator, right.Type())
 	}
 }
 
 func evalInfixExpression(operator string, left, right object.Object, line int) object.Object {
+	if isNull(left) || isNull(right) {
+		return evalNullInfixExpression(operator, left, right, line)
+	}
+
 	if left.Type() != right.Type() {
-		return newError("type mismatch: %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "type mismatch: %s %s %s", left.Type(), operator, right.Type())
 	}
 
 	switch left.Type() {
@@ -612,21 +2540,59 @@ func evalInfixExpression(operator string, left, right object.Object, line int) o
 	case object.SetObj:
 		return evalSetInfixExpression(operator, left, right, line)
 	default:
-		return newError("unknown operator: %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
+// evalNullInfixExpression handles the comparisons involving null, which is
+// equal only to itself or to the absence of a value, e.g. the result of a
+// builtin that returns nothing.
+func evalNullInfixExpression(operator string, left, right object.Object, line int) object.Object {
+	switch operator {
+	case "==":
+		return getBoolReference(isNull(left) && isNull(right))
+	case "!=":
+		return getBoolReference(!isNull(left) || !isNull(right))
+	default:
+		return newLineError(line, "unknown operator: %s %s %s", typeOrNull(left), operator, typeOrNull(right))
+	}
+}
+
+func isNull(obj object.Object) bool {
+	return obj == nil || obj == NULL
+}
+
+func typeOrNull(obj object.Object) object.ObjectType {
+	if obj == nil {
+		return object.NullObj
+	}
+	return obj.Type()
+}
+
 func evalBlockStatement(blockStatement *ast.BlockStatement, env *object.Environment) object.Object {
 	var result object.Object
 	for _, statement := range blockStatement.Statements {
 		result = Eval(statement, env)
-		if isReturnValOrError(result) {
+		if isReturnValOrError(result) || isBreak(result) {
 			return result
 		}
 	}
 	return result
 }
 
+func isBreak(obj object.Object) bool {
+	return obj != nil && obj.Type() == object.BreakObj
+}
+
+// unmatchedBreakError reports a break that escaped every enclosing loop,
+// either because it was used outside of a loop or because of its label.
+func unmatchedBreakError(brk *object.Break) *object.Error {
+	if brk.Label == "" {
+		return newLineError(brk.Line, "break outside of a loop")
+	}
+	return newLineError(brk.Line, "unknown loop label %q", brk.Label)
+}
+
 func isReturnValOrError(obj object.Object) bool {
 	switch {
 	case obj == nil:
@@ -653,7 +2619,37 @@ func evalIfExpression(expression *ast.IfExpression, env *object.Environment) obj
 	} else if expression.Alternative != nil {
 		return Eval(expression.Alternative, env)
 	} else {
-		return nil
+		return NULL
+	}
+}
+
+func evalDoWhileExpression(expression *ast.DoWhileExpression, env *object.Environment) object.Object {
+	// the body is always executed once, before checking the condition
+	for {
+		if err := env.Err(); err != nil {
+			return newLineError(expression.LineNumber, "execution interrupted: %s", err)
+		}
+
+		result := Eval(expression.Body, env)
+		if brk, isBrk := result.(*object.Break); isBrk {
+			if brk.Label == "" || (expression.Label != nil && brk.Label == expression.Label.Value) {
+				return NULL
+			}
+			return brk
+		}
+
+		if isReturnValOrError(result) {
+			return result
+		}
+
+		condition := Eval(expression.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+
+		if !isTruthy(condition) {
+			return NULL
+		}
 	}
 }
 
@@ -672,7 +2668,7 @@ func evalUnaryNotExpression(right object.Object) object.Object {
 
 func evalUnaryMinusExpression(right object.Object, line int) object.Object {
 	if right.Type() != object.IntegerObj {
-		return newError("unsupported operand '%s' for unary minus on line %d", right.Type(), line)
+		return newLineError(line, "unsupported operand '%s' for unary minus", right.Type())
 	}
 
 	intValue := right.(*object.Integer).Value
@@ -681,7 +2677,7 @@ func evalUnaryMinusExpression(right object.Object, line int) object.Object {
 
 func evalBitwiseNotExpression(right object.Object, line int) object.Object {
 	if right.Type() != object.IntegerObj {
-		return newError("unsupported operand '%s' for bitwise not on line %d", right.Type(), line)
+		return newLineError(line, "unsupported operand '%s' for bitwise not", right.Type())
 	}
 
 	intValue := right.(*object.Integer).Value
@@ -714,12 +2710,12 @@ func evalIntegerInfixExpression(operator string, left, right object.Object, line
 		return &object.Integer{Value: leftValue * rightValue}
 	case "/":
 		if rightValue == 0 {
-			return newError("division by zero on line %d", line)
+			return newLineError(line, "division by zero")
 		}
 		return &object.Integer{Value: leftValue / rightValue}
 	case "%":
 		if rightValue == 0 {
-			return newError("division by zero on line %d", line)
+			return newLineError(line, "division by zero")
 		}
 		return &object.Integer{Value: leftValue % rightValue}
 	case "|":
@@ -730,12 +2726,12 @@ func evalIntegerInfixExpression(operator string, left, right object.Object, line
 		return &object.Integer{Value: leftValue ^ rightValue}
 	case "<<":
 		if rightValue < 0 {
-			return newError("attemping a negative bit-shift on line %d", line)
+			return newLineError(line, "attemping a negative bit-shift")
 		}
 		return &object.Integer{Value: leftValue << rightValue}
 	case ">>":
 		if rightValue < 0 {
-			return newError("attemping a negative bit-shift on line %d", line)
+			return newLineError(line, "attemping a negative bit-shift")
 		}
 		return &object.Integer{Value: leftValue >> rightValue}
 	case "==":
@@ -751,7 +2747,7 @@ func evalIntegerInfixExpression(operator string, left, right object.Object, line
 	case "<=":
 		return getBoolReference(leftValue <= rightValue)
 	default:
-		return newError("unknown operator %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "unknown operator %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
@@ -769,7 +2765,7 @@ func evalBooleanInfixExpression(operator string, left, right object.Object, line
 	case "||":
 		return getBoolReference(leftValue || rightValue)
 	default:
-		return newError("unknown operator %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "unknown operator %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
@@ -784,7 +2780,7 @@ func evalStringInfixExpression(operator string, left, right object.Object, line
 	case "!=":
 		return getBoolReference(leftString != rightString)
 	default:
-		return newError("unsupported operator %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "unsupported operator %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
@@ -797,7 +2793,7 @@ func evalTypeInfixExpression(operator string, left, right object.Object, line in
 	case "!=":
 		return getBoolReference(leftType != rightType)
 	default:
-		return newError("unknown operator %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "unknown operator %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
@@ -812,7 +2808,7 @@ func evalArrayInfixExpression(operator string, left, right object.Object, line i
 	case "!=":
 		return getBoolReference(!arrayEquals(leftArray, rightArray))
 	default:
-		return newError("unknown operator %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "unknown operator %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
@@ -825,7 +2821,7 @@ func evalMapInfixExpression(operator string, left, right object.Object, line int
 	case "!=":
 		return getBoolReference(!mapEquals(leftMap, rightMap))
 	default:
-		return newError("unknown operator %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "unknown operator %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
@@ -868,7 +2864,7 @@ func evalSetInfixExpression(operator string, left, right object.Object, line int
 	case "!=":
 		return getBoolReference(!setEquals(leftSet, rightSet))
 	default:
-		return newError("unknown operator %s %s %s on line %d", left.Type(), operator, right.Type(), line)
+		return newLineError(line, "unknown operator %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
@@ -877,10 +2873,14 @@ func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object
 		return value
 	}
 
+	if constant, ok := constants[node.Value]; ok {
+		return constant
+	}
+
 	if builtin, ok := builtins[node.Value]; ok {
 		return builtin
 	}
-	return newError("undefined identifier '%s' on line %d", node.Value, node.LineNumber)
+	return newLineError(node.LineNumber, "undefined identifier '%s'", node.Value)
 }
 
 func evalExpressions(expressions []ast.Expression, env *object.Environment, line int) []object.Object {
@@ -888,8 +2888,9 @@ func evalExpressions(expressions []ast.Expression, env *object.Environment, line
 	for _, expression := range expressions {
 		evaluatedExpr := Eval(expression, env)
 		if isError(evaluatedExpr) {
-			err := evaluatedExpr.(*object.Error)
-			err.Message += fmt.Sprintf(" on line %d", line)
+			if err := evaluatedExpr.(*object.Error); err.Line <= 0 {
+				err.Line = line
+			}
 			return []object.Object{evaluatedExpr}
 		}
 		evaluatedExpressions = append(evaluatedExpressions, evaluatedExpr)
@@ -901,36 +2902,80 @@ func evalIndexExpression(indexed, index object.Object, line int) object.Object {
 	switch {
 	case indexed.Type() == object.ArrayObj && index.Type() == object.IntegerObj:
 		return evalArrayIndexExpression(indexed, index, line)
+	case indexed.Type() == object.StringObj && index.Type() == object.IntegerObj:
+		return evalStringIndexExpression(indexed, index, line)
 	case indexed.Type() == object.MapObj:
 		return evalMapIndexExpression(indexed, index, line)
 	case indexed.Type() == object.ArrayObj && index.Type() != object.IntegerObj:
-		return newError("attempting to use a non-integer as an array index on line %d", line)
+		return newLineError(line, "attempting to use a non-integer as an array index")
+	case indexed.Type() == object.StringObj && index.Type() != object.IntegerObj:
+		return newLineError(line, "attempting to use a non-integer as a string index")
 	default:
-		return newError("attempting to index a non-subscriptable object (%s) on line %d", indexed.Type(), line)
+		return newLineError(line, "attempting to index a non-subscriptable object (%s)", indexed.Type())
 	}
 }
 
+func evalSliceExpression(sliced, start, end object.Object, line int) object.Object {
+	str, isString := sliced.(*object.String)
+	if !isString {
+		return newLineError(line, "attempting to slice a non-sliceable object (%s)", sliced.Type())
+	}
+
+	startIdx, isStartInt := start.(*object.Integer)
+	endIdx, isEndInt := end.(*object.Integer)
+	if !isStartInt || !isEndInt {
+		return newLineError(line, "attempting to use a non-integer as a slice index")
+	}
+
+	substr, ok := substring(str.Value, startIdx.Value, endIdx.Value)
+	if !ok {
+		return newLineError(line, "attempted an out of bounds slice [%d:%d] of a string", startIdx.Value, endIdx.Value)
+	}
+	return &object.String{Value: substr}
+}
+
 func evalArrayIndexExpression(array, index object.Object, line int) object.Object {
 	arrayObject := array.(*object.Array)
 	idx := index.(*object.Integer).Value
 	maxIdx := int64(len(arrayObject.Elements) - 1)
 
 	if idx < 0 || idx > maxIdx {
-		return newError("attempted an out of bounds access to an array with index %d on line %d ", idx, line)
+		return newLineError(line, "attempted an out of bounds access to an array with index %d", idx)
 	}
 	return arrayObject.Elements[idx]
 }
 
+// evalStringIndexExpression returns the byte at the passed index as a
+// one-character string. Negative indices count backwards from the end of
+// the string, so that -1 is the last byte.
+func evalStringIndexExpression(str, index object.Object, line int) object.Object {
+	strObject := str.(*object.String)
+	idx := index.(*object.Integer).Value
+	length := int64(len(strObject.Value))
+
+	if idx < 0 {
+		idx += length
+	}
+
+	if idx < 0 || idx >= length {
+		return newLineError(line, "attempted an out of bounds access to a string with index %d",
+			index.(*object.Integer).Value)
+	}
+	return &object.String{Value: strObject.Value[idx : idx+1]}
+}
+
 func evalMapIndexExpression(hashmap, index object.Object, line int) object.Object {
 	mapObject := hashmap.(*object.Map)
 	key, isHashable := index.(object.Hashable)
 	if !isHashable {
-		return newError("attempted to access a map with a non-hashable key on line %d", line)
+		return newLineError(line, "attempted to access a map with a non-hashable key")
 	}
 
 	pair, ok := mapObject.Mappings[key.HashKey()]
 	if !ok {
-		return newKeyError("%s", index.Inspect())
+		keyErr := newKeyError("%s", index.Inspect())
+		keyErr.Line = line
+		return keyErr
 	}
 	return pair.Value
 }
@@ -946,7 +2991,7 @@ func evalMapLiteral(mapLiteral *ast.MapLiteral, env *object.Environment) object.
 
 		hashKey, ok := key.(object.Hashable)
 		if !ok {
-			return newError("attempted to access a map with a non-hashable key on line %d", mapLiteral.LineNumber)
+			return newLineError(mapLiteral.LineNumber, "attempted to access a map with a non-hashable key")
 		}
 
 		value := Eval(valueNode, env)
@@ -969,7 +3014,7 @@ func evalMethodExpression(methodExpression *ast.MethodCallExpression, env *objec
 	methodName := methodExpression.Called.Function.String()
 	method, exists := builtinMethods[evaluatedCaller.Type()][methodName]
 	if !exists {
-		return newError("%s has no method called %s on line %d", evaluatedCaller.Type(), methodName, methodExpression.LineNumber)
+		return newLineError(methodExpression.LineNumber, "%s has no method called %s", evaluatedCaller.Type(), methodName)
 	}
 
 	args := evalExpressions(methodExpression.Called.Arguments, env, methodExpression.LineNumber)
@@ -989,16 +3034,19 @@ func callFunction(funcName string, funcObj object.Object, args []object.Object,
 		if validateFunctionCall(function, args) {
 			functionEnv := extendFunctionEnvironment(function, args)
 			evaluatedFunction := Eval(function.Body, functionEnv)
+			if brk, isBrk := evaluatedFunction.(*object.Break); isBrk {
+				return unmatchedBreakError(brk)
+			}
 			return unwrapReturnValue(evaluatedFunction)
 		}
 		nameOnly := funcName[:strings.Index(funcName, "(")]
-		return newError("function %q was called with a wrong number of args on line %d", nameOnly, line)
+		return newLineError(line, "function %q was called with a wrong number of args", nameOnly)
 	case *object.Builtin:
 		return execBuiltin(function, line, args...)
 	case *object.Method:
 		return execBuiltin(function, line, args...)
 	default:
-		return newError("'%s' identifier is not a function on line %d", funcObj.Type(), line)
+		return newLineError(line, "'%s' identifier is not a function", funcObj.Type())
 	}
 }
 
@@ -1098,10 +3146,18 @@ func setEquals(obj1, obj2 *object.Set) bool {
 	return true
 }
 
+// newError returns a fatal error, which cannot be recovered with try.
+// Builtins and methods should return a *object.RuntimeError instead.
 func newError(format string, args ...any) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(format, args...)}
 }
 
+// newLineError returns an error raised on the passed line, which
+// gets reported together with the message.
+func newLineError(line int, format string, args ...any) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, args...), Line: line}
+}
+
 func isError(obj object.Object) bool {
 	if obj == nil {
 		return false
@@ -1145,6 +3201,34 @@ func newElfError(msg string, args ...any) *object.RuntimeError {
 	}
 }
 
+func newPeError(msg string, args ...any) *object.RuntimeError {
+	return &object.RuntimeError{
+		Kind:    object.PeError,
+		Message: fmt.Sprintf(msg, args...),
+	}
+}
+
+func newMachoError(msg string, args ...any) *object.RuntimeError {
+	return &object.RuntimeError{
+		Kind:    object.MachoError,
+		Message: fmt.Sprintf(msg, args...),
+	}
+}
+
+func newZipError(msg string, args ...any) *object.RuntimeError {
+	return &object.RuntimeError{
+		Kind:    object.ZipError,
+		Message: fmt.Sprintf(msg, args...),
+	}
+}
+
+func newTarError(msg string, args ...any) *object.RuntimeError {
+	return &object.RuntimeError{
+		Kind:    object.TarError,
+		Message: fmt.Sprintf(msg, args...),
+	}
+}
+
 func newBytesError(msg string, args ...any) *object.RuntimeError {
 	return &object.RuntimeError{
 		Kind:    object.BytesError,
@@ -1,12 +1,14 @@
 package evaluator
 
 import (
+	"archive/tar"
 	"bufio"
 	"bytes"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"strconv"
@@ -88,6 +90,10 @@ func TestEvalBooleanExpression(t *testing.T) {
 		{"true || false", true},
 		{"false || true", true},
 		{"false || false", false},
+		{"true and true", true},
+		{"true and false", false},
+		{"false or true", true},
+		{"false or false", false},
 	}
 
 	for _, testCase := range tests {
@@ -106,6 +112,8 @@ func TestUnaryNotOperator(t *testing.T) {
 		{"!!true", true},
 		{"!!false", false},
 		{"!5", false},
+		{"not true", false},
+		{"not not false", false},
 	}
 
 	for _, testCase := range tests {
@@ -141,6 +149,131 @@ func TestIfElseExpression(t *testing.T) {
 	}
 }
 
+func TestDoWhileExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var i = 0\ndo {\n\tvar i = i + 1\n} while false\ni", 1},
+		{"var i = 0\ndo {\n\tvar i = i + 1\n} while i < 5\ni", 5},
+		{"var i = 10\ndo {\n\tvar i = i + 1\n} while i < 5\ni", 11},
+		{"var f = fun() { var i = 0\ndo {\nvar i = i + 1\nif i == 3 { ret i } } while true }\nf()", 3},
+		{"do { 1 } while false", nil},
+		{"do { 1 } while x", object.ErrorObj},
+		{"do { error(\"failed\") } while true", object.RuntimeErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evaluated, int64(expected))
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestLabeledBreak(t *testing.T) {
+	nested := `var i = 0
+var total = 0
+outer: do {
+	var i = i + 1
+	var j = 0
+	do {
+		var j = j + 1
+		var total = total + 1
+		if i == 2 && j == 2 {
+			break %s
+		}
+	} while j < 3
+} while i < 3
+total`
+
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{fmt.Sprintf(nested, "outer"), 5},
+		{fmt.Sprintf(nested, ""), 8},
+		{"var i = 0\ndo {\n\tvar i = i + 1\n\tif i == 3 { break }\n} while true\ni", 3},
+		{"var i = 0\nloop: do {\n\tvar i = i + 1\n\tbreak loop\n} while true\ni", 1},
+		{fmt.Sprintf(nested, "inner"), object.ErrorObj},
+		{"break", object.ErrorObj},
+		{"inner: do {\n\tbreak outer\n} while true", object.ErrorObj},
+		{"do {\n\tfun() { break }()\n} while true", object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evaluated, int64(expected))
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestNullLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"null", nil},
+		{"var a = null\na", nil},
+		{"if false {}", nil},
+		{"var f = fun() { ret }\nf()", nil},
+		{"null == null", true},
+		{"null != null", false},
+		{"var a = if false { 1 }\na == null", true},
+		{"var a = 1\na == null", false},
+		{"var a = 1\na != null", true},
+		{"null == \"null\"", false},
+		{"[] == null", false},
+		{"print() == null", true},
+		{"type(null)", "Null"},
+		{"null ?? 1", 1},
+		{"2 ?? 1", 2},
+		{"var a = if false { 1 }\na ?? \"default\"", "default"},
+		{"null ?? null ?? 3", 3},
+		{"false ?? true", false},
+		{"print() ?? 4", 4},
+		{"var m = {1: null}\nm[1] ?? 5", 5},
+		{"1 ?? undefined_identifier", 1},
+		{"null ?? undefined_identifier", object.ErrorObj},
+		{"is_null(null)", true},
+		{"is_null(if false { 1 })", true},
+		{"is_null(print())", true},
+		{"is_null(0)", false},
+		{"is_null(\"\")", false},
+		{"is_null([])", false},
+		{"is_null()", object.ErrorObj},
+		{"null + 1", object.ErrorObj},
+		{"null < null", object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			testStringObject(t, evaluated, expected)
+		case object.ObjectType:
+			if evaluated.Type() != expected {
+				t.Errorf("%s: expected a %s object, got %s", testCase.input, expected, evaluated.Type())
+			}
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
 func TestReturnStatement(t *testing.T) {
 	tests := []struct {
 		input               string
@@ -166,6 +299,30 @@ if 10 > 1 {
 	}
 }
 
+func TestReprRoundTrip(t *testing.T) {
+	tests := []string{
+		`"plain"`,
+		`"\u00e9\u263a"`,
+		`"\x00\x7f\t\r\n"`,
+		`"\x22quoted\x22 \\ \$"`,
+	}
+
+	for _, input := range tests {
+		original := testEval(input)
+		repr := testEval("repr(" + input + ")")
+		reprStr, isStr := repr.(*object.String)
+		if !isStr {
+			t.Errorf("%s: expected a string, got %v", input, repr)
+			continue
+		}
+
+		readBack := testEval(reprStr.Value)
+		if !testStringObject(t, readBack, original.(*object.String).Value) {
+			t.Errorf("%s: the repr %s cannot be read back", input, reprStr.Value)
+		}
+	}
+}
+
 func TestErrorHandling(t *testing.T) {
 	tests := []struct {
 		input            string
@@ -179,6 +336,8 @@ func TestErrorHandling(t *testing.T) {
 		{`"string" + 12`, "type mismatch: String + Int on line 1"},
 		{`"string" + true`, "type mismatch: String + Bool on line 1"},
 		{`"string" - "string2"`, "unsupported operator String - String on line 1"},
+		{"\nprint(1 / 0)", "division by zero on line 2"},
+		{"[1].zip_with([2], fun(a, b) {\n  ret a / 0\n})", "division by zero on line 2"},
 	}
 
 	for _, testCase := range tests {
@@ -189,8 +348,8 @@ func TestErrorHandling(t *testing.T) {
 			continue
 		}
 
-		if errorObj.Message != testCase.expectedErrorMsg {
-			t.Errorf("expected %s error, got %s", testCase.expectedErrorMsg, errorObj.Message)
+		if errorObj.Inspect() != "Error: "+testCase.expectedErrorMsg {
+			t.Errorf("expected %s error, got %s", testCase.expectedErrorMsg, errorObj.Inspect())
 		}
 	}
 }
@@ -211,6 +370,121 @@ func TestVarStatement(t *testing.T) {
 	}
 }
 
+func TestMemoize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{
+			"var calls = {\"n\": 0}\nvar double = fun(x) {\n calls.set(\"n\", calls[\"n\"] + 1)\n ret x * 2\n}\n" +
+				"var f = memoize(double)\nf(2)\nf(2)\nf(3)\n[f(2), f(3), calls[\"n\"]]",
+			[]int64{4, 6, 2},
+		},
+		{
+			"var calls = {\"n\": 0}\nvar add = fun(x, y) {\n calls.set(\"n\", calls[\"n\"] + 1)\n ret x + y\n}\n" +
+				"var f = memoize(add)\n[f(1, 2), f(1, 2), f(2, 1), calls[\"n\"]]",
+			[]int64{3, 3, 3, 2},
+		},
+		{
+			"var calls = {\"n\": 0}\nvar first = fun(x) {\n calls.set(\"n\", calls[\"n\"] + 1)\n ret x[0]\n}\n" +
+				"var f = memoize(first)\n[f([1]), f([1]), calls[\"n\"]]",
+			[]int64{1, 1, 2},
+		},
+		{"var f = memoize(fun(x) { ret x + 1 })\n[1, 2].map(f)", []int64{2, 3}},
+		{"memoize(fun(x) { ret x })(1, 2)", object.ErrorObj},
+		{"memoize(hex)", object.ErrorObj},
+		{"memoize(1)", object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case []int64:
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestFunctionCombinators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var inc = fun(x) { ret x + 1 }\nvar double = fun(x) { ret x * 2 }\ncompose(inc, double)(5)", int64(11)},
+		{"var inc = fun(x) { ret x + 1 }\nvar double = fun(x) { ret x * 2 }\ncompose(double, inc)(5)", int64(12)},
+		{"var inc = fun(x) { ret x + 1 }\nvar double = fun(x) { ret x * 2 }\nvar square = fun(x) { ret x * x }\npipe(inc, double, square)(2)", int64(36)},
+		{"var inc = fun(x) { ret x + 1 }\nvar double = fun(x) { ret x * 2 }\nvar square = fun(x) { ret x * x }\npipe(square, double, inc)(2)", int64(9)},
+		{"pipe(fun(x) { ret x + 1 })(1)", int64(2)},
+		{"len(compose(hex, fun(x) { ret x * 16 })(1))", int64(4)},
+		{"[1, 2].map(pipe(fun(x) { ret x + 1 }, fun(x) { ret x * 10 }))[1]", int64(30)},
+		{"compose(fun(x) { ret x }, fun(x, y) { ret x })", object.RuntimeErrorObj},
+		{"compose(fun(x) { ret x })", object.ErrorObj},
+		{"compose(1, fun(x) { ret x })", object.ErrorObj},
+		{"pipe()", object.RuntimeErrorObj},
+		{"pipe(fun(x) { ret x }, 2)", object.RuntimeErrorObj},
+		{"pipe(fun(x) { ret x })(1, 2)", object.ErrorObj},
+		{"pipe(fun(x) { ret x.pop() }, fun(x) { ret x })([])", object.RuntimeErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int64:
+			testIntegerObject(t, testCase.input, evaluated, expected)
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestPartial(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var sub = fun(x, y) { ret x - y }\npartial(sub, 10)(3)", int64(7)},
+		{"var sub = fun(x, y) { ret x - y }\npartial(sub, 10, 3)()", int64(7)},
+		{"var sub = fun(x, y) { ret x - y }\npartial(sub)(10, 3)", int64(7)},
+		{"var sum = fun(x, y, z) { ret x + y + z }\npartial(partial(sum, 1), 2)(3)", int64(6)},
+		{"[1, 2].map(partial(fun(x, y) { ret x * y }, 3))[1]", int64(6)},
+		{"partial(as_int, [1, 0])(\"big\")", int64(256)},
+		{"var sub = fun(x, y) { ret x - y }\npartial(sub, 10)(3, 4)", object.ErrorObj},
+		{"var sub = fun(x, y) { ret x - y }\npartial(sub, 10)()", object.ErrorObj},
+		{"partial(as_int, [1, 0])(\"big\", 1, 2)", object.ErrorObj},
+		{"var sub = fun(x, y) { ret x - y }\npartial(sub, 1, 2, 3)", object.BuiltinObj},
+		{"[12].zip_with([18], partial(gcd))[0]", int64(6)},
+		{"[12, 18].map(partial(gcd, 8))[1]", int64(2)},
+		{"[1].zip_with([2], partial(fun(x, y, z) { ret x + y + z }, 10))[0]", int64(13)},
+		{"partial(1, 2)", object.RuntimeErrorObj},
+		{"partial()", object.RuntimeErrorObj},
+		{
+			"var sub = fun(x, y) { ret x - y }\npartial(sub, 1, 2, 3)()",
+			"'<anonymous function>' takes 2 argument(s), but 3 were bound to it on line 2",
+		},
+		{"partial(gcd, 1, 2, 3)()", "'gcd' takes 2 argument(s), but 3 were bound to it on line 1"},
+		{"partial(gcd, 8)(1, 2)", "'partial(gcd)' requires 1 parameter(s) (Int), got partial(gcd)(1, 2) (1 Int, 1 Int) on line 1"},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int64:
+			testIntegerObject(t, testCase.input, evaluated, expected)
+		case string:
+			errObj, isErr := evaluated.(*object.Error)
+			if !isErr || errObj.Inspect() != "Error: "+expected {
+				t.Errorf("%s: expected the %q error, got %v", testCase.input, expected, evaluated)
+			}
+		case object.ObjectType:
+			if evaluated.Type() != expected {
+				t.Errorf("%s: expected object of type %s, got %s", testCase.input, expected, evaluated.Type())
+			}
+		}
+	}
+}
+
 func TestFunctionLiterals(t *testing.T) {
 	input := "fun(a) { a * a }\n"
 	expectedFunBody := "(a*a)"
@@ -311,6 +585,116 @@ func TestStringLiteral(t *testing.T) {
 	}
 }
 
+func TestInterpolatedString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var name = \"fw\"\n\"hello ${name}!\"", "hello fw!"},
+		{`"${1 + 2} == 3"`, "3 == 3"},
+		{`"${[1, 2].map(fun(x) { x * 2 })}, ${{"k": "v"}["k"]}, ${null}"`, "[2, 4], v, null"},
+		{`"outer ${"inner ${1 + 1}"}"`, "outer inner 2"},
+		{`"price: \${x}"`, "price: ${x}"},
+		{`'literal ${x}'`, "literal ${x}"},
+		{`"${x}"`, object.ErrorObj},
+		{`"${1 +}"`, object.ErrorObj},
+		{`"${int("a")}"`, object.RuntimeErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case string:
+			testStringObject(t, evaluated, expected)
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestAssertions(t *testing.T) {
+	passing := []string{
+		`assert_eq(1 + 1, 2)`,
+		`assert_eq("a", "a", "strings differ")`,
+		`assert_eq([1, {"k": 2}], [1, {"k": 2}])`,
+		`assert_eq(null, print())`,
+		`assert_type(1, "Int")`,
+		`assert_type("s", "String", "not a string")`,
+		`assert_type(null, "Null")`,
+		`assert_type(bytes_from_hex("00"), type(bytes_from_hex("01")))`,
+	}
+
+	for _, input := range passing {
+		evaluated := testEval(input)
+		if evaluated != nil && evaluated != NULL {
+			t.Errorf("%s: expected the assertion to pass, got %v", input, evaluated)
+		}
+	}
+
+	failing := []struct {
+		input    string
+		expected string
+	}{
+		{`assert_eq(1, 2)`, "assertion failed: 1 != 2 on line 1"},
+		{`assert_eq("1", 1)`, "assertion failed: \"1\" != 1 on line 1"},
+		{"\nassert_eq([1, 2], [2], \"mismatch\")", "assertion failed: mismatch ([1, 2] != [2]) on line 2"},
+		{`assert_type(1, "String")`, "assertion failed: expected a value of type String, got Int (1) on line 1"},
+		{`assert_type("1", "Int", "bad")`, "assertion failed: bad (expected a value of type Int, got String (\"1\")) on line 1"},
+	}
+
+	for _, testCase := range failing {
+		evaluated := testEval(testCase.input)
+		errObj, isErr := evaluated.(*object.Error)
+		if !isErr {
+			t.Errorf("%s: expected an error, got %v", testCase.input, evaluated)
+			continue
+		}
+
+		if errObj.Inspect() != "Error: "+testCase.expected {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expected, errObj.Inspect())
+		}
+	}
+
+	// failed assertions cannot be recovered from
+	testError(t, `try assert_eq(1, 2)`, object.ErrorObj, testEval(`try assert_eq(1, 2)`))
+	testError(t, `assert_type(1)`, object.ErrorObj, testEval(`assert_type(1)`))
+}
+
+func TestRuntimeErrorLine(t *testing.T) {
+	tests := []struct {
+		input    string
+		line     int
+		expected string
+	}{
+		{`try error("boom")`, 1, "Runtime Error: boom on line 1"},
+		{"var a = 1\n\ntry error(\"boom\")", 3, "Runtime Error: boom on line 3"},
+		{"\ntry from_hex(\"jkjk\")", 2, "Type Error: 'from_hex' - invalid hex digit jk on line 2"},
+		{"var f = fun() {\n  ret error(\"inner\")\n}\ntry f()", 2, "Runtime Error: inner on line 2"},
+		{"var f = partial(as_int, [1, 2], \"weird\")\n\ntry f()", 3, "Type Error: 'as_int' - invalid endianness \"weird\" on line 3"},
+		{"var m = {}\n\ntry m[\"key\"]", 3, "Key Error: key on line 3"},
+		{"var b = bytes_from_hex(\"01\")\ntry b.map(fun(x) {\n  ret error(\"bad byte\")\n})", 3, "Runtime Error: bad byte on line 3"},
+		{"try [1].zip_with([2], fun(a, b) {\n  ret error(\"bad pair\")\n})", 2, "Runtime Error: bad pair on line 2"},
+		{"var p = pipe(from_hex)\ntry p(\"jk\")", 2, "Type Error: 'from_hex' - invalid hex digit jk on line 2"},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+		if !isRuntimeErr {
+			t.Errorf("%s: expected a runtime error, got %v", testCase.input, evaluated)
+			continue
+		}
+
+		if runtimeErr.Line != testCase.line {
+			t.Errorf("%s: expected the error on line %d, got %d", testCase.input, testCase.line, runtimeErr.Line)
+		}
+
+		if runtimeErr.Inspect() != testCase.expected {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expected, runtimeErr.Inspect())
+		}
+	}
+}
+
 func TestBuiltinFunctions(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -323,6 +707,18 @@ func TestBuiltinFunctions(t *testing.T) {
 		{`int(1)`, object.ErrorObj},
 		{`int([1, 2])`, object.ErrorObj},
 		{`int("test")`, object.RuntimeErrorObj},
+		{`parse_int("1010", 2)`, 10},
+		{`parse_int("ff", 16)`, 255},
+		{`parse_int("-7f", 16)`, -127},
+		{`parse_int("zz", 36)`, 1295},
+		{`parse_int("777", 8)`, 511},
+		{`parse_int("102", 2)`, object.RuntimeErrorObj},
+		{`parse_int("0xff", 16)`, object.RuntimeErrorObj},
+		{`parse_int("", 10)`, object.RuntimeErrorObj},
+		{`parse_int("10", 1)`, object.RuntimeErrorObj},
+		{`parse_int("10", 37)`, object.RuntimeErrorObj},
+		{`parse_int("10")`, object.ErrorObj},
+		{`parse_int(10, 10)`, object.ErrorObj},
 		{`hex(255)`, "0xff"},
 		{`hex()`, object.ErrorObj},
 		{`hex([0x01, 0x04, 0xfa, 0xcb])`, "0104facb"},
@@ -330,8 +726,108 @@ func TestBuiltinFunctions(t *testing.T) {
 		{`hex("error")`, object.ErrorObj},
 		{`from_hex("ffab21")`, object.ArrayObj},
 		{`from_hex(0)`, object.ErrorObj},
+		{`from_hex("deadbeef")`, []int64{0xde, 0xad, 0xbe, 0xef}},
+		{`from_hex("0xdeadbeef")`, []int64{0xde, 0xad, 0xbe, 0xef}},
+		{`from_hex("DE AD BE EF")`, []int64{0xde, 0xad, 0xbe, 0xef}},
+		{`from_hex("DE AD\nBE\tEF")`, []int64{0xde, 0xad, 0xbe, 0xef}},
+		{`from_hex("0xde, 0xad, 0xbe, 0xef")`, []int64{0xde, 0xad, 0xbe, 0xef}},
+		{`from_hex("de:ad:be:ef")`, []int64{0xde, 0xad, 0xbe, 0xef}},
+		{`from_hex("DE AD BE E")`, object.RuntimeErrorObj},
+		{`from_hex("DE AD BE EG")`, object.RuntimeErrorObj},
+		{`from_hex("   ")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("deadbeef")`, object.BytesObj},
+		{`bytes_from_hex("de ad be ef").read_at(1, 2)`, []int64{0xad, 0xbe}},
+		{`bytes_from_hex("de ad be e")`, object.RuntimeErrorObj},
+		{`bytes_from_hex(0)`, object.ErrorObj},
+		{`to_hex_string(bytes_from_hex("DE:AD:BE:EF"))`, "deadbeef"},
+		{`to_hex_string([0, 1, 0xab, 255])`, "0001abff"},
+		{`to_hex_string([])`, ""},
+		{`to_hex_string([256])`, object.RuntimeErrorObj},
+		{`to_hex_string("ciao")`, object.ErrorObj},
+		{`base32_encode([0x66, 0x6f, 0x6f, 0x62, 0x61, 0x72])`, "MZXW6YTBOI======"},
+		{`base32_encode([])`, ""},
+		{`base32_decode("MZXW6YTBOI======")`, []int64{0x66, 0x6f, 0x6f, 0x62, 0x61, 0x72}},
+		{`base32_decode(base32_encode([0, 1, 0xab, 255]))`, []int64{0, 1, 0xab, 255}},
+		{`base32_decode("MZXW6YTBOI")`, object.RuntimeErrorObj},
+		{`base32_decode("mzxw6ytboi======")`, object.RuntimeErrorObj},
+		{`try base32_decode("MZXW6YT!")`, object.RuntimeErrorObj},
+		{`base32_encode([256])`, object.RuntimeErrorObj},
+		{`base32_encode("foobar")`, object.ErrorObj},
+		{`base32_decode([1])`, object.ErrorObj},
+		{`to_hex([1, 2, 3, 4])`, object.HexObj},
+		{`to_hex([1, 2, 3, 4]).record(0)`, ":0400000001020304F2"},
+		{`to_hex([1, 2, 3, 4], 2).record(1)`, ":020002000304F5"},
+		{`to_hex(bytes_from_hex("0102030405"), 32).size()`, 2},
+		{`to_hex(from_hex("00112233445566778899aabbccddeeff0011"), 16).size()`, 3},
+		{`to_hex(from_hex("00112233445566778899aabbccddeeff0011"), 32).size()`, 2},
+		{`to_hex([1, 2, 3, 4], 2).read_at(1, 2)`, []int64{2, 3}},
+		{`to_hex([1, 2, 3, 4], 0)`, object.RuntimeErrorObj},
+		{`to_hex([1, 2, 3, 4], 256)`, object.RuntimeErrorObj},
+		{`to_hex([1, 2, 3, 4], "16")`, object.RuntimeErrorObj},
+		{`to_hex([1, 256])`, object.RuntimeErrorObj},
+		{`to_hex("test")`, object.ErrorObj},
+		{`clamp(-5, 0, 10)`, 0},
+		{`clamp(5, 0, 10)`, 5},
+		{`clamp(15, 0, 10)`, 10},
+		{`clamp(0, 0, 0)`, 0},
+		{`clamp(-20, -10, -5)`, -10},
+		{`clamp(5, 10, 0)`, object.RuntimeErrorObj},
+		{`clamp(5, 0)`, object.ErrorObj},
+		{`clamp("5", 0, 10)`, object.ErrorObj},
+		{`gcd(8, 15)`, 1},
+		{`gcd(12, 18)`, 6},
+		{`gcd(-12, 18)`, 6},
+		{`gcd(0, 7)`, 7},
+		{`gcd(0, 0)`, 0},
+		{`gcd(-9223372036854775807 - 1, 0)`, object.RuntimeErrorObj},
+		{`lcm(8, 15)`, 120},
+		{`lcm(12, 18)`, 36},
+		{`lcm(-4, 6)`, 12},
+		{`lcm(0, 5)`, 0},
+		{`lcm(4611686018427387904, 3)`, object.RuntimeErrorObj},
+		{`gcd(1)`, object.ErrorObj},
+		{`lcm(1, "2")`, object.ErrorObj},
+		{`align_up(0x1234, 0x100)`, 0x1300},
+		{`align_up(0x1200, 0x100)`, 0x1200},
+		{`align_up(0, 0x100)`, 0},
+		{`align_up(5, 1)`, 5},
+		{`align_down(0x12ff, 0x100)`, 0x1200},
+		{`align_down(0x1200, 0x100)`, 0x1200},
+		{`align_down(-1, 0x100)`, -0x100},
+		{`align_up(0x1234, 0x300)`, object.RuntimeErrorObj},
+		{`align_down(0x1234, 0)`, object.RuntimeErrorObj},
+		{`align_down(0x1234, -4)`, object.RuntimeErrorObj},
+		{`align_up(9223372036854775807, 2)`, object.RuntimeErrorObj},
+		{`align_up(1)`, object.ErrorObj},
+		{`repr("a")`, `"a"`},
+		{`repr("\u00e9\x01\n\x22")`, `"é\x01\n\x22"`},
+		{`repr(12)`, "12"},
+		{`repr(true)`, "true"},
+		{`repr([1, 2])`, "[1, 2]"},
+		{`repr(["1", 2, [true]])`, `["1", 2, [true]]`},
+		{`repr({"b": 2, "a": "1"})`, `{"a": "1", "b": 2}`},
+		{`repr(set(3, 1, 2))`, "set(1, 2, 3)"},
+		{`repr(bytes_from_hex("000102"))`, `Bytes File("", size=0x3)`},
+		{`repr(len)`, "builtin(len)"},
+		{`repr()`, object.ErrorObj},
 		{`len("")`, 0},
 		{`len("ciao")`, 4},
+		{`to_string([0x63, 0x69, 0x61, 0x6f])`, "ciao"},
+		{`to_string([0x63, 0x69, 0x61, 0x6f, 0, 0x78], "ascii", true)`, "ciao"},
+		{`to_string([0x63, 0x69, 0x61, 0x6f], "ascii", true)`, "ciao"},
+		{`len(to_string([0x63, 0, 0x78], "ascii", false))`, 3},
+		{`len(to_string([0x63, 0, 0x78], "ascii"))`, 3},
+		{`to_string([0x63, 0x69, 0xc3, 0xa0, 0x6f], "utf8")`, "ci\u00e0o"},
+		{`to_string([0x63, 0x69, 0xe0, 0x6f], "latin1")`, "ci\u00e0o"},
+		{`to_string([0x63, 0x69, 0xe0, 0x6f], "ascii")`, object.RuntimeErrorObj},
+		{`to_string([0x63, 0x69, 0xe0, 0x6f], "utf8")`, object.RuntimeErrorObj},
+		{`to_string([0x63, 0x69, 0xe0, 0x6f])`, object.RuntimeErrorObj},
+		{`to_string([0x63], "ebcdic")`, object.RuntimeErrorObj},
+		{`to_string([0x63], 1)`, object.RuntimeErrorObj},
+		{`to_string([0x63], "ascii", 1)`, object.RuntimeErrorObj},
+		{`to_string([256])`, object.RuntimeErrorObj},
+		{`to_string("ciao")`, object.ErrorObj},
+		{`len("\0\a\b\f\v")`, 5},
 		{`len([1, 2, 3])`, 3},
 		{`len({1: 3, 6: 12, "ciao": "test"})`, 3},
 		{`len(set(1, 4, 7, 11))`, 4},
@@ -349,6 +845,13 @@ func TestBuiltinFunctions(t *testing.T) {
 		{`type()`, object.ErrorObj},
 		{`print("ciao")`, nil},
 		{`print(a)`, object.ErrorObj},
+		{`pretty_print({1: 2})`, nil},
+		{`pretty_print()`, object.ErrorObj},
+		{`print_hex([0, 15, 255])`, nil},
+		{`print_hex(bytes_from_hex("cafe"))`, nil},
+		{`print_hex([256])`, object.RuntimeErrorObj},
+		{`print_hex("cafe")`, object.ErrorObj},
+		{`print_hex([1], [2])`, object.ErrorObj},
 		{`contains([1, 2, 3], 1)`, true},
 		{`contains([1, 2, 3], 4)`, false},
 		{`contains({1: 2, 3: 4}, 3)`, true},
@@ -398,7 +901,114 @@ func TestBuiltinFunctions(t *testing.T) {
 		{`as_array(0xab, 1, "non-ex")`, object.RuntimeErrorObj},
 		{`as_array(0xab, 9, "non-ex")`, object.RuntimeErrorObj},
 		{`as_array(0xab, -1, "non-ex")`, object.RuntimeErrorObj},
-		{`as_array(0xab, 1, "big", 1)`, object.ErrorObj},
+		{`as_array(0xab, 1, "big", 1)`, object.RuntimeErrorObj},
+		{`as_array(0xab, 1, "big", "signed", 1)`, object.ErrorObj},
+		{`as_array(-1, 1, "little", "signed")`, []int64{0xff}},
+		{`as_array(-2, 2, "little", "signed")`, []int64{0xfe, 0xff}},
+		{`as_array(-2, 2, "big", "signed")`, []int64{0xff, 0xfe}},
+		{`as_array(-128, 1, "big", "signed")`, []int64{0x80}},
+		{`as_array(127, 1, "big", "signed")`, []int64{0x7f}},
+		{`as_array(-1, 8, "big", "signed")`, []int64{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{`as_array(0xff, 1, "big", "unsigned")`, []int64{0xff}},
+		{`as_array(128, 1, "big", "signed")`, object.RuntimeErrorObj},
+		{`as_array(-129, 1, "big", "signed")`, object.RuntimeErrorObj},
+		{`as_array(-1, 1, "big", "other")`, object.RuntimeErrorObj},
+		{`as_int([0xff], "little")`, 0xff},
+		{`as_int([0xff], "little", "signed")`, -1},
+		{`as_int([0xcd, 0xab], "little")`, 0xabcd},
+		{`as_int([0xab, 0xcd], "big")`, 0xabcd},
+		{`as_int([0xfe, 0xff], "little", "signed")`, -2},
+		{`as_int([0x7f, 0xff], "big", "signed")`, 0x7fff},
+		{`as_int(as_array(-1000, 4, "big", "signed"), "big", "signed")`, -1000},
+		{`as_int([0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff], "big", "signed")`, -1},
+		{`as_int([], "big")`, object.RuntimeErrorObj},
+		{`as_int([1, 2, 3, 4, 5, 6, 7, 8, 9], "big")`, object.RuntimeErrorObj},
+		{`as_int([1000], "big")`, object.RuntimeErrorObj},
+		{`as_int([1], "middle")`, object.RuntimeErrorObj},
+		{`as_int([1], "big", "other")`, object.RuntimeErrorObj},
+		{`as_int(1, "big")`, object.ErrorObj},
+		{`get_bits(0xabcd, 0, 4)`, 0xd},
+		{`get_bits(0xabcd, 4, 8)`, 0xbc},
+		{`get_bits(0xabcd, 15, 1)`, 1},
+		{`get_bits(-1, 0, 64)`, -1},
+		{`get_bits(0xabcd, 60, 5)`, object.RuntimeErrorObj},
+		{`get_bits(0xabcd, -1, 4)`, object.RuntimeErrorObj},
+		{`get_bits(0xabcd, 0, 0)`, object.RuntimeErrorObj},
+		{`get_bits(0xabcd, 0)`, object.ErrorObj},
+		{`set_bits(0xabcd, 4, 8, 0x12)`, 0xa12d},
+		{`set_bits(0, 0, 1, 1)`, 1},
+		{`set_bits(0xff, 0, 4, 0)`, 0xf0},
+		{`set_bits(0, 63, 1, 1)`, math.MinInt64},
+		{`set_bits(0, 4, 4, 0x10)`, object.RuntimeErrorObj},
+		{`set_bits(0, 4, 4, -1)`, object.RuntimeErrorObj},
+		{`set_bits(0, 62, 4, 1)`, object.RuntimeErrorObj},
+		{`set_bits(0, 4, 4)`, object.ErrorObj},
+		{`count_ones(0)`, 0},
+		{`count_ones(0xff)`, 8},
+		{`count_ones(0x8001)`, 2},
+		{`count_ones(-1)`, 64},
+		{`count_ones("1")`, object.ErrorObj},
+		{`count_zeros(0, 8)`, 8},
+		{`count_zeros(0x0f, 8)`, 4},
+		{`count_zeros(0x8001, 16)`, 14},
+		{`count_zeros(-1, 64)`, 0},
+		{`count_zeros(0x100, 8)`, object.RuntimeErrorObj},
+		{`count_zeros(1, 65)`, object.RuntimeErrorObj},
+		{`count_zeros(1)`, object.ErrorObj},
+		{`leading_zeros(1, 8)`, 7},
+		{`leading_zeros(0, 16)`, 16},
+		{`leading_zeros(0x80, 8)`, 0},
+		{`leading_zeros(0x0800, 16)`, 4},
+		{`leading_zeros(1, 64)`, 63},
+		{`leading_zeros(0x100, 8)`, object.RuntimeErrorObj},
+		{`leading_zeros(1, 0)`, object.RuntimeErrorObj},
+		{`leading_zeros(1)`, object.ErrorObj},
+		{`reverse_bits(0x01, 8)`, 0x80},
+		{`reverse_bits(0xf0, 8)`, 0x0f},
+		{`reverse_bits(0x0001, 16)`, 0x8000},
+		{`reverse_bits(0x1234, 16)`, 0x2c48},
+		{`reverse_bits(0x01, 32)`, 0x80000000},
+		{`reverse_bits(0x01, 64)`, math.MinInt64},
+		{`reverse_bits(0x100, 8)`, object.RuntimeErrorObj},
+		{`reverse_bits(0x01, 12)`, object.RuntimeErrorObj},
+		{`reverse_bits(0x01)`, object.ErrorObj},
+		{`byte_width(0)`, 1},
+		{`byte_width(255)`, 1},
+		{`byte_width(256)`, 2},
+		{`byte_width(65535)`, 2},
+		{`byte_width(65536)`, 3},
+		{`byte_width(0x7fffffffffffffff)`, 8},
+		{`len(as_array(65536, byte_width(65536), "little"))`, 3},
+		{`byte_width(-1)`, object.RuntimeErrorObj},
+		{`byte_width("1")`, object.ErrorObj},
+		{`hexdump([65, 66, 67], 4)`, "00000000  41 42 43     |ABC|"},
+		{`hexdump([0, 127, 72, 105], 2)`, "00000000  00 7f  |..|\n00000002  48 69  |Hi|"},
+		{`hexdump([])`, ""},
+		{`hexdump([1000])`, object.RuntimeErrorObj},
+		{`hexdump([1, 2], 0)`, object.RuntimeErrorObj},
+		{`hexdump([1, 2, 3], 0x7fffffffffffffff)`, object.RuntimeErrorObj},
+		{`hexdump([1, 2], 257)`, object.RuntimeErrorObj},
+		{`len(hexdump([1, 2], 256))`, 9 + 3*256 + 3 + 2 + 1},
+		{`hexdump([1, 2], "16")`, object.RuntimeErrorObj},
+		{`hexdump("test")`, object.ErrorObj},
+		{`hex_dump_diff([65, 66, 67], [65, 66, 67])`, "00000000  41 42 43  | 41 42 43"},
+		{`hex_dump_diff([65, 66, 67], [65, 88, 67])`, "00000000  41*42 43  | 41*58 43"},
+		{`hex_dump_diff([1, 2], [1, 2, 3])`, "00000000  01 02*--  | 01 02*03"},
+		{`hex_dump_diff([], [])`, ""},
+		{`hex_dump_diff(bytes_from_hex("000102030405060708090a0b0c0d0e0f10"), from_hex("000102030405060708090a0b0c0d0e0f11"))`,
+			"00000000  00 01 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f  | 00 01 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f\n" +
+				"00000010 *10  |*11"},
+		{`hex_dump_diff([256], [1])`, object.RuntimeErrorObj},
+		{`hex_dump_diff([1], "test")`, object.ErrorObj},
+		{`hex_dump_diff([1])`, object.ErrorObj},
+		{`repr(make_patch([1, 2, 3, 4, 5, 6], [1, 9, 9, 4, 5, 7]))`, "[[1, [9, 9]], [5, [7]]]"},
+		{`repr(make_patch([1, 2, 3], [1, 2, 3]))`, "[]"},
+		{`repr(make_patch(bytes_from_hex("0001"), [2, 3]))`, "[[0, [2, 3]]]"},
+		{"var a = bytes_from_hex(\"000102030405\")\nvar b = bytes_from_hex(\"ff0102fefd05\")\na.apply_patch(make_patch(a, b))\na.read_at(0, 6)",
+			[]int64{0xff, 1, 2, 0xfe, 0xfd, 5}},
+		{`make_patch([1, 2], [1, 2, 3])`, object.RuntimeErrorObj},
+		{`make_patch([256], [1])`, object.RuntimeErrorObj},
+		{`make_patch([1], "test")`, object.ErrorObj},
 		{`as_array("test", 0xab, 1, "big")`, object.ErrorObj},
 	}
 
@@ -427,6 +1037,15 @@ func TestBuiltinFunctions(t *testing.T) {
 	}
 }
 
+func TestVersionBuiltin(t *testing.T) {
+	oldVersion := Version
+	defer func() { Version = oldVersion }()
+
+	Version = "v1.2.3"
+	testStringObject(t, testEval(`version()`), "v1.2.3")
+	testError(t, `version(1)`, object.ErrorObj, testEval(`version(1)`))
+}
+
 func TestHashBuiltinFunction(t *testing.T) {
 	const arraySize = 30
 	const testSize = 100
@@ -482,6 +1101,153 @@ func TestHashBuiltinFunction(t *testing.T) {
 	}
 }
 
+func TestHashBytesFile(t *testing.T) {
+	const fileSize = 4 * 1024 * 1024
+
+	randGen := rand.New(rand.NewSource(time.Now().UnixNano()))
+	testData := make([]byte, fileSize)
+	randGen.Read(testData)
+
+	if err := os.WriteFile("test.bin", testData, 0666); err != nil {
+		t.Fatalf("cannot create the test.bin file")
+	}
+	defer func() { _ = os.Remove("test.bin") }()
+
+	sha1Sum := sha1.Sum(testData)
+	sha256Sum := sha256.Sum256(testData)
+	md5Sum := md5.Sum(testData)
+
+	tests := []struct {
+		algo     string
+		expected []byte
+	}{
+		{"sha1", sha1Sum[:]},
+		{"sha256", sha256Sum[:]},
+		{"md5", md5Sum[:]},
+	}
+
+	for _, testCase := range tests {
+		prog := fmt.Sprintf("hash(open(\"test.bin\", \"bytes\"), \"%s\")", testCase.algo)
+		res, isArray := testEval(prog).(*object.Array)
+		if !isArray {
+			t.Errorf("%s: expected byte array, got %v", prog, res)
+			continue
+		}
+
+		byteResult := make([]byte, len(res.Elements))
+		if err := intArrayToBytes(res, byteResult); err != nil {
+			t.Errorf("%s: expected byte array, got %v", prog, res)
+			continue
+		}
+
+		if !bytes.Equal(testCase.expected, byteResult) {
+			t.Errorf("got a discrepancy in calculating the '%s' hash of a bytes file", testCase.algo)
+		}
+	}
+
+	testError(t, `hash(open("test.bin", "bytes"), "sha3")`, object.RuntimeErrorObj,
+		testEval(`hash(open("test.bin", "bytes"), "sha3")`))
+}
+
+func TestRegionHash(t *testing.T) {
+	hexFile := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var b = bytes_from_hex(\"000102030405\")\nb.region_hash(1, 4, \"sha256\") == hash(b.read_at(1, 4), \"sha256\")", true},
+		{"var b = bytes_from_hex(\"000102030405\")\nb.region_hash(0, 6, \"md5\") == hash(b, \"md5\")", true},
+		{"var b = bytes_from_hex(\"000102030405\")\nb.region_hash(0, 0, \"sha1\") == hash([], \"sha1\")", true},
+		{"var b = bytes_from_hex(\"000102030405\")\nb.region_hash(0, 4, \"sha1\") == hash(b.read_at(1, 4), \"sha1\")", false},
+		{"var h = open(\"test.hex\", \"hex\")\nh.region_hash(0x1C204, 8, \"sha1\") == hash(h.read_at(0x1C204, 8), \"sha1\")", true},
+		{"var h = open(\"test.hex\", \"hex\")\nh.region_hash(0x1C200, 16, \"md5\") == hash(h.read_at(0x1C200, 16), \"md5\")", true},
+		{`bytes_from_hex("0001").region_hash(1, 2, "sha1")`, object.BytesError},
+		{`bytes_from_hex("0001").region_hash(-1, 1, "sha1")`, object.BytesError},
+		{`bytes_from_hex("0001").region_hash(0, 1, "sha3")`, object.TypeError},
+		{`open("test.hex", "hex").region_hash(0x1C20E, 4, "sha1")`, object.HexError},
+		{`open("test.hex", "hex").region_hash(0x1C200, 4, "crc")`, object.TypeError},
+		{`open("test.hex", "hex").region_hash(0x1C200, 4)`, object.ErrorObj},
+	}
+
+	if err := os.WriteFile("test.hex", []byte(hexFile), 0666); err != nil {
+		t.Fatalf("cannot create the test.hex file")
+	}
+	defer func() { _ = os.Remove("test.hex") }()
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
+			}
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	hexFile := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	randGen := rand.New(rand.NewSource(time.Now().UnixNano()))
+	randomData := make([]byte, 1<<16)
+	randGen.Read(randomData)
+
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`bytes_from_hex("ffffffffffffffff").entropy(0, 8)`, int64(0)},
+		{`bytes_from_hex("0001020300010203").entropy(0, 8)`, int64(2000)},
+		{`bytes_from_hex("0001020300010203").entropy(1, 1)`, int64(0)},
+		{`bytes_from_hex("0001").entropy(0, 0)`, int64(0)},
+		{`open("test.hex", "hex").entropy(0x1C200, 16)`, int64(2983)},
+		{`open("test.hex", "hex").entropy(0x1C20B, 2)`, int64(1000)},
+		{`open("test.bin", "bytes").entropy(0, 65536) > 7900`, true},
+		{`bytes_from_hex("0001").entropy(1, 2)`, object.BytesError},
+		{`bytes_from_hex("0001").entropy(-1, 1)`, object.BytesError},
+		{`open("test.hex", "hex").entropy(0x1C20E, 4)`, object.HexError},
+		{`open("test.hex", "hex").entropy(-1, 4)`, object.HexError},
+		{`open("test.hex", "hex").entropy(0x1C200)`, object.ErrorObj},
+	}
+
+	if err := os.WriteFile("test.hex", []byte(hexFile), 0666); err != nil {
+		t.Fatalf("cannot create the test.hex file")
+	}
+	defer func() { _ = os.Remove("test.hex") }()
+
+	if err := os.WriteFile("test.bin", randomData, 0666); err != nil {
+		t.Fatalf("cannot create the test.bin file")
+	}
+	defer func() { _ = os.Remove("test.bin") }()
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int64:
+			testIntegerObject(t, testCase.input, evaluated, expected)
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
+			}
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
 func TestArrayLiterals(t *testing.T) {
 	input := `[5, 5 % 4, 6 & 2]`
 
@@ -655,36 +1421,274 @@ func TestElfFile(t *testing.T) {
 	}
 }
 
-func TestBytesFile(t *testing.T) {
-	bytesFile := [32]byte{}
-
-	input := `open("test.bin", "bytes")`
+func TestPeFile(t *testing.T) {
+	input := `open("test.exe", "pe")`
 
-	err := os.WriteFile("test.bin", bytesFile[:], 0666)
+	err := os.WriteFile("test.exe", peFile, 0666)
 	if err != nil {
-		t.Fatalf("cannot create the test.bin file")
+		t.Fatalf("cannot create the test.exe file")
 	}
-	defer func() { _ = os.Remove("test.bin") }()
+	defer func() { _ = os.Remove("test.exe") }()
 
 	evaluated := testEval(input)
-	elf, ok := evaluated.(*object.BytesFile)
+	pe, ok := evaluated.(*object.PeFile)
 	if !ok {
-		t.Fatalf("expected object of BytesFile type, got %T: %v", evaluated, evaluated)
+		t.Fatalf("expected object of PeFile type, got %T: %v", evaluated, evaluated)
 	}
 
-	if elf.Name() != "test.bin" {
-		t.Fatalf("expected file to have \"test.bin\" as its name, got %q", elf.Name())
+	if pe.Name() != "test.exe" {
+		t.Fatalf("expected file to have \"test.exe\" as its name, got %q", pe.Name())
 	}
 }
 
-func TestMapLiterals(t *testing.T) {
-	input := `var test = 22
-{
-	"test1": 20 * 2,
-	"test2": 2 & 3,
-	"tes"+"t3": 4,
-	test: 22,	
-	true: 1,
+func TestMachoFile(t *testing.T) {
+	input := `open("test.macho", "macho")`
+
+	err := os.WriteFile("test.macho", machoFile, 0666)
+	if err != nil {
+		t.Fatalf("cannot create the test.macho file")
+	}
+	defer func() { _ = os.Remove("test.macho") }()
+
+	evaluated := testEval(input)
+	macho, ok := evaluated.(*object.MachoFile)
+	if !ok {
+		t.Fatalf("expected object of MachoFile type, got %T: %v", evaluated, evaluated)
+	}
+
+	if macho.Name() != "test.macho" {
+		t.Fatalf("expected file to have \"test.macho\" as its name, got %q", macho.Name())
+	}
+}
+
+func TestFileClone(t *testing.T) {
+	hexFile := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	tests := []struct {
+		input    string
+		expected []int64
+	}{
+		{
+			"var h = open(\"test.hex\", \"hex\")\nvar c = h.clone()\nc.write_at(0x1C200, [1, 2])\nh.read_at(0x1C200, 2)",
+			[]int64{0xE0, 0xA5},
+		},
+		{
+			"var h = open(\"test.hex\", \"hex\")\nvar c = h.clone()\nc.write_at(0x1C200, [1, 2])\nc.read_at(0x1C200, 2)",
+			[]int64{1, 2},
+		},
+		{
+			"var h = open(\"test.hex\", \"hex\")\nvar c = h.clone()\nh.write_at(0x1C200, [1, 2])\nc.read_at(0x1C200, 2)",
+			[]int64{0xE0, 0xA5},
+		},
+		{
+			"var h = open(\"test.hex\", \"hex\")\nvar c = h.clone()\nc.write_record(\":020000040800F2\")\n[h.size(), c.size()]",
+			[]int64{3, 4},
+		},
+		{
+			"var e = open(\"test.elf\", \"elf\")\nvar c = e.clone()\nc.write_section(\".metadata\", [1, 2], 0)\n[e.read_section(\".metadata\")[0], c.read_section(\".metadata\")[0]]",
+			[]int64{0, 1},
+		},
+		{
+			"var e = open(\"test.elf\", \"elf\")\nvar c = e.clone()\nc.add_section(\".added\", [1, 2], 0)\n[len(e.sections()), len(c.sections())]",
+			[]int64{15, 16},
+		},
+		{
+			"var b = bytes_from_hex(\"0102\")\nvar c = b.clone()\nc.write_at(0, [0xff])\nb.read_at(0, 2) + c.read_at(0, 2)",
+			[]int64{1, 2, 0xff, 2},
+		},
+	}
+
+	if err := os.WriteFile("test.hex", []byte(hexFile), 0666); err != nil {
+		t.Fatalf("cannot create the test.hex file")
+	}
+	defer func() { _ = os.Remove("test.hex") }()
+
+	if err := os.WriteFile("test.elf", elfFile, 0666); err != nil {
+		t.Fatalf("cannot create the test.elf file")
+	}
+	defer func() { _ = os.Remove("test.elf") }()
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		testArrayObject(t, testCase.input, evaluated, testCase.expected)
+	}
+
+	readOnly := testEval("open(\"test.hex\", \"hex\", \"ro\").clone().write_at(0x1C200, [1])")
+	if readOnly.Type() != object.RuntimeErrorObj {
+		t.Errorf("expected the clone of a read-only file to be read-only, got %v", readOnly)
+	}
+}
+
+func TestReadOnlyFile(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"open(\"test.bin\", \"bytes\", \"ro\").read_at(0, 2)", []int64{0, 0}},
+		{"var b = open(\"test.bin\", \"bytes\", \"rw\")\nb.write_at(0, [1, 2])\nb.read_at(0, 2)", []int64{1, 2}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.write_at(0, [3, 4])\nb.read_at(0, 2)", []int64{3, 4}},
+		{"var b = open(\"test.bin\", \"bytes\", \"rw\")\nb.write_at(0, [5, 6])\nsave(b)", nil},
+		{"open(\"test.bin\", \"bytes\", \"ro\").read_at(0, 2)", []int64{5, 6}},
+		{"open(\"test.bin\", \"bytes\", \"ro\").write_at(0, [1, 2])", object.FileError},
+		{"open(\"test.bin\", \"bytes\", \"ro\").map(fun(x) { ret x })", object.FileError},
+		{"save(open(\"test.bin\", \"bytes\", \"ro\"))", object.FileError},
+		{"open(\"test.elf\", \"elf\", \"ro\").write_section(\".metadata\", [1], 0)", object.FileError},
+		{"open(\"test.elf\", \"elf\", \"ro\").add_section(\".new\", [1], 0)", object.FileError},
+		{"var e = try open(\"test.elf\", \"elf\", \"ro\").write_section(\".metadata\", [1], 0)\ne", object.FileError},
+		{"open(\"test.bin\", \"bytes\", \"wr\")", object.TypeError},
+		{"open(\"test.bin\", \"bytes\", 1)", object.TypeError},
+	}
+
+	if err := os.WriteFile("test.bin", make([]byte, 8), 0666); err != nil {
+		t.Fatalf("cannot create the test.bin file")
+	}
+	defer func() { _ = os.Remove("test.bin") }()
+
+	if err := os.WriteFile("test.elf", elfFile, 0666); err != nil {
+		t.Fatalf("cannot create the test.elf file")
+	}
+	defer func() { _ = os.Remove("test.elf") }()
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case []int64:
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
+			}
+		case nil:
+			if evaluated != nil {
+				t.Errorf("%s: expected no return value, got %v", testCase.input, evaluated)
+			}
+		}
+	}
+}
+
+func TestSaveWithBackup(t *testing.T) {
+	if err := os.WriteFile("test.bin", []byte{1, 2, 3, 4}, 0666); err != nil {
+		t.Fatalf("cannot create the test.bin file")
+	}
+	defer func() { _ = os.Remove("test.bin") }()
+	defer func() { _ = os.Remove("test.bin.bak") }()
+
+	evaluated := testEval("var b = open(\"test.bin\", \"bytes\")\nb.write_at(0, [5, 6])\nsave(b, false)")
+	if evaluated != nil {
+		t.Fatalf("expected no return value, got %v", evaluated)
+	}
+
+	if _, err := os.Stat("test.bin.bak"); !os.IsNotExist(err) {
+		t.Errorf("expected no backup to be created without the backup flag")
+	}
+
+	evaluated = testEval("var b = open(\"test.bin\", \"bytes\")\nb.write_at(2, [7, 8])\nsave(b, true)")
+	if evaluated != nil {
+		t.Fatalf("expected no return value, got %v", evaluated)
+	}
+
+	backup, err := os.ReadFile("test.bin.bak")
+	if err != nil || !bytes.Equal(backup, []byte{5, 6, 3, 4}) {
+		t.Errorf("expected the backup to hold the old contents, got %v (%v)", backup, err)
+	}
+
+	saved, err := os.ReadFile("test.bin")
+	if err != nil || !bytes.Equal(saved, []byte{5, 6, 7, 8}) {
+		t.Errorf("expected the file to hold the new contents, got %v (%v)", saved, err)
+	}
+
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"save(open(\"test.bin\", \"bytes\"), 1)", object.TypeError},
+		{"save(open(\"test.bin\", \"bytes\", \"ro\"), true)", object.FileError},
+		{"save(open(\"test.bin\", \"bytes\"), true, 1)", object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
+			}
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestBytesFile(t *testing.T) {
+	bytesFile := [32]byte{}
+
+	input := `open("test.bin", "bytes")`
+
+	err := os.WriteFile("test.bin", bytesFile[:], 0666)
+	if err != nil {
+		t.Fatalf("cannot create the test.bin file")
+	}
+	defer func() { _ = os.Remove("test.bin") }()
+
+	evaluated := testEval(input)
+	elf, ok := evaluated.(*object.BytesFile)
+	if !ok {
+		t.Fatalf("expected object of BytesFile type, got %T: %v", evaluated, evaluated)
+	}
+
+	if elf.Name() != "test.bin" {
+		t.Fatalf("expected file to have \"test.bin\" as its name, got %q", elf.Name())
+	}
+}
+
+func TestHexdumpBuiltin(t *testing.T) {
+	bytesFile := []byte("0123456789abcdefghij")
+
+	if err := os.WriteFile("test.bin", bytesFile, 0666); err != nil {
+		t.Fatalf("cannot create the test.bin file")
+	}
+	defer func() { _ = os.Remove("test.bin") }()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`hexdump(open("test.bin", "bytes"))`,
+			"00000000  30 31 32 33 34 35 36 37 38 39 61 62 63 64 65 66  |0123456789abcdef|",
+		},
+		{
+			`hexdump(as_bytes(open("test.bin", "bytes")), 8)`,
+			"00000000  30 31 32 33 34 35 36 37  |01234567|",
+		},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		dump, isString := evaluated.(*object.String)
+		if !isString {
+			t.Fatalf("%s: expected string, got %T", testCase.input, evaluated)
+		}
+
+		firstRow := strings.Split(dump.Value, "\n")[0]
+		if firstRow != testCase.expected {
+			t.Errorf("%s: expected first row %q, got %q", testCase.input, testCase.expected, firstRow)
+		}
+	}
+}
+
+func TestMapLiterals(t *testing.T) {
+	input := `var test = 22
+{
+	"test1": 20 * 2,
+	"test2": 2 & 3,
+	"tes"+"t3": 4,
+	test: 22,	
+	true: 1,
 	false: 0,
 }`
 	expected := map[object.HashKey]int64{
@@ -715,6 +1719,202 @@ func TestMapLiterals(t *testing.T) {
 	}
 }
 
+func TestPretty(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"b": 2, "a": [1, "x"]}`, `{"a": [1, "x"], "b": 2}`},
+		{`[3, 2, 1]`, `[3, 2, 1]`},
+		{`set(2, 1)`, `set(1, 2)`},
+		{`"text"`, `text`},
+		{`12`, `12`},
+		{
+			`{10: "j", 9: "i", 8: "h", 7: "g", 6: "f", 5: "e", 4: "d", 3: "c", 2: "b", 1: "a"}`,
+			"{\n\t1: \"a\",\n\t2: \"b\",\n\t3: \"c\",\n\t4: \"d\",\n\t5: \"e\",\n" +
+				"\t6: \"f\",\n\t7: \"g\",\n\t8: \"h\",\n\t9: \"i\",\n\t10: \"j\",\n}",
+		},
+		{
+			`[9, 8, 7, 6, 5, 4, 3, 2, [1]]`,
+			"[\n\t9,\n\t8,\n\t7,\n\t6,\n\t5,\n\t4,\n\t3,\n\t2,\n\t[1],\n]",
+		},
+		{
+			`set("i", "h", "g", "f", "e", "d", "c", "b", "a")`,
+			"set(\n\t\"a\",\n\t\"b\",\n\t\"c\",\n\t\"d\",\n\t\"e\",\n\t\"f\",\n" +
+				"\t\"g\",\n\t\"h\",\n\t\"i\",\n)",
+		},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		if rendered := pretty(evaluated); rendered != testCase.expected {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expected, rendered)
+		}
+	}
+}
+
+func TestArrayOf(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`array_of(16, 0)`, make([]int64, 16)},
+		{`array_of(3, 0xff)`, []int64{0xff, 0xff, 0xff}},
+		{`array_of(0, 1)`, []int64{}},
+		{`array_of(3, "pad")`, []string{"pad", "pad", "pad"}},
+		{"var a = array_of(2, {})\na[0].set(1, 2)\n[len(a[0]), len(a[1])]", []int64{1, 0}},
+		{"var a = array_of(2, set())\na[1].add(1)\n[len(a[0]), len(a[1])]", []int64{0, 1}},
+		{`array_of(2, null)[1] == null`, true},
+		{`array_of(-1, 0)`, object.RuntimeErrorObj},
+		{`array_of(0x7fffffffffffffff, 0)`, object.RuntimeErrorObj},
+		{`array_of(16777217, 0)`, object.RuntimeErrorObj},
+		{`array_of(1)`, object.ErrorObj},
+		{`array_of("1", 0)`, object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case []int64:
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case []string:
+			testStringArrayObject(t, evaluated, expected)
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestMapConstructors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected map[object.HashKey]int64
+	}{
+		{
+			"map([[1, 2], [3, 4]])",
+			map[object.HashKey]int64{
+				(&object.Integer{Value: 1}).HashKey(): 2,
+				(&object.Integer{Value: 3}).HashKey(): 4,
+			},
+		},
+		{
+			"map([[1, 2], [1, 4]])",
+			map[object.HashKey]int64{
+				(&object.Integer{Value: 1}).HashKey(): 4,
+			},
+		},
+		{"map([])", map[object.HashKey]int64{}},
+		{
+			`dict("a", 1, true, 2, 3, 4)`,
+			map[object.HashKey]int64{
+				(&object.String{Value: "a"}).HashKey(): 1,
+				TRUE.HashKey():                         2,
+				(&object.Integer{Value: 3}).HashKey():  4,
+			},
+		},
+		{"dict()", map[object.HashKey]int64{}},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		mapObj, ok := evaluated.(*object.Map)
+		if !ok {
+			t.Fatalf("%s: expected object of Map type, got %T", testCase.input, evaluated)
+		}
+
+		if len(mapObj.Mappings) != len(testCase.expected) {
+			t.Fatalf("%s: expected %d elements, got %d", testCase.input,
+				len(testCase.expected), len(mapObj.Mappings))
+		}
+
+		for expKey, expVal := range testCase.expected {
+			mapping, ok := mapObj.Mappings[expKey]
+			if !ok {
+				t.Errorf("%s: expected key %+v to be present in the map", testCase.input, expKey)
+				continue
+			}
+			testIntegerObject(t, testCase.input, mapping.Value, expVal)
+		}
+	}
+
+	failures := []struct {
+		input    string
+		expected object.ObjectType
+	}{
+		{"map()", object.ErrorObj},
+		{"map(1)", object.ErrorObj},
+		{"map([[1, 2], [3]])", object.RuntimeErrorObj},
+		{"map([[1, 2, 3]])", object.RuntimeErrorObj},
+		{"map([1, 2])", object.RuntimeErrorObj},
+		{"map([[[1], 2]])", object.RuntimeErrorObj},
+		{"dict(1, 2, 3)", object.RuntimeErrorObj},
+		{"dict([1], 2)", object.RuntimeErrorObj},
+	}
+
+	for _, testCase := range failures {
+		evaluated := testEval(testCase.input)
+		if evaluated.Type() != testCase.expected {
+			t.Errorf("%s: expected %s, got %s", testCase.input, testCase.expected, evaluated.Type())
+		}
+	}
+}
+
+func TestFrozenCollections(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"[1, 2].push(3)", []int64{1, 2, 3}},
+		{"freeze([1, 2])", []int64{1, 2}},
+		{"freeze([1, 2])[1]", int64(2)},
+		{"var a = [1, 2]\nvar f = freeze(a)\na.push(3)", []int64{1, 2, 3}},
+		{"var m = {1: 2}\nvar f = freeze(m)\nm.set(3, 4)\nlen(m) + len(f)", int64(3)},
+		{"freeze(set(1, 2)).to_array()", []int64{1, 2}},
+		{"is_frozen(freeze([1]))", true},
+		{"is_frozen(freeze({1: 2}))", true},
+		{"is_frozen(freeze(set(1)))", true},
+		{"is_frozen([1])", false},
+		{"var a = [1]\nfreeze(a)\nis_frozen(a)", false},
+		{"is_frozen(1)", false},
+		{"freeze([1]).push(2)", object.TypeError},
+		{"freeze([1]).pop()", object.TypeError},
+		{"freeze({1: 2}).set(3, 4)", object.TypeError},
+		{"freeze({1: 2}).pop(1)", object.TypeError},
+		{"freeze(set(1)).add(2)", object.TypeError},
+		{"freeze(set(1)).remove(1)", object.TypeError},
+		{"freeze(1)", object.ErrorObj},
+		{"freeze()", object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case []int64:
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case int64:
+			testIntegerObject(t, testCase.input, evaluated, expected)
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr {
+				t.Errorf("%s: expected a runtime error, got %v", testCase.input, evaluated)
+				continue
+			}
+
+			if runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %s", testCase.input, expected, runtimeErr.Kind)
+			}
+		case object.ObjectType:
+			if evaluated.Type() != expected {
+				t.Errorf("%s: expected %s, got %s", testCase.input, expected, evaluated.Type())
+			}
+		}
+	}
+}
+
 func TestMapIndexExpressions(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -764,9 +1964,44 @@ func TestArrayBuiltinMethods(t *testing.T) {
 		{`[1, 2, 3, 255, 254].map()`, object.ErrorObj},
 		{`[1, 2, 3, 255, 254].map(12)`, object.ErrorObj},
 		{`[1, 2, 3, 255, 254].map(hex, 12)`, object.ErrorObj},
+		{`[0xde, 0xad, 0xbe, 0xef].zip_with([0xff, 0x00, 0x0f, 0xf0], fun(a, b) { ret a ^ b })`, []int64{0x21, 0xad, 0xb1, 0x1f}},
+		{`[1, 2, 3].zip_with([10, 20, 30, 40], fun(a, b) { ret a + b })`, []int64{11, 22, 33}},
+		{`[1, 2, 3, 4].zip_with([10, 20], fun(a, b) { ret a + b })`, []int64{11, 22}},
+		{`[].zip_with([1, 2], fun(a, b) { ret a + b })`, []int64{}},
+		{`[1, 2].zip_with([3, 4], gcd)`, []int64{1, 2}},
+		{`[1, 2].zip_with([3, 4], fun(a) { ret a })`, object.RuntimeErrorObj},
+		{`[1, 2].zip_with([3, 4], hex)`, object.RuntimeErrorObj},
+		{`[1, 2].zip_with([3, 4], fun(a, b) { })`, object.RuntimeErrorObj},
+		{`[1, 2].zip_with(["3", 4], fun(a, b) { ret a.pop() })`, object.ErrorObj},
+		{`[1, 2].zip_with([3, 4], fun(a, b) { ret a / 0 })`, object.ErrorObj},
+		{`[1, 2].zip_with([3, 4], fun(a, b) { ret error("bad pair") })`, object.RuntimeErrorObj},
+		{`[1, 2].zip_with(fun(a, b) { ret a + b })`, object.ErrorObj},
+		{`[1, 2].zip_with(3, fun(a, b) { ret a + b })`, object.ErrorObj},
+		{`[1, 2, 3].tap(fun(a) { ret 12 })`, []int64{1, 2, 3}},
+		{`[1, 2, 3].tap(len).map(fun(e) { ret e + 1 })`, []int64{2, 3, 4}},
+		{"var seen = {}\nvar r = [1, 2].map(fun(e) { ret e * 2 }).tap(fun(a) { seen.set(\"a\", a) })\nr + seen[\"a\"]", []int64{2, 4, 2, 4}},
+		{`[1, 2, 3].tap(fun(a, b) { ret a })`, object.RuntimeErrorObj},
+		{`[].tap(fun(a) { ret a.pop() })`, object.RuntimeErrorObj},
+		{`[1, 2, 3].tap()`, object.ErrorObj},
+		{`[1, 2, 3].tap(1)`, object.ErrorObj},
 		{`[[10, 5, 7].reduce(fun(x, y) { ret x+y })]`, []int64{22}},
 		{"var x = 2\n[[10, 5, 7].reduce(fun(x, y) { ret x+y }, x)]", []int64{24}},
 		{"var x = 2\n[[10, 5, 7].reduce()]", object.ErrorObj},
+		{`repr([1, 2, 3, 4, 5, 6, 7, 8, 9, 10].chunk(3))`, "[[1, 2, 3], [4, 5, 6], [7, 8, 9], [10]]"},
+		{`repr([1, 2, 3, 4, 5, 6].chunk(3))`, "[[1, 2, 3], [4, 5, 6]]"},
+		{`repr([1, 2].chunk(5))`, "[[1, 2]]"},
+		{`repr([].chunk(2))`, "[]"},
+		{`[1, 2, 3].chunk(0)`, object.RuntimeErrorObj},
+		{`[1, 2, 3].chunk(-1)`, object.RuntimeErrorObj},
+		{`[1, 2, 3].chunk()`, object.ErrorObj},
+		{`repr([1, 2, 3, 4].window(2))`, "[[1, 2], [2, 3], [3, 4]]"},
+		{`repr([1, 2, 3, 4].window(4))`, "[[1, 2, 3, 4]]"},
+		{`repr([1, 2, 3, 4].window(1))`, "[[1], [2], [3], [4]]"},
+		{`repr([1, 2].window(3))`, "[]"},
+		{`repr([].window(1))`, "[]"},
+		{`[1, 2, 3, 4].window(2).map(fun(w) { ret w[0] + w[1] })`, []int64{3, 5, 7}},
+		{`[1, 2, 3].window(0)`, object.RuntimeErrorObj},
+		{`[1, 2, 3].window("2")`, object.ErrorObj},
 	}
 
 	for _, testCase := range tests {
@@ -778,6 +2013,8 @@ func TestArrayBuiltinMethods(t *testing.T) {
 			}
 		case []string:
 			testStringArrayObject(t, evalArrayBuiltin, expected)
+		case string:
+			testStringObject(t, evalArrayBuiltin, expected)
 		case object.ObjectType:
 			testError(t, testCase.input, expected, evalArrayBuiltin)
 		}
@@ -800,23 +2037,79 @@ func TestMapBuiltinMethods(t *testing.T) {
 	}
 }
 
-func TestMapBuiltinMethodsFailure(t *testing.T) {
+func TestMapEntries(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected object.ObjectType
+		expected string
 	}{
-		{"var m = {1: 2}\nm.set()", object.ErrorObj},
-		{"var m = {1: 2}\nm.set(3)", object.ErrorObj},
-		{"var m = {1: 2}\nm.set(3, 4, 5)", object.ErrorObj},
-		{"var m = {1: 2}\nm.set([1, 2], 5)", object.RuntimeErrorObj},
-		{"var m  = {1: 2, 3: 4}\nm.pop()", object.ErrorObj},
-		{"var m  = {1: 2, 3: 4}\nm.pop(3, 2)", object.ErrorObj},
-		{"var m  = {1: 2, 3: 4}\nm.pop([1,2])", object.RuntimeErrorObj},
+		{`repr({}.entries())`, `[]`},
+		{`repr({3: "c", 1: "a", 2: "b"}.entries())`, `[[1, "a"], [2, "b"], [3, "c"]]`},
+		{`repr({-1: 0, 10: 1, 0: 2}.entries())`, `[[-1, 0], [0, 2], [10, 1]]`},
+		{
+			`repr({"b": 1, 2: 2, true: 3, 1: 4, "a": 5, false: 6}.entries())`,
+			`[[false, 6], [true, 3], [1, 4], [2, 2], ["a", 5], ["b", 1]]`,
+		},
+		{"var m = {2: 4, 1: 2}\nm.set(0, 0)\nrepr(m.entries())", `[[0, 0], [1, 2], [2, 4]]`},
 	}
 
 	for _, testCase := range tests {
-		evalMapBuiltin := testEval(testCase.input)
-		testError(t, testCase.input, testCase.expected, evalMapBuiltin)
+		evalEntries := testEval(testCase.input)
+		testStringObject(t, evalEntries, testCase.expected)
+	}
+
+	if evalEntries := testEval(`{1: 2}.entries(1)`); evalEntries.Type() != object.ErrorObj {
+		t.Errorf("expected an error, got %s", evalEntries.Type())
+	}
+}
+
+func TestCollectionToArray(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`repr(set().to_array())`, `[]`},
+		{`repr(set(3, 1, 2).to_array())`, `[1, 2, 3]`},
+		{`repr(set("b", 2, "a", 1).to_array())`, `[1, 2, "a", "b"]`},
+		{`repr(set(1, 2, 3).to_array().map(fun(x) { ret x * 2 }))`, `[2, 4, 6]`},
+		{`repr({}.to_array())`, `[]`},
+		{`repr({3: "c", 1: "a", 2: "b"}.to_array())`, `["a", "b", "c"]`},
+		{`{1: 10, 2: 20}.to_array().reduce(fun(a, b) { ret a + b })`, 30},
+		{`set(1).to_array(1)`, object.ErrorObj},
+		{`{1: 2}.to_array(1)`, object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evalToArray := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case string:
+			testStringObject(t, evalToArray, expected)
+		case int:
+			testIntegerObject(t, testCase.input, evalToArray, int64(expected))
+		case object.ObjectType:
+			if evalToArray.Type() != expected {
+				t.Errorf("%s: expected a %s object, got %s", testCase.input, expected, evalToArray.Type())
+			}
+		}
+	}
+}
+
+func TestMapBuiltinMethodsFailure(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.ObjectType
+	}{
+		{"var m = {1: 2}\nm.set()", object.ErrorObj},
+		{"var m = {1: 2}\nm.set(3)", object.ErrorObj},
+		{"var m = {1: 2}\nm.set(3, 4, 5)", object.ErrorObj},
+		{"var m = {1: 2}\nm.set([1, 2], 5)", object.RuntimeErrorObj},
+		{"var m  = {1: 2, 3: 4}\nm.pop()", object.ErrorObj},
+		{"var m  = {1: 2, 3: 4}\nm.pop(3, 2)", object.ErrorObj},
+		{"var m  = {1: 2, 3: 4}\nm.pop([1,2])", object.RuntimeErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evalMapBuiltin := testEval(testCase.input)
+		testError(t, testCase.input, testCase.expected, evalMapBuiltin)
 	}
 }
 
@@ -835,6 +2128,10 @@ func TestHexFileBuiltinMethods(t *testing.T) {
 		expected any
 	}{
 		{"open(\"test.hex\", \"hex\").record(2)", ":10C21000FFFFF6F50EFE4B66F2FA0CFEF2F40EFE90"},
+		{"open(\"test.hex\", \"hex\").records(2, 4)[1]", ":10C22000F04EF05FF06CF07DCA0050C2F086F097DF"},
+		{"len(open(\"test.hex\", \"hex\").records(2, 6))", int64(4)},
+		{"len(open(\"test.hex\", \"hex\").records(0, 8))", int64(8)},
+		{"len(open(\"test.hex\", \"hex\").records(3, 3))", int64(0)},
 		{"open(\"test.hex\", \"hex\").size()", int64(8)},
 		{"open(\"test.hex\", \"hex\").binary_size()", int64(68)},
 		{"open(\"test.hex\", \"hex\").read_at(0x1000*16 + 0xC200, 2)", []int64{0xE0, 0xA5}},
@@ -843,6 +2140,78 @@ func TestHexFileBuiltinMethods(t *testing.T) {
 h.write_at(0x2000*16, from_hex("DEADBEEF"))
 h.read_at(0x2000*16, 4)`, []int64{0xDE, 0xAD, 0xBE, 0xEF},
 		},
+		{
+			`var h = open("test.hex", "hex")
+h.write_at(0x2000*16, "DEADBEEF")
+h.read_at(0x2000*16, 4)`, []int64{0xDE, 0xAD, 0xBE, 0xEF},
+		},
+		{
+			`var h = open("test.hex", "hex")
+var g = open("test.hex", "hex")
+h.write_at(0x1000*16 + 0xC204, "de ad be ef")
+g.write_at(0x1000*16 + 0xC204, [0xde, 0xad, 0xbe, 0xef])
+h.equals(g)`, true,
+		},
+		{
+			`var h = open("test.hex", "hex")
+h.fill_pattern(0x1000*16 + 0xC200, 10, [0xDE, 0xAD, 0xBE, 0xEF])
+h.read_at(0x1000*16 + 0xC200, 12)`,
+			[]int64{0xDE, 0xAD, 0xBE, 0xEF, 0xDE, 0xAD, 0xBE, 0xEF, 0xDE, 0xAD, 0xE6, 0xFC},
+		},
+		{
+			`var h = open("test.hex", "hex")
+h.apply_patch([[0x1C200, [0xDE, 0xAD]], [0x1C204, "BEEF"]])
+h.read_at(0x1C200, 6)`,
+			[]int64{0xDE, 0xAD, 0xE6, 0xF6, 0xBE, 0xEF},
+		},
+		{
+			`var h = open("test.hex", "hex")
+h.fill_unused(0xFF)
+h.read_at(0x1C23E, 4) + h.read_at(0x1FFFE, 2)`,
+			[]int64{0xBB, 0x03, 0xFF, 0xFF, 0xFF, 0xFF},
+		},
+		{
+			`var h = open("test.hex", "hex")
+h.copy_region(0x1C200, 0x1C210, 4)
+h.read_at(0x1C20E, 8)`,
+			[]int64{0xE6, 0xFD, 0xE0, 0xA5, 0xE6, 0xF6, 0x0E, 0xFE},
+		},
+		{
+			`var h = open("test.hex", "hex")
+h.copy_region(0x1C200, 0x1C202, 20)
+h.read_at(0x1C200, 6) + h.read_at(0x1C214, 2)`,
+			[]int64{0xE0, 0xA5, 0xE0, 0xA5, 0xE6, 0xF6, 0xF6, 0xF5},
+		},
+		{"open(\"test.hex\", \"hex\").abs_address(0x1000, 0xC200)", int64(0x1C200)},
+		{"open(\"test.hex\", \"hex\").extended_linear_base()[0]", int64(0x10000)},
+		{"open(\"test.hex\", \"hex\").extended_linear_base()[5]", int64(0x20000)},
+		{"len(open(\"test.hex\", \"hex\").extended_linear_base())", int64(2)},
+		{"open(\"test.hex\", \"hex\").min_address()", int64(0x1C200)},
+		{"open(\"test.hex\", \"hex\").max_address()", int64(0x20003)},
+		{"open(\"test.hex\", \"hex\").record_address(0)", int64(0)},
+		{"open(\"test.hex\", \"hex\").record_address(1)", int64(0x1C200)},
+		{"open(\"test.hex\", \"hex\").record_address(2)", int64(0x1C210)},
+		{"open(\"test.hex\", \"hex\").record_address(6)", int64(0x20000)},
+		{
+			`var h = open("test.hex", "hex")
+h.read_at(h.record_address(1), 2)`, []int64{0xE0, 0xA5},
+		},
+		{
+			`var h = open("test.hex", "hex")
+h.write_record(":020000040800F2")
+h.write_record(":04000000DEADBEEFC4")
+h.read_at(0x08000000, 4)`, []int64{0xDE, 0xAD, 0xBE, 0xEF},
+		},
+		{
+			`var h = open("test.hex", "hex")
+h.write_record(":04000000DEADBEEFC4")
+h.record(h.size() - 2)`, ":04000000DEADBEEFC4",
+		},
+		{
+			`var h = open("test.hex", "hex")
+h.write_record(":04000000DEADBEEFC4")
+h.binary_size()`, int64(72),
+		},
 	}
 
 	err := os.WriteFile("test.hex", []byte(hexFile), 0666)
@@ -854,6 +2223,8 @@ h.read_at(0x2000*16, 4)`, []int64{0xDE, 0xAD, 0xBE, 0xEF},
 	for _, testCase := range tests {
 		evalHexBuiltin := testEval(testCase.input)
 		switch expected := testCase.expected.(type) {
+		case bool:
+			testBooleanObject(t, evalHexBuiltin, expected)
 		case string:
 			evalString, isString := evalHexBuiltin.(*object.String)
 			if !isString {
@@ -912,10 +2283,31 @@ func TestHexFileBuiltinMethodsFailure(t *testing.T) {
 		{"open(\"test.hex\", \"hex\").record(1, 2)", object.ErrorObj},
 		{"open(\"test.hex\", \"hex\").record(-1)", object.RuntimeErrorObj},
 		{"open(\"test.hex\", \"hex\").record(100000)", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").records(1)", object.ErrorObj},
+		{"open(\"test.hex\", \"hex\").records(\"1\", 2)", object.ErrorObj},
+		{"open(\"test.hex\", \"hex\").records(-1, 2)", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").records(2, 9)", object.RuntimeErrorObj},
 
 		{"open(\"test.hex\", \"hex\").size(1)", object.ErrorObj},
 		{"open(\"test.hex\", \"hex\").binary_size(1)", object.ErrorObj},
 
+		{"open(\"test.hex\", \"hex\").abs_address(1)", object.ErrorObj},
+		{"open(\"test.hex\", \"hex\").abs_address(-1, 0)", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").abs_address(0, 0x10000)", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").record_address()", object.ErrorObj},
+		{"open(\"test.hex\", \"hex\").record_address(-1)", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").record_address(100)", object.RuntimeErrorObj},
+
+		{"open(\"test.hex\", \"hex\").write_record()", object.ErrorObj},
+		{"open(\"test.hex\", \"hex\").write_record(1)", object.ErrorObj},
+		{"open(\"test.hex\", \"hex\").write_record(\":00000001FF\")", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").write_record(\":04000000DEADBEEFC5\")", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").write_record(\"04000000DEADBEEFC4\")", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").write_record(\":04000000DEADBEEFC4 :00000001FF\")", object.RuntimeErrorObj},
+
+		{"open(\"test.hex\", \"hex\").insert_at(0x1C200, [1, 2])", object.RuntimeErrorObj},
+		{"open(\"test.hex\", \"hex\").insert_at(0x1C200)", object.ErrorObj},
+
 		{"open(\"test.hex\", \"hex\").read_at()", object.ErrorObj},
 		{"open(\"test.hex\", \"hex\").read_at(1, 2, 3)", object.ErrorObj},
 		{"open(\"test.hex\", \"hex\").read_at(\"test\", 1)", object.ErrorObj},
@@ -929,8 +2321,9 @@ func TestHexFileBuiltinMethodsFailure(t *testing.T) {
 
 		{"open(\"test.hex\", \"hex\").write_at()", object.ErrorObj},
 		{"open(\"test.hex\", \"hex\").write_at(1, 2, 3)", object.ErrorObj},
+		{"open(\"test.hex\", \"hex\").extended_linear_base(1)", object.ErrorObj},
 		{"open(\"test.hex\", \"hex\").write_at(\"test\", 1)", object.ErrorObj},
-		{"open(\"test.hex\", \"hex\").write_at(2, \"test\")", object.ErrorObj},
+		{"open(\"test.hex\", \"hex\").write_at(2, \"test\")", object.RuntimeErrorObj},
 		{"open(\"test.hex\", \"hex\").write_at(\"test\", \"test\")", object.ErrorObj},
 		{"open(\"test.hex\", \"hex\").write_at(-1, [1, 2])", object.RuntimeErrorObj},
 		{"open(\"test.hex\", \"hex\").write_at(0, [-1, 2])", object.RuntimeErrorObj},
@@ -952,6 +2345,90 @@ func TestHexFileBuiltinMethodsFailure(t *testing.T) {
 	}
 }
 
+func TestHexFileAddressRangeEmpty(t *testing.T) {
+	tests := []string{
+		"open(\"empty.hex\", \"hex\").min_address()",
+		"open(\"empty.hex\", \"hex\").max_address()",
+		"open(\"empty.hex\", \"hex\").fill_unused(0xFF)",
+	}
+
+	if err := os.WriteFile("empty.hex", []byte(":00000001FF\n"), 0666); err != nil {
+		t.Fatalf("cannot create the empty.hex file")
+	}
+	defer func() { _ = os.Remove("empty.hex") }()
+
+	for _, input := range tests {
+		evaluated := testEval(input)
+		runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+		if !isRuntimeErr {
+			t.Errorf("%s: expected a runtime error, got %s", input, evaluated.Type())
+			continue
+		}
+
+		if runtimeErr.Kind != object.HexError {
+			t.Errorf("%s: expected a %s, got %s", input, object.HexError, runtimeErr.Kind)
+		}
+	}
+}
+
+func TestHexFileBuiltinMethodsErrorKind(t *testing.T) {
+	hexFile := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+
+	testCases := []struct {
+		input    string
+		expected object.RuntimeErrorType
+	}{
+		{"open(\"test.hex\", \"hex\").record(100000)", object.HexError},
+		{"open(\"test.hex\", \"hex\").records(2, 1)", object.HexError},
+		{"open(\"test.hex\", \"hex\").read_at(0, 4)", object.HexError},
+		{"open(\"test.hex\", \"hex\").write_at(0, [1])", object.HexError},
+		{"open(\"test.hex\", \"hex\").record_address(100)", object.HexError},
+		{"open(\"test.hex\", \"hex\").copy_region(0x1C200, 0, 4)", object.HexError},
+		{"open(\"test.hex\", \"hex\").copy_region(0, 0x1C200, 4)", object.HexError},
+		{"open(\"test.hex\", \"hex\").copy_region(0x1C200, 0x1C20E, 4)", object.HexError},
+		{"open(\"test.hex\", \"hex\").copy_region(-1, 0x1C200, 4)", object.TypeError},
+		{"open(\"test.hex\", \"hex\").fill_pattern(0x1C200, 4, [])", object.HexError},
+		{"open(\"test.hex\", \"hex\").fill_pattern(0, 4, [1])", object.HexError},
+		{"open(\"test.hex\", \"hex\").fill_pattern(0x1C200, 4, [-1])", object.TypeError},
+		{"open(\"test.hex\", \"hex\").fill_pattern(0x1C200, 0x7fffffffffffffff, [1])", object.HexError},
+		{"open(\"test.hex\", \"hex\").fill_pattern(0x1C200, 0xffffffffff, [1])", object.HexError},
+		{"open(\"test.hex\", \"hex\").fill_pattern(0x7fffffffffffffff, 1, [1])", object.HexError},
+		{"open(\"test.hex\", \"hex\").apply_patch([[0, [1]]])", object.HexError},
+		{"open(\"test.hex\", \"hex\").apply_patch([0x1C200])", object.TypeError},
+		{"open(\"test.hex\", \"hex\").fill_unused(256)", object.TypeError},
+		{"open(\"test.hex\", \"hex\").read_at(-1, 1)", object.TypeError},
+		{"open(\"test.hex\", \"hex\").write_at(0, [-1])", object.TypeError},
+		{"try open(\"test.hex\", \"hex\").record(100000)", object.HexError},
+		{"try open(\"test.hex\", \"hex\").read_at(0, 4)", object.HexError},
+		{"try open(\"test.hex\", \"hex\").write_at(0, [1])", object.HexError},
+	}
+
+	if err := os.WriteFile("test.hex", []byte(hexFile), 0666); err != nil {
+		t.Fatalf("cannot create the test.hex file")
+	}
+	defer func() { _ = os.Remove("test.hex") }()
+
+	for _, testCase := range testCases {
+		fileExpr := testEval(testCase.input)
+		runtimeErr, isRuntimeErr := fileExpr.(*object.RuntimeError)
+		if !isRuntimeErr {
+			t.Errorf("%s: expected a runtime error, got %s", testCase.input, fileExpr.Type())
+			continue
+		}
+
+		if runtimeErr.Kind != testCase.expected {
+			t.Errorf("%s: expected a %s, got %s", testCase.input, testCase.expected, runtimeErr.Kind)
+		}
+
+		if !strings.HasPrefix(runtimeErr.Inspect(), string(testCase.expected)+": ") {
+			t.Errorf("%s: unexpected error message %q", testCase.input, runtimeErr.Inspect())
+		}
+	}
+}
+
 func TestElfFileBuiltinMethods(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -969,6 +2446,14 @@ func TestElfFileBuiltinMethods(t *testing.T) {
 				".debug_line_str", ".symtab", ".strtab", ".shstrtab",
 			},
 		},
+		{
+			"var e = open(\"test.elf\", \"elf\")\nlen(e.relocations(\".metadata\")) == 0",
+			true,
+		},
+		{
+			"var e = open(\"test.elf\", \"elf\")\nlen(e.dynamic()) == 0",
+			true,
+		},
 		{
 			"var e = open(\"test.elf\", \"elf\")\ne.section_address(\".metadata\")",
 			int64(0x800100),
@@ -1000,154 +2485,1063 @@ func TestElfFileBuiltinMethods(t *testing.T) {
 		},
 	}
 
-	err := os.WriteFile("test.elf", elfFile, 0666)
-	if err != nil {
-		t.Fatalf("cannot create the test.elf file")
+	err := os.WriteFile("test.elf", elfFile, 0666)
+	if err != nil {
+		t.Fatalf("cannot create the test.elf file")
+	}
+	defer func() { _ = os.Remove("test.elf") }()
+
+	for _, testCase := range tests {
+		evalElfBuiltin := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case bool:
+			evalBool, isBool := evalElfBuiltin.(*object.Boolean)
+			if !isBool {
+				t.Fatalf("expected bool, got %T", evalElfBuiltin)
+			}
+
+			if expected != evalBool.Value {
+				t.Fatalf("expected bool = %t, got %t", expected, evalBool.Value)
+			}
+		case []int64:
+			evalArr, isArr := evalElfBuiltin.(*object.Array)
+			if !isArr {
+				t.Fatalf("expected array, got %T: %v", evalElfBuiltin, evalElfBuiltin)
+			}
+
+			for idx, elem := range evalArr.Elements {
+				intElem, isInt := elem.(*object.Integer)
+				if !isInt {
+					t.Fatalf("expected int, got %T", elem)
+				}
+
+				if idx > len(expected) || intElem.Value != expected[idx] {
+					t.Fatalf("expected %v, got %d", expected, intElem.Value)
+				}
+			}
+		case int64:
+			evalInt, isInt := evalElfBuiltin.(*object.Integer)
+			if !isInt {
+				t.Fatalf("expected int, got %T", evalElfBuiltin)
+			}
+
+			if expected != evalInt.Value {
+				t.Fatalf("expected value = %d, got %d", expected, evalInt.Value)
+			}
+		case []string:
+			evalArr, isArr := evalElfBuiltin.(*object.Array)
+			if !isArr {
+				t.Fatalf("expected array, got %T: %v", evalElfBuiltin, evalElfBuiltin)
+			}
+
+			for idx, elem := range evalArr.Elements {
+				strElem, isString := elem.(*object.String)
+				if !isString {
+					t.Fatalf("expected String, got %T", elem)
+				}
+
+				if idx > len(expected) || strElem.Value != expected[idx] {
+					t.Fatalf("expected %v, got %s", expected, strElem.Value)
+				}
+			}
+		}
+	}
+}
+
+func TestElfFileAddSection(t *testing.T) {
+	input := "var e = open(\"test.elf\", \"elf\")\n" +
+		"e.add_section(\".added\", [1, 2, 3, 4], 2)\n" +
+		"save(e)\n" +
+		"var r = open(\"test.elf\", \"elf\")\n" +
+		"r.read_section(\".added\")"
+
+	if err := os.WriteFile("test.elf", elfFile, 0666); err != nil {
+		t.Fatalf("cannot create the test.elf file")
+	}
+	defer func() { _ = os.Remove("test.elf") }()
+
+	evaluated := testEval(input)
+	testArrayObject(t, input, evaluated, []int64{1, 2, 3, 4})
+}
+
+func TestElfFileBuiltinMethodsFailure(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected object.ObjectType
+	}{
+		{"open(\"test.elf\", \"elf\").has_section()", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").has_section(1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").has_section(1, 2)", object.ErrorObj},
+
+		{"open(\"test.elf\", \"elf\").sections(1)", object.ErrorObj},
+
+		{"open(\"test.elf\", \"elf\").section_address()", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").section_address(1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").section_address(\"test\", 1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").section_address(\"test-not-exist\")", object.RuntimeErrorObj},
+
+		{"open(\"test.elf\", \"elf\").section_size()", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").section_size(1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").section_size(\"test\", 1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").section_size(\"test-not-exist\")", object.RuntimeErrorObj},
+
+		{"open(\"test.elf\", \"elf\").read_section()", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").read_section(1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").read_section(\"test-not-exist\", 1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").read_section(1, 2, 3)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").read_section(1, 2, 3)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").read_section(\"test-not-exist\")", object.RuntimeErrorObj},
+
+		{"open(\"test.elf\", \"elf\").write_section()", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").write_section(1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").write_section(\"test-not-exist\", 1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").write_section(1, 2, 3)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").write_section(1, 2, 3, 4)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").write_section(\"test-not-exist\", [1, 2], -1)", object.RuntimeErrorObj},
+		{"open(\"test.elf\", \"elf\").write_section(\"test-not-exist\", [1000, 2], 0)", object.RuntimeErrorObj},
+		{"open(\"test.elf\", \"elf\").write_section(\"test-not-exist\", [1, 2, 3], 0)", object.RuntimeErrorObj},
+		{"open(\"test.elf\", \"elf\").write_section(\".metadata\", [1, 2, 3], 100000000000)", object.RuntimeErrorObj},
+
+		{"open(\"test.elf\", \"elf\").relocations()", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").relocations(1)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").relocations(\"test-not-exist\")", object.RuntimeErrorObj},
+
+		{"open(\"test.elf\", \"elf\").dynamic(1)", object.ErrorObj},
+
+		{"open(\"test.elf\", \"elf\").add_section()", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").add_section(\".new\", [1, 2])", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").add_section(1, [1, 2], 0)", object.ErrorObj},
+		{"open(\"test.elf\", \"elf\").add_section(\".new\", [1, 2], -1)", object.RuntimeErrorObj},
+		{"open(\"test.elf\", \"elf\").add_section(\".new\", [1000, 2], 0)", object.RuntimeErrorObj},
+		{"open(\"test.elf\", \"elf\").add_section(\".metadata\", [1, 2], 0)", object.RuntimeErrorObj},
+	}
+
+	if err := os.WriteFile("test.elf", elfFile, 0666); err != nil {
+		t.Fatalf("cannot create the test.elf file")
+	}
+	defer func() { _ = os.Remove("test.elf") }()
+
+	for _, testCase := range testCases {
+		fileExpr := testEval(testCase.input)
+		if fileExpr.Type() != testCase.expected {
+			t.Errorf("%s: expected error of type %s, got %s", testCase.input, testCase.expected, fileExpr.Type())
+		}
+	}
+}
+
+func TestPeFileBuiltinMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var p = open(\"test.exe\", \"pe\")\np.has_section(\".data\")", true},
+		{"var p = open(\"test.exe\", \"pe\")\np.has_section(\".rdata\")", false},
+		{"var p = open(\"test.exe\", \"pe\")\np.sections()", []string{".text", ".data"}},
+		{"var p = open(\"test.exe\", \"pe\")\np.section_address(\".data\")", int64(0x2000)},
+		{"var p = open(\"test.exe\", \"pe\")\np.section_size(\".data\")", int64(16)},
+		{
+			"var p = open(\"test.exe\", \"pe\")\np.read_section(\".data\")",
+			[]int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		},
+		{
+			"var p = open(\"test.exe\", \"pe\")\np.write_section(\".data\", [0xff, 0xfe], 2)\np.read_section(\".data\")",
+			[]int64{0, 1, 0xff, 0xfe, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		},
+		{
+			"var p = open(\"test.exe\", \"pe\")\np.write_section(\".data\", [0xaa], 0)\nsave(p)\n" +
+				"open(\"test.exe\", \"pe\").read_section(\".data\")",
+			[]int64{0xaa, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		},
+		{"len(as_bytes(open(\"test.exe\", \"pe\")))", int64(len(peFile))},
+	}
+
+	err := os.WriteFile("test.exe", peFile, 0666)
+	if err != nil {
+		t.Fatalf("cannot create the test.exe file")
+	}
+	defer func() { _ = os.Remove("test.exe") }()
+
+	for _, testCase := range tests {
+		evalPeBuiltin := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case bool:
+			testBooleanObject(t, evalPeBuiltin, expected)
+		case int64:
+			testIntegerObject(t, testCase.input, evalPeBuiltin, expected)
+		case []int64:
+			testArrayObject(t, testCase.input, evalPeBuiltin, expected)
+		case []string:
+			testStringArrayObject(t, evalPeBuiltin, expected)
+		}
+	}
+}
+
+func TestPeFileBuiltinMethodsFailure(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected object.ObjectType
+	}{
+		{"open(\"test.exe\", \"pe\").has_section()", object.ErrorObj},
+		{"open(\"test.exe\", \"pe\").has_section(1)", object.ErrorObj},
+		{"open(\"test.exe\", \"pe\").sections(1)", object.ErrorObj},
+		{"open(\"test.exe\", \"pe\").section_address(\"test-not-exist\")", object.RuntimeErrorObj},
+		{"open(\"test.exe\", \"pe\").section_size(\"test-not-exist\")", object.RuntimeErrorObj},
+		{"open(\"test.exe\", \"pe\").read_section(1)", object.ErrorObj},
+		{"open(\"test.exe\", \"pe\").read_section(\"test-not-exist\")", object.RuntimeErrorObj},
+		{"open(\"test.exe\", \"pe\").write_section(\".data\", [1, 2])", object.ErrorObj},
+		{"open(\"test.exe\", \"pe\").write_section(\".data\", [1, 2], -1)", object.RuntimeErrorObj},
+		{"open(\"test.exe\", \"pe\").write_section(\".data\", [1000, 2], 0)", object.RuntimeErrorObj},
+		{"open(\"test.exe\", \"pe\").write_section(\".data\", [1, 2, 3], 14)", object.RuntimeErrorObj},
+		{"open(\"test.exe\", \"pe\").write_section(\"test-not-exist\", [1, 2], 0)", object.RuntimeErrorObj},
+		{"open(\"test.exe\", \"elf\")", object.RuntimeErrorObj},
+	}
+
+	if err := os.WriteFile("test.exe", peFile, 0666); err != nil {
+		t.Fatalf("cannot create the test.exe file")
+	}
+	defer func() { _ = os.Remove("test.exe") }()
+
+	for _, testCase := range testCases {
+		fileExpr := testEval(testCase.input)
+		if fileExpr.Type() != testCase.expected {
+			t.Errorf("%s: expected error of type %s, got %s", testCase.input, testCase.expected, fileExpr.Type())
+		}
+	}
+}
+
+func TestMachoFileBuiltinMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var m = open(\"test.macho\", \"macho\")\nm.has_section(\"__data\")", true},
+		{"var m = open(\"test.macho\", \"macho\")\nm.has_section(\"__bss\")", false},
+		{"var m = open(\"test.macho\", \"macho\")\nm.sections()", []string{"__text", "__data"}},
+		{"var m = open(\"test.macho\", \"macho\")\nm.segments()", []string{"__TEXT", "__DATA"}},
+		{"var m = open(\"test.macho\", \"macho\")\nm.section_address(\"__data\")", int64(0x100001000)},
+		{"var m = open(\"test.macho\", \"macho\")\nm.section_size(\"__text\")", int64(16)},
+		{"var m = open(\"test.macho\", \"macho\")\nm.segment_address(\"__TEXT\")", int64(0x100000000)},
+		{"var m = open(\"test.macho\", \"macho\")\nm.segment_size(\"__DATA\")", int64(0x1000)},
+		{"var m = open(\"test.macho\", \"macho\")\nm.entry()", int64(0x100000170)},
+		{
+			"var m = open(\"test.macho\", \"macho\")\nm.read_section(\"__data\")",
+			[]int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		},
+		{
+			"var m = open(\"test.macho\", \"macho\")\nm.write_section(\"__data\", [0xaa], 0)\nsave(m)\n" +
+				"open(\"test.macho\", \"macho\").read_section(\"__data\")",
+			[]int64{0xaa, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		},
+	}
+
+	err := os.WriteFile("test.macho", machoFile, 0666)
+	if err != nil {
+		t.Fatalf("cannot create the test.macho file")
+	}
+	defer func() { _ = os.Remove("test.macho") }()
+
+	for _, testCase := range tests {
+		evalMachoBuiltin := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case bool:
+			testBooleanObject(t, evalMachoBuiltin, expected)
+		case int64:
+			testIntegerObject(t, testCase.input, evalMachoBuiltin, expected)
+		case []int64:
+			testArrayObject(t, testCase.input, evalMachoBuiltin, expected)
+		case []string:
+			testStringArrayObject(t, evalMachoBuiltin, expected)
+		}
+	}
+}
+
+func TestMachoFileBuiltinMethodsFailure(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected object.ObjectType
+	}{
+		{"open(\"test.macho\", \"macho\").has_section()", object.ErrorObj},
+		{"open(\"test.macho\", \"macho\").segments(1)", object.ErrorObj},
+		{"open(\"test.macho\", \"macho\").entry(1)", object.ErrorObj},
+		{"open(\"test.macho\", \"macho\").section_address(\"__random\")", object.RuntimeErrorObj},
+		{"open(\"test.macho\", \"macho\").segment_address(\"__RANDOM\")", object.RuntimeErrorObj},
+		{"open(\"test.macho\", \"macho\").segment_size(1)", object.ErrorObj},
+		{"open(\"test.macho\", \"macho\").read_section(\"__random\")", object.RuntimeErrorObj},
+		{"open(\"test.macho\", \"macho\").write_section(\"__data\", [1, 2], -1)", object.RuntimeErrorObj},
+		{"open(\"test.macho\", \"macho\").write_section(\"__data\", [1000], 0)", object.RuntimeErrorObj},
+		{"open(\"test.macho\", \"macho\").write_section(\"__data\", [1, 2, 3], 14)", object.RuntimeErrorObj},
+		{"open(\"test.macho\", \"pe\")", object.RuntimeErrorObj},
+	}
+
+	if err := os.WriteFile("test.macho", machoFile, 0666); err != nil {
+		t.Fatalf("cannot create the test.macho file")
+	}
+	defer func() { _ = os.Remove("test.macho") }()
+
+	for _, testCase := range testCases {
+		fileExpr := testEval(testCase.input)
+		if fileExpr.Type() != testCase.expected {
+			t.Errorf("%s: expected error of type %s, got %s", testCase.input, testCase.expected, fileExpr.Type())
+		}
+	}
+}
+
+func TestZipFileBuiltinMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var z = open(\"test.zip\", \"zip\")\nz.entries()", []string{"hello.txt", "data.bin"}},
+		{"var z = open(\"test.zip\", \"zip\")\nz.has_entry(\"data.bin\")", true},
+		{"var z = open(\"test.zip\", \"zip\")\nz.has_entry(\"other.bin\")", false},
+		{"var z = open(\"test.zip\", \"zip\")\nz.read_entry(\"data.bin\")", []int64{0, 1, 2, 3, 4, 5, 6, 7}},
+		{"var z = open(\"test.zip\", \"zip\")\nto_string(z.read_entry(\"hello.txt\"))", "hello\n"},
+		{
+			"var z = open(\"test.zip\", \"zip\")\nz.add_entry(\"added.bin\", [1, 2, 3])\nz.read_entry(\"added.bin\")",
+			[]int64{1, 2, 3},
+		},
+		{
+			"var z = open(\"test.zip\", \"zip\")\nz.add_entry(\"saved.bin\", [0xca, 0xfe])\nsave(z)\n" +
+				"open(\"test.zip\", \"zip\").read_entry(\"saved.bin\")",
+			[]int64{0xca, 0xfe},
+		},
+		{
+			"open(\"test.zip\", \"zip\").entries()",
+			[]string{"hello.txt", "data.bin", "saved.bin"},
+		},
+	}
+
+	err := os.WriteFile("test.zip", zipFile, 0666)
+	if err != nil {
+		t.Fatalf("cannot create the test.zip file")
+	}
+	defer func() { _ = os.Remove("test.zip") }()
+
+	for _, testCase := range tests {
+		evalZipBuiltin := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case bool:
+			testBooleanObject(t, evalZipBuiltin, expected)
+		case string:
+			testStringObject(t, evalZipBuiltin, expected)
+		case []int64:
+			testArrayObject(t, testCase.input, evalZipBuiltin, expected)
+		case []string:
+			testStringArrayObject(t, evalZipBuiltin, expected)
+		}
+	}
+}
+
+func TestZipFileBuiltinMethodsFailure(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected object.ObjectType
+	}{
+		{"open(\"test.zip\", \"zip\").entries(1)", object.ErrorObj},
+		{"open(\"test.zip\", \"zip\").has_entry()", object.ErrorObj},
+		{"open(\"test.zip\", \"zip\").read_entry(1)", object.ErrorObj},
+		{"open(\"test.zip\", \"zip\").read_entry(\"other.bin\")", object.RuntimeErrorObj},
+		{"open(\"test.zip\", \"zip\").add_entry(\"other.bin\")", object.ErrorObj},
+		{"open(\"test.zip\", \"zip\").add_entry(\"other.bin\", [1000])", object.RuntimeErrorObj},
+		{"open(\"test.zip\", \"zip\").add_entry(\"hello.txt\", [1])", object.RuntimeErrorObj},
+		{"open(\"test.zip\", \"elf\")", object.RuntimeErrorObj},
+	}
+
+	if err := os.WriteFile("test.zip", zipFile, 0666); err != nil {
+		t.Fatalf("cannot create the test.zip file")
+	}
+	defer func() { _ = os.Remove("test.zip") }()
+
+	for _, testCase := range testCases {
+		fileExpr := testEval(testCase.input)
+		if fileExpr.Type() != testCase.expected {
+			t.Errorf("%s: expected error of type %s, got %s", testCase.input, testCase.expected, fileExpr.Type())
+		}
+	}
+}
+
+func TestTarFileBuiltinMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var t = open(\"test.tar\", \"tar\")\nt.entries()", []string{"hello.txt", "data/data.bin"}},
+		{"var t = open(\"test.tar\", \"tar\")\nt.has_entry(\"data/data.bin\")", true},
+		{"var t = open(\"test.tar\", \"tar\")\nt.has_entry(\"data.bin\")", false},
+		{"var t = open(\"test.tar\", \"tar\")\nt.read_entry(\"data/data.bin\")", []int64{0, 1, 2, 3, 4, 5, 6, 7}},
+		{"var t = open(\"test.tar\", \"tar\")\nto_string(t.read_entry(\"hello.txt\"))", "hello\n"},
+		{
+			"var t = open(\"test.tar\", \"tar\")\nt.add_entry(\"added.bin\", [1, 2, 3], 420)\nt.read_entry(\"added.bin\")",
+			[]int64{1, 2, 3},
+		},
+		{
+			"var t = open(\"test.tar\", \"tar\")\nt.add_entry(\"saved.bin\", [0xca, 0xfe], 420)\nsave(t)\n" +
+				"open(\"test.tar\", \"tar\").read_entry(\"saved.bin\")",
+			[]int64{0xca, 0xfe},
+		},
+		{
+			"open(\"test.tar\", \"tar\").entries()",
+			[]string{"hello.txt", "data/data.bin", "saved.bin"},
+		},
+	}
+
+	err := os.WriteFile("test.tar", newTarFixture(t), 0666)
+	if err != nil {
+		t.Fatalf("cannot create the test.tar file")
+	}
+	defer func() { _ = os.Remove("test.tar") }()
+
+	for _, testCase := range tests {
+		evalTarBuiltin := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case bool:
+			testBooleanObject(t, evalTarBuiltin, expected)
+		case string:
+			testStringObject(t, evalTarBuiltin, expected)
+		case []int64:
+			testArrayObject(t, testCase.input, evalTarBuiltin, expected)
+		case []string:
+			testStringArrayObject(t, evalTarBuiltin, expected)
+		}
+	}
+}
+
+func TestTarFileBuiltinMethodsFailure(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected object.ObjectType
+	}{
+		{"open(\"test.tar\", \"tar\").entries(1)", object.ErrorObj},
+		{"open(\"test.tar\", \"tar\").has_entry()", object.ErrorObj},
+		{"open(\"test.tar\", \"tar\").read_entry(1)", object.ErrorObj},
+		{"open(\"test.tar\", \"tar\").read_entry(\"other.bin\")", object.RuntimeErrorObj},
+		{"open(\"test.tar\", \"tar\").add_entry(\"other.bin\", [1])", object.ErrorObj},
+		{"open(\"test.tar\", \"tar\").add_entry(\"other.bin\", [1000], 420)", object.RuntimeErrorObj},
+		{"open(\"test.tar\", \"tar\").add_entry(\"other.bin\", [1], -1)", object.RuntimeErrorObj},
+		{"open(\"test.tar\", \"tar\").add_entry(\"hello.txt\", [1], 420)", object.RuntimeErrorObj},
+		{"open(\"test.tar\", \"zip\")", object.RuntimeErrorObj},
+	}
+
+	if err := os.WriteFile("test.tar", newTarFixture(t), 0666); err != nil {
+		t.Fatalf("cannot create the test.tar file")
+	}
+	defer func() { _ = os.Remove("test.tar") }()
+
+	for _, testCase := range testCases {
+		fileExpr := testEval(testCase.input)
+		if fileExpr.Type() != testCase.expected {
+			t.Errorf("%s: expected error of type %s, got %s", testCase.input, testCase.expected, fileExpr.Type())
+		}
+	}
+}
+
+// newTarFixture builds a tar archive containing two regular files:
+// 'hello.txt', containing the "hello\n" string, and 'data/data.bin',
+// containing the sequence 0x00..0x07.
+func newTarFixture(t *testing.T) []byte {
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{"hello.txt", []byte("hello\n")},
+		{"data/data.bin", []byte{0, 1, 2, 3, 4, 5, 6, 7}},
+	}
+
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     entry.name,
+			Mode:     0o644,
+			Size:     int64(len(entry.data)),
+		}
+
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatalf("cannot write the tar fixture header: %v", err)
+		}
+
+		if _, err := writer.Write(entry.data); err != nil {
+			t.Fatalf("cannot write the tar fixture data: %v", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("cannot close the tar fixture: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBytesFileBuiltinMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []int64
+	}{
+		{"var b = open(\"test.bin\", \"bytes\")\nb.read_at(0, 5)", []int64{0, 0, 0, 0, 0}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.write_at(0, [1, 2, 3])\nb.read_at(0, 5)", []int64{1, 2, 3, 0, 0}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.write_at(5, [1, 2, 3])\nb.read_at(5, 5)", []int64{1, 2, 3, 0, 0}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.write_at(5, \"010203\")\nb.read_at(5, 5)", []int64{1, 2, 3, 0, 0}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.write_at(2, [0xca, 0xfe])\nb.read_at(0, 5)", []int64{0, 0, 0xca, 0xfe, 0}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.write_at(2, \"0xCAFE\")\nb.read_at(0, 5)", []int64{0, 0, 0xca, 0xfe, 0}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.fill_pattern(1, 6, [0xde, 0xad, 0xbe, 0xef])\nb.read_at(0, 8)", []int64{0, 0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.fill_pattern(0, 3, [0xde, 0xad, 0xbe, 0xef])\nb.read_at(0, 4)", []int64{0xde, 0xad, 0xbe, 0}},
+		{"var b = bytes_from_hex(\"0000000000000000\")\nb.apply_patch([[0, [1, 2]], [5, \"0304\"]])\nb.read_at(0, 8)", []int64{1, 2, 0, 0, 0, 3, 4, 0}},
+		{"var b = bytes_from_hex(\"0102030400000000\")\nb.copy_region(0, 4, 4)\nb.read_at(0, 8)", []int64{1, 2, 3, 4, 1, 2, 3, 4}},
+		{"var b = bytes_from_hex(\"0102030405000000\")\nb.copy_region(0, 2, 5)\nb.read_at(0, 8)", []int64{1, 2, 1, 2, 3, 4, 5, 0}},
+		{"var b = bytes_from_hex(\"0001020304050000\")\nb.copy_region(2, 0, 4)\nb.read_at(0, 8)", []int64{2, 3, 4, 5, 4, 5, 0, 0}},
+		{"var b = open(\"test.bin\", \"bytes\")\nb.map(fun(x) { ret x + 1 })\nb.read_at(0, 5)", []int64{1, 1, 1, 1, 1}},
+		{"var b = bytes_from_hex(\"01ff80\")\nb.map(fun(x) { ret x ^ 0xff })\nb.read_at(0, 3)", []int64{0xfe, 0x00, 0x7f}},
+	}
+
+	bytesFile := [32]byte{}
+
+	err := os.WriteFile("test.bin", bytesFile[:], 0666)
+	if err != nil {
+		t.Fatalf("cannot create the test.bin file")
+	}
+	defer func() { _ = os.Remove("test.bin") }()
+
+	for _, testCase := range tests {
+		evalElfBuiltin := testEval(testCase.input)
+		evalArr, isArr := evalElfBuiltin.(*object.Array)
+		if !isArr {
+			t.Fatalf("expected array, got %T: %v", evalElfBuiltin, evalElfBuiltin)
+		}
+
+		for idx, elem := range evalArr.Elements {
+			intElem, isInt := elem.(*object.Integer)
+			if !isInt {
+				t.Fatalf("expected int, got %T", elem)
+			}
+
+			if idx > len(testCase.expected) || intElem.Value != testCase.expected[idx] {
+				t.Fatalf("expected %v, got %d", testCase.expected, intElem.Value)
+			}
+		}
+	}
+}
+
+func TestTypedReadersAndWriters(t *testing.T) {
+	hexFile := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`bytes_from_hex("78563412").read_u32(0, "little")`, int64(0x12345678)},
+		{`bytes_from_hex("78563412").read_u32(0, "big")`, int64(0x78563412)},
+		{`bytes_from_hex("00cafe00").read_u16(1, "big")`, int64(0xcafe)},
+		{`bytes_from_hex("00cafe00").read_u16(1, "little")`, int64(0xfeca)},
+		{`bytes_from_hex("0102030405060708").read_u64(0, "big")`, int64(0x0102030405060708)},
+		{`bytes_from_hex("0102030405060708").read_u64(0, "little")`, int64(0x0807060504030201)},
+		{`open("test.hex", "hex").read_u32(0x1C200, "little")`, int64(0xF6E6A5E0)},
+		{`open("test.hex", "hex").read_u32(0x1C200, "big")`, int64(0xE0A5E6F6)},
+		{`open("test.hex", "hex").read_u16(0x1C20E, "big")`, int64(0xE6FD)},
+		{`open("test.hex", "hex").read_u16(0x1C20E, "little")`, int64(0xFDE6)},
+		{"var b = bytes_from_hex(\"00000000\")\nb.write_u32(0, 0x12345678, \"little\")\nb.read_u32(0, \"little\")", int64(0x12345678)},
+		{"var b = bytes_from_hex(\"00000000\")\nb.write_u32(0, 0x12345678, \"big\")\nb.read_u32(0, \"big\")", int64(0x12345678)},
+		{"var b = bytes_from_hex(\"00000000\")\nb.write_u32(0, 0x12345678, \"big\")\nb.read_u32(0, \"little\")", int64(0x78563412)},
+		{"var b = bytes_from_hex(\"00000000\")\nb.write_u16(1, 0xcafe, \"little\")\nb.read_u32(0, \"big\")", int64(0x00feca00)},
+		{"var b = bytes_from_hex(\"0000000000000000\")\nb.write_u64(0, 0x0102030405060708, \"little\")\nb.read_u64(0, \"big\")", int64(0x0807060504030201)},
+		{"var h = open(\"test.hex\", \"hex\")\nh.write_u32(0x1C204, 0xdeadbeef, \"big\")\nh.read_u32(0x1C204, \"big\")", int64(0xdeadbeef)},
+		{"var h = open(\"test.hex\", \"hex\")\nh.write_u32(0x1C204, 0xdeadbeef, \"little\")\nh.read_u32(0x1C204, \"big\")", int64(0xefbeadde)},
+		{"var h = open(\"test.hex\", \"hex\")\nh.write_u16(0x1C20E, 0xbeef, \"big\")\nh.read_u16(0x1C20E, \"big\")", int64(0xbeef)},
+		{`bytes_from_hex("0000").write_u16(0, 0x10000, "big")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("0000").write_u16(0, -1, "big")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("0000").write_u16(0, 1, "middle")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("0000").write_u32(0, 1, "big")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("0000").write_u16(0, 1)`, object.ErrorObj},
+		{`open("test.hex", "hex").write_u16(0, 1, "big")`, object.RuntimeErrorObj},
+		{`open("test.hex", "hex").write_u32(0x1C200, 0x100000000, "big")`, object.RuntimeErrorObj},
+		{`open("test.hex", "hex", "ro").write_u16(0x1C200, 1, "big")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("78563412").read_u32(1, "little")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("78563412").read_u32(0, "middle")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("78563412").read_u32(-1, "big")`, object.RuntimeErrorObj},
+		{`bytes_from_hex("78563412").read_u32(0)`, object.ErrorObj},
+		{`open("test.hex", "hex").read_u16(0, "big")`, object.RuntimeErrorObj},
+		{`open("test.hex", "hex").read_u16(0x1C200, 1)`, object.ErrorObj},
+	}
+
+	if err := os.WriteFile("test.hex", []byte(hexFile), 0666); err != nil {
+		t.Fatalf("cannot create the test.hex file")
+	}
+	defer func() { _ = os.Remove("test.hex") }()
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int64:
+			testIntegerObject(t, testCase.input, evaluated, expected)
+		case object.ObjectType:
+			if evaluated.Type() != expected {
+				t.Errorf("%s: expected %s, got %s", testCase.input, expected, evaluated.Type())
+			}
+		}
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	hexFile := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`bytes_from_hex("cafe00cafe00").find_all([0xca, 0xfe])`, []int64{0, 3}},
+		{`bytes_from_hex("cafe00cafe00").find_all([0xfe, 0xca])`, []int64{}},
+		{`bytes_from_hex("000000").find_all([0, 0])`, []int64{0}},
+		{`open("test.hex", "hex").find_all([0xFD, 0xFF])`, []int64{0x1C204, 0x1C20C}},
+		{`open("test.hex", "hex").find_all([0xE0])`, []int64{0x1C200, 0x1C206, 0x1C208}},
+		{`open("test.hex", "hex").find_all([0xCA, 0xFE])`, []int64{}},
+		{`bytes_from_hex("cafe").find_all([])`, object.RuntimeErrorObj},
+		{`bytes_from_hex("cafe").find_all([256])`, object.RuntimeErrorObj},
+		{`bytes_from_hex("cafe").find_all("cafe")`, object.ErrorObj},
+		{`open("test.hex", "hex").find_all([])`, object.RuntimeErrorObj},
+		{`open("test.hex", "hex").find_all()`, object.ErrorObj},
+	}
+
+	if err := os.WriteFile("test.hex", []byte(hexFile), 0666); err != nil {
+		t.Fatalf("cannot create the test.hex file")
+	}
+	defer func() { _ = os.Remove("test.hex") }()
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case []int64:
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestBytesReplace(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`bytes_from_hex("00cafe00").replace([0xca, 0xfe], [0xbe, 0xef])`, 1},
+		{`bytes_from_hex("cafecafe00cafe").replace([0xca, 0xfe], [0, 0])`, 3},
+		{`bytes_from_hex("cafe").replace([0xbe, 0xef], [0, 0])`, 0},
+		{"var b = bytes_from_hex(\"00cafe00\")\nb.replace([0xca, 0xfe], [0xbe, 0xef])\nb.read_at(0, 4)", []int64{0, 0xbe, 0xef, 0}},
+		{"var b = bytes_from_hex(\"cafecafe00cafe\")\nb.replace([0xca, 0xfe], [1, 2])\nb.read_at(0, 7)", []int64{1, 2, 1, 2, 0, 1, 2}},
+		{`bytes_from_hex("cafe").replace([0xca, 0xfe], [0])`, object.RuntimeErrorObj},
+		{`bytes_from_hex("cafe").replace([], [])`, object.RuntimeErrorObj},
+		{`bytes_from_hex("cafe").replace([0xca], [256])`, object.RuntimeErrorObj},
+		{`bytes_from_hex("cafe").replace([0xca])`, object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evaluated, int64(expected))
+		case []int64:
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestScanStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`repr(bytes_from_hex("00016861726c6f636b006162ff76312e322e337f").scan_strings(4))`, `[[2, "harlock"], [13, "v1.2.3"]]`},
+		{`repr(bytes_from_hex("00016861726c6f636b006162ff76312e322e337f").scan_strings(2))`, `[[2, "harlock"], [10, "ab"], [13, "v1.2.3"]]`},
+		{`repr(bytes_from_hex("00016861726c6f636b006162ff76312e322e337f").scan_strings(8))`, `[]`},
+		{`repr(bytes_from_hex("68690968").scan_strings(1))`, `[[0, "hi\th"]]`},
+		{`repr(to_hex(from_hex("00016861726c6f636b006162ff76312e322e337f"), 4).scan_strings(4))`, `[[2, "harlock"], [13, "v1.2.3"]]`},
+		{`repr(to_hex([0, 1, 2]).scan_strings(1))`, `[]`},
+		{`bytes_from_hex("00016861726c6f636b006162ff76312e322e337f").scan_strings(0)`, object.BytesError},
+		{`to_hex(from_hex("00016861726c6f636b006162ff76312e322e337f")).scan_strings(-1)`, object.TypeError},
+		{`bytes_from_hex("00016861726c6f636b006162ff76312e322e337f").scan_strings()`, object.ErrorObj},
+		{`bytes_from_hex("00016861726c6f636b006162ff76312e322e337f").scan_strings("4")`, object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case string:
+			testStringObject(t, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
+			}
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
+
+func TestApplyPatchIsAtomic(t *testing.T) {
+	tests := []struct {
+		file     string
+		patch    string
+		expected object.RuntimeErrorType
+	}{
+		{`bytes_from_hex("00000000")`, `[[0, [1]], [3, [2, 3]]]`, object.BytesError},
+		{`bytes_from_hex("00000000")`, `[[0, [1]], [1, [256]]]`, object.TypeError},
+		{`to_hex(from_hex("00000000"))`, `[[0, [1]], [3, "0203"]]`, object.HexError},
+		{`to_hex(from_hex("00000000"))`, `[[0, [1]], [-1, [2]]]`, object.TypeError},
+	}
+
+	for _, testCase := range tests {
+		file := testEval(testCase.file)
+		methods := builtinMethods[file.Type()]
+
+		outcome := methods["apply_patch"].Call(file, testEval(testCase.patch))
+		runtimeErr, isRuntimeErr := outcome.(*object.RuntimeError)
+		if !isRuntimeErr || runtimeErr.Kind != testCase.expected {
+			t.Errorf("%s: expected a %s, got %v", testCase.patch, testCase.expected, outcome)
+		}
+
+		contents := methods["read_at"].Call(file, &object.Integer{Value: 0}, &object.Integer{Value: 4})
+		testArrayObject(t, testCase.patch, contents, []int64{0, 0, 0, 0})
+	}
+}
+
+func TestBytesCursor(t *testing.T) {
+	// magic (4 bytes), version (u8), flags (u16 little), size (u16 big), payload
+	header := `var b = bytes_from_hex("7f454c460134120010deadbeef")
+var c = b.cursor()
+`
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{header + "c.read_bytes(4)", []int64{0x7f, 0x45, 0x4c, 0x46}},
+		{header + "c.skip(4)\nc.read_u8()", 1},
+		{header + "c.seek(5)\nc.read_u16(\"little\")", 0x1234},
+		{header + "c.seek(7)\nc.read_u16(\"big\")", 0x0010},
+		{header + "var magic = c.read_bytes(4)\nvar version = c.read_u8()\nvar flags = c.read_u16(\"little\")\n" +
+			"var size = c.read_u16(\"big\")\nvar payload = c.read_bytes(size - 12)\n[version, flags, size, c.tell()] + payload",
+			[]int64{1, 0x1234, 0x10, 13, 0xde, 0xad, 0xbe, 0xef}},
+		{header + "c.read_bytes(2)\nc.skip(-1)\nc.tell()", 1},
+		{header + "c.seek(13)\nc.read_bytes(0)", []int64{}},
+		{header + "c.seek(12)\nc.read_u16(\"big\")", object.BytesError},
+		{header + "c.seek(13)\nc.read_u8()", object.BytesError},
+		{header + "c.read_u16(\"middle\")", object.TypeError},
+		{header + "c.seek(14)", object.BytesError},
+		{header + "c.skip(-1)", object.BytesError},
+		{header + "c.read_bytes(-1)", object.BytesError},
+		{header + "c.seek(1)\nc.read_bytes(0x7fffffffffffffff)", object.BytesError},
+		{header + "c.seek(13)\nc.read_bytes(1)", object.BytesError},
+		{header + "c.read_u16()", object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evaluated, int64(expected))
+		case []int64:
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
+			}
+		case object.ObjectType:
+			if evaluated.Type() != expected {
+				t.Errorf("%s: expected object of type %s, got %s", testCase.input, expected, evaluated.Type())
+			}
+		}
+	}
+}
+
+func TestBytesCursorDoesNotAdvanceOnError(t *testing.T) {
+	file := testEval(`bytes_from_hex("0102")`)
+	cursor := builtinMethods[file.Type()]["cursor"].Call(file)
+	methods := builtinMethods[cursor.Type()]
+
+	if outcome := methods["read_bytes"].Call(cursor, &object.Integer{Value: 3}); !isRuntimeError(outcome) {
+		t.Fatalf("expected a runtime error reading past the end, got %v", outcome)
+	}
+	testIntegerObject(t, "tell", methods["tell"].Call(cursor), 0)
+}
+
+func TestBytesBuilder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"to_hex_string(bytes_builder().build())", ""},
+		{"var b = bytes_builder()\nb.write_bytes([0x7f, 0x45, 0x4c, 0x46])\nb.write_u8(1)\n" +
+			"b.write_u16(0x1234, \"little\")\nb.write_u16(0x10, \"big\")\nb.write_string(\"hi\", \"ascii\")\n" +
+			"to_hex_string(b.build())", "7f454c4601341200106869"},
+		{"var b = bytes_builder()\nb.write_string(\"\u00e8\", \"utf8\")\nb.write_string(\"\u00e8\", \"latin1\")\nb.size()", 3},
+		{"var b = bytes_builder()\nb.write_u8(1)\nvar f = b.build()\nb.write_u8(2)\nto_hex_string(f)", "01"},
+		{"var b = bytes_builder()\nb.write_u16(0xcafe, \"big\")\nb.build().read_u16(0, \"big\")", 0xcafe},
+		{"bytes_builder().write_u8(256)", object.TypeError},
+		{"bytes_builder().write_u16(0x10000, \"big\")", object.TypeError},
+		{"bytes_builder().write_u16(1, \"middle\")", object.TypeError},
+		{"bytes_builder().write_bytes([1, -1])", object.TypeError},
+		{"bytes_builder().write_string(\"\u00e8\", \"ascii\")", object.TypeError},
+		{"bytes_builder().write_bytes(\"01\")", object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evaluated, int64(expected))
+		case string:
+			testStringObject(t, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
+			}
+		case object.ObjectType:
+			if evaluated.Type() != expected {
+				t.Errorf("%s: expected object of type %s, got %s", testCase.input, expected, evaluated.Type())
+			}
+		}
+	}
+}
+
+func TestBytesInsertAt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var b = bytes_from_hex(\"01020304\")\nb.insert_at(2, [0xaa, 0xbb])\nb", []byte{1, 2, 0xaa, 0xbb, 3, 4}},
+		{"var b = bytes_from_hex(\"01020304\")\nb.insert_at(4, [0xaa])\nb", []byte{1, 2, 3, 4, 0xaa}},
+		{"var b = bytes_from_hex(\"01020304\")\nb.insert_at(0, [0xaa])\nb", []byte{0xaa, 1, 2, 3, 4}},
+		{"var b = bytes_from_hex(\"0102\")\nb.insert_at(1, [])\nb", []byte{1, 2}},
+		{"var b = bytes_from_hex(\"0102\")\nb.insert_at(1, [3, 4])\nb.read_at(2, 2)", []int64{4, 2}},
+		{`bytes_from_hex("0102").insert_at(3, [0])`, object.BytesError},
+		{`bytes_from_hex("0102").insert_at(-1, [0])`, object.BytesError},
+		{`bytes_from_hex("0102").insert_at(0, [256])`, object.TypeError},
+		{`bytes_from_hex("0102").view(0, 2).insert_at(0, [0])`, object.FileError},
+		{`bytes_from_hex("0102").insert_at(0)`, object.ErrorObj},
 	}
-	defer func() { _ = os.Remove("test.elf") }()
 
 	for _, testCase := range tests {
-		evalElfBuiltin := testEval(testCase.input)
+		evaluated := testEval(testCase.input)
 		switch expected := testCase.expected.(type) {
-		case bool:
-			evalBool, isBool := evalElfBuiltin.(*object.Boolean)
-			if !isBool {
-				t.Fatalf("expected bool, got %T", evalElfBuiltin)
+		case []byte:
+			bytesFile, isBytes := evaluated.(*object.BytesFile)
+			if !isBytes {
+				t.Errorf("%s: expected a bytes file, got %v", testCase.input, evaluated)
+				continue
 			}
-
-			if expected != evalBool.Value {
-				t.Fatalf("expected bool = %t, got %t", expected, evalBool.Value)
+			if !bytes.Equal(bytesFile.AsBytes(), expected) {
+				t.Errorf("%s: expected %v, got %v", testCase.input, expected, bytesFile.AsBytes())
 			}
 		case []int64:
-			evalArr, isArr := evalElfBuiltin.(*object.Array)
-			if !isArr {
-				t.Fatalf("expected array, got %T: %v", evalElfBuiltin, evalElfBuiltin)
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
 			}
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
+	}
+}
 
-			for idx, elem := range evalArr.Elements {
-				intElem, isInt := elem.(*object.Integer)
-				if !isInt {
-					t.Fatalf("expected int, got %T", elem)
-				}
-
-				if idx > len(expected) || intElem.Value != expected[idx] {
-					t.Fatalf("expected %v, got %d", expected, intElem.Value)
-				}
-			}
-		case int64:
-			evalInt, isInt := evalElfBuiltin.(*object.Integer)
-			if !isInt {
-				t.Fatalf("expected int, got %T", evalElfBuiltin)
-			}
+func TestBytesRemoveRange(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var b = bytes_from_hex(\"0102030405\")\nb.remove_range(1, 2)\nb", []byte{1, 4, 5}},
+		{"var b = bytes_from_hex(\"0102030405\")\nb.remove_range(3, 2)\nb", []byte{1, 2, 3}},
+		{"var b = bytes_from_hex(\"0102030405\")\nb.remove_range(0, 5)\nb", []byte{}},
+		{"var b = bytes_from_hex(\"0102030405\")\nb.remove_range(2, 0)\nb", []byte{1, 2, 3, 4, 5}},
+		{"var b = bytes_from_hex(\"0102030405\")\nb.remove_range(3, 2)\ntry b.read_at(2, 2)", object.BytesError},
+		{`bytes_from_hex("0102").remove_range(1, 2)`, object.BytesError},
+		{`bytes_from_hex("0102").remove_range(-1, 1)`, object.BytesError},
+		{`bytes_from_hex("0102").remove_range(0x7fffffffffffffff, 2)`, object.BytesError},
+		{`bytes_from_hex("0102").remove_range(1, 0x7fffffffffffffff)`, object.BytesError},
+		{`bytes_from_hex("0102").view(0, 2).remove_range(0, 1)`, object.FileError},
+		{`bytes_from_hex("0102").remove_range(0)`, object.ErrorObj},
+	}
 
-			if expected != evalInt.Value {
-				t.Fatalf("expected value = %d, got %d", expected, evalInt.Value)
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case []byte:
+			bytesFile, isBytes := evaluated.(*object.BytesFile)
+			if !isBytes {
+				t.Errorf("%s: expected a bytes file, got %v", testCase.input, evaluated)
+				continue
 			}
-		case []string:
-			evalArr, isArr := evalElfBuiltin.(*object.Array)
-			if !isArr {
-				t.Fatalf("expected array, got %T: %v", evalElfBuiltin, evalElfBuiltin)
+			if !bytes.Equal(bytesFile.AsBytes(), expected) {
+				t.Errorf("%s: expected %v, got %v", testCase.input, expected, bytesFile.AsBytes())
 			}
-
-			for idx, elem := range evalArr.Elements {
-				strElem, isString := elem.(*object.String)
-				if !isString {
-					t.Fatalf("expected String, got %T", elem)
-				}
-
-				if idx > len(expected) || strElem.Value != expected[idx] {
-					t.Fatalf("expected %v, got %s", expected, strElem.Value)
-				}
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
 			}
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
 		}
 	}
 }
 
-func TestElfFileBuiltinMethodsFailure(t *testing.T) {
-	testCases := []struct {
+func TestBytesView(t *testing.T) {
+	tests := []struct {
 		input    string
-		expected object.ObjectType
+		expected any
 	}{
-		{"open(\"test.elf\", \"elf\").has_section()", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").has_section(1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").has_section(1, 2)", object.ErrorObj},
-
-		{"open(\"test.elf\", \"elf\").sections(1)", object.ErrorObj},
-
-		{"open(\"test.elf\", \"elf\").section_address()", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").section_address(1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").section_address(\"test\", 1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").section_address(\"test-not-exist\")", object.RuntimeErrorObj},
-
-		{"open(\"test.elf\", \"elf\").section_size()", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").section_size(1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").section_size(\"test\", 1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").section_size(\"test-not-exist\")", object.RuntimeErrorObj},
-
-		{"open(\"test.elf\", \"elf\").read_section()", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").read_section(1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").read_section(\"test-not-exist\", 1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").read_section(1, 2, 3)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").read_section(1, 2, 3)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").read_section(\"test-not-exist\")", object.RuntimeErrorObj},
+		{`bytes_from_hex("0102030405").view(1, 4).read_at(0, 3)`, []int64{2, 3, 4}},
+		{`bytes_from_hex("0102030405").view(2, 2).read_at(0, 0)`, []int64{}},
+		{"var b = bytes_from_hex(\"0102030405\")\nvar v = b.view(1, 3)\nb.write_at(1, [0xff, 0xfe])\nv.read_at(0, 2)", []int64{0xff, 0xfe}},
+		{"var b = bytes_from_hex(\"0102030405\")\nvar v = b.view(1, 3)\nb.replace([3], [0])\nv.read_at(0, 2)", []int64{2, 0}},
+		{"var b = bytes_from_hex(\"0100\")\nvar v = b.view(0, 2)\nb.insert_at(0, [9, 9])\nb.write_at(0, [7])\nv.read_at(0, 2) + b.read_at(0, 4)", []int64{1, 0, 7, 9, 1, 0}},
+		{"var b = bytes_from_hex(\"010203\")\nvar v = b.view(0, 3)\nb.remove_range(0, 1)\nb.write_at(0, [7])\nv.read_at(0, 3) + b.read_at(0, 2)", []int64{1, 2, 3, 7, 3}},
+		{`bytes_from_hex("0102030405").view(1, 3).write_at(0, [0])`, object.FileError},
+		{`bytes_from_hex("0102030405").view(1, 3).fill_pattern(0, 2, [0])`, object.FileError},
+		{`bytes_from_hex("0102030405").view(1, 3).read_at(1, 2)`, object.BytesError},
+		{`bytes_from_hex("0102030405").view(1, 6)`, object.BytesError},
+		{`bytes_from_hex("0102030405").view(3, 1)`, object.BytesError},
+		{`bytes_from_hex("0102030405").view(-1, 1)`, object.BytesError},
+		{`bytes_from_hex("0102030405").view(1)`, object.ErrorObj},
+	}
 
-		{"open(\"test.elf\", \"elf\").write_section()", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").write_section(1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").write_section(\"test-not-exist\", 1)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").write_section(1, 2, 3)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").write_section(1, 2, 3, 4)", object.ErrorObj},
-		{"open(\"test.elf\", \"elf\").write_section(\"test-not-exist\", [1, 2], -1)", object.RuntimeErrorObj},
-		{"open(\"test.elf\", \"elf\").write_section(\"test-not-exist\", [1000, 2], 0)", object.RuntimeErrorObj},
-		{"open(\"test.elf\", \"elf\").write_section(\"test-not-exist\", [1, 2, 3], 0)", object.RuntimeErrorObj},
-		{"open(\"test.elf\", \"elf\").write_section(\".metadata\", [1, 2, 3], 100000000000)", object.RuntimeErrorObj},
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case []int64:
+			testArrayObject(t, testCase.input, evaluated, expected)
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evaluated.(*object.RuntimeError)
+			if !isRuntimeErr || runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %v", testCase.input, expected, evaluated)
+			}
+		case object.ObjectType:
+			testError(t, testCase.input, expected, evaluated)
+		}
 	}
+}
 
-	if err := os.WriteFile("test.elf", elfFile, 0666); err != nil {
-		t.Fatalf("cannot create the test.elf file")
+func TestBytesFileChecksums(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`bytes_from_hex("01020304").sum(0, 4, 8)`, 10},
+		{`bytes_from_hex("01020304").sum(1, 2, 8)`, 5},
+		{`bytes_from_hex("ffffff").sum(0, 3, 8)`, 0xfd},
+		{`bytes_from_hex("ffffff").sum(0, 3, 16)`, 0x2fd},
+		{`bytes_from_hex("ffffff").sum(0, 3, 32)`, 0x2fd},
+		{`bytes_from_hex("01020304").sum(0, 0, 8)`, 0},
+		{`bytes_from_hex("01020304").complement_checksum(0, 4, 8)`, 0xf6},
+		{`bytes_from_hex("01020304").complement_checksum(0, 4, 16)`, 0xfff6},
+		{`bytes_from_hex("00000000").complement_checksum(0, 4, 32)`, 0},
+		{"var b = bytes_from_hex(\"0102030400\")\n" +
+			"b.write_at(4, [b.complement_checksum(0, 4, 8)])\nb.sum(0, 5, 8)", 0},
+		{"var b = bytes_from_hex(\"ffffff0000\")\n" +
+			"var c = b.complement_checksum(0, 3, 16)\nb.write_at(3, as_array(c, 2, \"big\"))\n" +
+			"(b.sum(0, 3, 16) + c) & 0xffff", 0},
+		{`bytes_from_hex("01020304").sum(0, 4, 12)`, object.RuntimeErrorObj},
+		{`bytes_from_hex("01020304").sum(-1, 4, 8)`, object.RuntimeErrorObj},
+		{`bytes_from_hex("01020304").sum(0, 5, 8)`, object.RuntimeErrorObj},
+		{`bytes_from_hex("01020304").complement_checksum(2, 4, 8)`, object.RuntimeErrorObj},
+		{`bytes_from_hex("01020304").sum(0, 4)`, object.ErrorObj},
 	}
-	defer func() { _ = os.Remove("test.elf") }()
 
-	for _, testCase := range testCases {
-		fileExpr := testEval(testCase.input)
-		if fileExpr.Type() != testCase.expected {
-			t.Errorf("%s: expected error of type %s, got %s", testCase.input, testCase.expected, fileExpr.Type())
+	for _, testCase := range tests {
+		evalChecksum := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evalChecksum, int64(expected))
+		case object.ObjectType:
+			if evalChecksum.Type() != expected {
+				t.Errorf("%s: expected a %s object, got %s", testCase.input, expected, evalChecksum.Type())
+			}
 		}
 	}
 }
 
-func TestBytesFileBuiltinMethods(t *testing.T) {
+func TestBytesFileMapFailure(t *testing.T) {
 	tests := []struct {
 		input    string
-		expected []int64
+		expected object.ObjectType
 	}{
-		{"var b = open(\"test.bin\", \"bytes\")\nb.read_at(0, 5)", []int64{0, 0, 0, 0, 0}},
-		{"var b = open(\"test.bin\", \"bytes\")\nb.write_at(0, [1, 2, 3])\nb.read_at(0, 5)", []int64{1, 2, 3, 0, 0}},
-		{"var b = open(\"test.bin\", \"bytes\")\nb.write_at(5, [1, 2, 3])\nb.read_at(5, 5)", []int64{1, 2, 3, 0, 0}},
+		{"var b = bytes_from_hex(\"01ff80\")\nb.map(fun(x) { ret x + 1 })", object.RuntimeErrorObj},
+		{"var b = bytes_from_hex(\"01ff80\")\nb.map(fun(x) { ret x - 2 })", object.RuntimeErrorObj},
+		{"var b = bytes_from_hex(\"01ff80\")\nb.map(fun(x) { ret \"a\" })", object.RuntimeErrorObj},
+		{"var b = bytes_from_hex(\"01ff80\")\nb.map(fun(x, y) { ret x })", object.RuntimeErrorObj},
+		{"var b = bytes_from_hex(\"01ff80\")\nb.map(fun(x) { ret x / 0 })", object.ErrorObj},
+		{"var b = bytes_from_hex(\"01ff80\")\nb.map(1)", object.ErrorObj},
+		{"var b = bytes_from_hex(\"01ff80\")\nb.map()", object.ErrorObj},
+		{"var b = bytes_from_hex(\"01ff80\")\nvar r = b.map(fun(x) { ret x + 1 })\nb.read_at(0, 3)", object.ArrayObj},
 	}
 
-	bytesFile := [32]byte{}
+	for _, testCase := range tests {
+		evalBytesMap := testEval(testCase.input)
+		if evalBytesMap.Type() != testCase.expected {
+			t.Errorf("%s: expected a %s object, got %s", testCase.input, testCase.expected, evalBytesMap.Type())
+		}
+	}
 
-	err := os.WriteFile("test.bin", bytesFile[:], 0666)
-	if err != nil {
-		t.Fatalf("cannot create the test.bin file")
+	// a failing callback must leave the file untouched
+	input := "var b = bytes_from_hex(\"01ff80\")\nvar r = b.map(fun(x) { ret x + 1 })\nb.read_at(0, 3)"
+	testArrayObject(t, input, testEval(input), []int64{0x01, 0xff, 0x80})
+}
+
+func TestFileEqualsMethods(t *testing.T) {
+	hexFile := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	files := map[string][]byte{
+		"test.hex":  []byte(hexFile),
+		"test2.hex": []byte(hexFile),
+		"test.bin":  {1, 2, 3, 4},
+		"test2.bin": {1, 2, 3, 4},
 	}
-	defer func() { _ = os.Remove("test.bin") }()
 
-	for _, testCase := range tests {
-		evalElfBuiltin := testEval(testCase.input)
-		evalArr, isArr := evalElfBuiltin.(*object.Array)
-		if !isArr {
-			t.Fatalf("expected array, got %T: %v", evalElfBuiltin, evalElfBuiltin)
+	for name, contents := range files {
+		if err := os.WriteFile(name, contents, 0666); err != nil {
+			t.Fatalf("cannot create the %s file", name)
 		}
+	}
+	defer func() {
+		for name := range files {
+			_ = os.Remove(name)
+		}
+	}()
 
-		for idx, elem := range evalArr.Elements {
-			intElem, isInt := elem.(*object.Integer)
-			if !isInt {
-				t.Fatalf("expected int, got %T", elem)
-			}
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`open("test.hex", "hex").equals(open("test2.hex", "hex"))`, true},
+		{"var h = open(\"test.hex\", \"hex\")\nh.equals(h)", true},
+		{"var h = open(\"test2.hex\", \"hex\")\nh.write_at(0x1C200, [0])\n" +
+			"open(\"test.hex\", \"hex\").equals(h)", false},
+		{`open("test.bin", "bytes").equals(open("test2.bin", "bytes"))`, true},
+		{"var b = open(\"test2.bin\", \"bytes\")\nb.write_at(0, [0])\n" +
+			"open(\"test.bin\", \"bytes\").equals(b)", false},
+		{`open("test.hex", "hex").equals(open("test.bin", "bytes"))`, object.ErrorObj},
+		{`open("test.bin", "bytes").equals(open("test.hex", "hex"))`, object.ErrorObj},
+		{`open("test.bin", "bytes").equals([1, 2, 3, 4])`, object.ErrorObj},
+		{`open("test.bin", "bytes").equals()`, object.ErrorObj},
+	}
 
-			if idx > len(testCase.expected) || intElem.Value != testCase.expected[idx] {
-				t.Fatalf("expected %v, got %d", testCase.expected, intElem.Value)
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case object.ObjectType:
+			if evaluated.Type() != expected {
+				t.Errorf("%s: expected %s, got %s", testCase.input, expected, evaluated.Type())
 			}
 		}
 	}
@@ -1168,7 +3562,9 @@ func TestFailingBytesMethodBuiltins(t *testing.T) {
 
 		{"open(\"test.bin\", \"bytes\").write_at()", object.ErrorObj},
 		{"open(\"test.bin\", \"bytes\").write_at(1, 2)", object.ErrorObj},
-		{"open(\"test.bin\", \"bytes\").write_at(1, \"test\")", object.ErrorObj},
+		{"open(\"test.bin\", \"bytes\").write_at(1, \"test\")", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").write_at(1, \"abc\")", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").write_at(1, {})", object.ErrorObj},
 		{"open(\"test.bin\", \"bytes\").write_at(1, 2, 3)", object.ErrorObj},
 		{"open(\"test.bin\", \"bytes\").write_at(1, [1, 2, 3], \"test\")", object.ErrorObj},
 		{"open(\"test.bin\", \"bytes\").write_at(-1, [1, 2, 3])", object.RuntimeErrorObj},
@@ -1177,6 +3573,24 @@ func TestFailingBytesMethodBuiltins(t *testing.T) {
 		{"open(\"test.bin\", \"bytes\").write_at(0, [\"test\", 1, 3])", object.RuntimeErrorObj},
 		{"open(\"test.bin\", \"bytes\").write_at(0, [0, 0, 0, 0, 0, 0, 0, 0, 0])", object.RuntimeErrorObj},
 		{"open(\"test.bin\", \"bytes\").write_at(7, [0, 0, 0])", object.RuntimeErrorObj},
+
+		{"open(\"test.bin\", \"bytes\").copy_region(0, 4)", object.ErrorObj},
+		{"open(\"test.bin\", \"bytes\").copy_region(-1, 4, 2)", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").copy_region(6, 0, 4)", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").copy_region(0, 6, 4)", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(0, 4)", object.ErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(0, 4, \"dead\")", object.ErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(0, 4, [])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(-1, 4, [1])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(0, -4, [1])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(0, 4, [256])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(6, 4, [1, 2])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(0, 0x7fffffffffffffff, [1])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(1, 0xffffffffff, [1])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").fill_pattern(0x7fffffffffffffff, 2, [1])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").apply_patch([[0]])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").apply_patch([[-1, [1]]])", object.RuntimeErrorObj},
+		{"open(\"test.bin\", \"bytes\").apply_patch([[7, [1, 2]]])", object.RuntimeErrorObj},
 	}
 
 	bytesFile := [8]byte{}
@@ -1281,6 +3695,30 @@ func TestSetBuiltinMethods(t *testing.T) {
 	}
 }
 
+func TestSetSubsets(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"set(1, 2).is_subset(set(1, 2, 3))", true},
+		{"set(1, 2, 3).is_subset(set(1, 2))", false},
+		{"set(1, 2).is_subset(set(2, 1))", true},
+		{"set(1, 2).is_subset(set(3, 4))", false},
+		{"set().is_subset(set(1))", true},
+		{"set(1, 2, 3).is_superset(set(1, 2))", true},
+		{"set(1, 2).is_superset(set(1, 2, 3))", false},
+		{"set(1, 2).is_superset(set(2, 1))", true},
+		{"set(1, 2).is_superset(set(3, 4))", false},
+		{"set(1).is_superset(set())", true},
+		{`set(".text", ".data", ".bss").is_superset(set(".text", ".data"))`, true},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		testBooleanObject(t, evaluated, testCase.expected)
+	}
+}
+
 func TestSetBuiltinMethodsFailure(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1292,6 +3730,9 @@ func TestSetBuiltinMethodsFailure(t *testing.T) {
 		{"var s = set(1, 2, 4, 7)}\ns.remove()", object.ErrorObj},
 		{"var s = set(1, 2, 4, 7)}\ns.remove(7, 1)", object.ErrorObj},
 		{"var s = set(1, 2, 4, 7)}\ns.remove([1, 2, 3])", object.RuntimeErrorObj},
+		{"set(1, 2).is_subset([1, 2, 3])", object.ErrorObj},
+		{"set(1, 2).is_superset({1: 2})", object.ErrorObj},
+		{"set(1, 2).is_subset()", object.ErrorObj},
 	}
 
 	for _, testCase := range tests {
@@ -1300,6 +3741,129 @@ func TestSetBuiltinMethodsFailure(t *testing.T) {
 	}
 }
 
+func TestStringBuiltinMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`"1.2".pad_end(5)`, "1.2  "},
+		{`"1.2".pad_start(5)`, "  1.2"},
+		{`"ff".pad_start(4, "0")`, "00ff"},
+		{`"ab".pad_end(4, "-")`, "ab--"},
+		{`"abcd".pad_start(2, "0")`, "abcd"},
+		{`"abcd".pad_end(4)`, "abcd"},
+		{`"".pad_end(0)`, ""},
+		{`"ab".pad_start(3, "ü")`, "üab"},
+		{`"section.text".substring(8, 12)`, "text"},
+		{`"section.text".substring(0, 7)`, "section"},
+		{`"abc".substring(1, 1)`, ""},
+		{`"abc".substring(0, 3)`, "abc"},
+		{`"abc".substring(0, 4)`, object.RuntimeErrorObj},
+		{`"abc".substring(-1, 2)`, object.RuntimeErrorObj},
+		{`"abc".substring(2, 1)`, object.RuntimeErrorObj},
+		{`"abc".substring(1)`, object.ErrorObj},
+		{`"abc"[0]`, "a"},
+		{`"abc"[1]`, "b"},
+		{`"abc"[-1]`, "c"},
+		{`"abc"[-3]`, "a"},
+		{"var s = \"abc\"\ns[len(s) - 1]", "c"},
+		{`"abc"[3]`, object.ErrorObj},
+		{`"abc"[-4]`, object.ErrorObj},
+		{`""[0]`, object.ErrorObj},
+		{`"abc"["a"]`, object.ErrorObj},
+		{`"section.text"[8:12]`, "text"},
+		{`"section.text"[0:7]`, "section"},
+		{"var s = \"abcdef\"\nvar n = 2\ns[n:n * 2]", "cd"},
+		{`"abc"[0:4]`, object.ErrorObj},
+		{`"abc"[2:1]`, object.ErrorObj},
+		{`"abc"["a":1]`, object.ErrorObj},
+		{`[1, 2, 3][0:1]`, object.ErrorObj},
+		{`"ab".pad_start(-1)`, object.RuntimeErrorObj},
+		{`"ab".pad_end(4, "ab")`, object.RuntimeErrorObj},
+		{`"ab".pad_end(4, "")`, object.RuntimeErrorObj},
+		{`"ab".pad_end(4, 0)`, object.RuntimeErrorObj},
+		{`"ab".pad_end("4")`, object.ErrorObj},
+		{`"ab".pad_start()`, object.ErrorObj},
+		{`"ab".pad_start(4, " ", 1)`, object.ErrorObj},
+		{`"ciao".encode("ascii")`, []int64{0x63, 0x69, 0x61, 0x6f}},
+		{`"ciao".encode("utf8")`, []int64{0x63, 0x69, 0x61, 0x6f}},
+		{`"ci\u00e0o".encode("utf8")`, []int64{0x63, 0x69, 0xc3, 0xa0, 0x6f}},
+		{`"ci\u00e0o".encode("latin1")`, []int64{0x63, 0x69, 0xe0, 0x6f}},
+		{`"".encode("ascii")`, []int64{}},
+		{`to_string("ci\u00e0o".encode("latin1"), "latin1")`, "ci\u00e0o"},
+		{`"ci\u00e0o".encode("ascii")`, object.RuntimeErrorObj},
+		{`"\u20ac".encode("latin1")`, object.RuntimeErrorObj},
+		{`"ciao".encode("ebcdic")`, object.RuntimeErrorObj},
+		{`"ciao".encode(1)`, object.ErrorObj},
+		{`"ciao".encode()`, object.ErrorObj},
+	}
+
+	for _, testCase := range tests {
+		evalStringBuiltin := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case string:
+			testStringObject(t, evalStringBuiltin, expected)
+		case []int64:
+			testArrayObject(t, testCase.input, evalStringBuiltin, expected)
+		case object.ObjectType:
+			if evalStringBuiltin.Type() != expected {
+				t.Errorf("%s: expected a %s object, got %s", testCase.input, expected, evalStringBuiltin.Type())
+			}
+		}
+	}
+}
+
+func TestHexRepr(t *testing.T) {
+	tests := []struct {
+		input    []byte
+		expected string
+	}{
+		{[]byte{}, "[]"},
+		{[]byte{0x00}, "[0x00]"},
+		{[]byte{0xde, 0xad, 0x0b, 0xef}, "[0xde, 0xad, 0x0b, 0xef]"},
+	}
+
+	for _, testCase := range tests {
+		if repr := hexRepr(testCase.input); repr != testCase.expected {
+			t.Errorf("expected %q, got %q", testCase.expected, repr)
+		}
+	}
+}
+
+func TestConstants(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"MAX_U8", 0xff},
+		{"MAX_U16", 0xffff},
+		{"MAX_U32", 0xffffffff},
+		{"MAX_I64", 0x7fffffffffffffff},
+		{"0x1234 & MAX_U8", 0x34},
+		{"var f = fun(MAX_U8) { ret MAX_U8 }\nf(3)", 3},
+		{"var MAX_U8 = 3", "cannot re-define the MAX_U8 constant on line 1"},
+		{"var f = fun() {\nvar MAX_U32 = 0\n}\nf()", "cannot re-define the MAX_U32 constant on line 2"},
+	}
+
+	for _, testCase := range tests {
+		evaluated := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evaluated, int64(expected))
+		case string:
+			errObj, isErr := evaluated.(*object.Error)
+			if !isErr {
+				t.Errorf("%s: expected an error, got %v", testCase.input, evaluated)
+				continue
+			}
+
+			if errObj.Inspect() != "Error: "+expected {
+				t.Errorf("%s: expected %q, got %q", testCase.input, expected, errObj.Inspect())
+			}
+		}
+	}
+}
+
 func TestTryExpression(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1330,6 +3894,53 @@ func TestTryExpression(t *testing.T) {
 	}
 }
 
+func TestTryFileMethods(t *testing.T) {
+	hexFile := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	if err := os.WriteFile("test_try.hex", []byte(hexFile), 0666); err != nil {
+		t.Fatalf("cannot create the test_try.hex file")
+	}
+	defer func() { _ = os.Remove("test_try.hex") }()
+
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"var h = open(\"test_try.hex\", \"hex\")\ntry h.read_at(0, 4)", object.HexError},
+		{"var h = open(\"test_try.hex\", \"hex\")\nvar r = try h.read_at(0, 4)\n1", object.HexError},
+		{"var h = open(\"test_try.hex\", \"hex\")\nvar f = fun(h) { ret try h.read_at(0, 4) }\nf(h)", object.HexError},
+		{"var h = open(\"test_try.hex\", \"hex\")\ntry h.read_at(-1, 4)", object.TypeError},
+		{"var h = open(\"test_try.hex\", \"hex\")\ntry h.write_at(0, [1])", object.HexError},
+		{"var h = open(\"test_try.hex\", \"hex\")\ntry h.record(100)", object.HexError},
+		{"try hash([1, 2], \"sha3\")", object.TypeError},
+		{"var h = open(\"test_try.hex\", \"hex\")\ntry h.read_at()", nil},
+		{"var h = open(\"test_try.hex\", \"hex\")\nvar r = h.read_at(0, 4)\n1", 1},
+	}
+
+	for _, testCase := range tests {
+		evalTry := testEval(testCase.input)
+		switch expected := testCase.expected.(type) {
+		case int:
+			testIntegerObject(t, testCase.input, evalTry, int64(expected))
+		case object.RuntimeErrorType:
+			runtimeErr, isRuntimeErr := evalTry.(*object.RuntimeError)
+			if !isRuntimeErr {
+				t.Errorf("%s: expected a runtime error, got %s", testCase.input, evalTry.Type())
+				continue
+			}
+			if runtimeErr.Kind != expected {
+				t.Errorf("%s: expected a %s, got %s", testCase.input, expected, runtimeErr.Kind)
+			}
+		case nil:
+			if !isError(evalTry) {
+				t.Errorf("%s: expected a fatal error, got %s", testCase.input, evalTry.Type())
+			}
+		}
+	}
+}
+
 func testEval(input string) object.Object {
 	l := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
 	p := parser.NewParser(l)
@@ -1486,7 +4097,7 @@ func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
 }
 
 func testNullObject(t *testing.T, obj object.Object) bool {
-	if obj != nil {
+	if obj != NULL {
 		t.Errorf("expected null, got %T", obj)
 		return false
 	}
@@ -2026,3 +4637,101 @@ var elfFile = []byte{
 	0x00, 0xa3, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
 	0x00, 0x00,
 }
+
+var peFile = []byte{
+	0x4d, 0x5a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x40, 0x00, 0x00, 0x00, 0x50, 0x45, 0x00, 0x00, 0x4c, 0x01, 0x02, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xe0, 0x00, 0x02, 0x01, 0x0b, 0x01, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00,
+	0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x40, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x30, 0x00, 0x00, 0x90, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x10, 0x00, 0x00,
+	0x00, 0x00, 0x10, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x2e, 0x74, 0x65, 0x78, 0x74, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x90, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x20, 0x00, 0x00, 0x60, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x00, 0x00, 0x00,
+	0x10, 0x00, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00,
+	0xa0, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0xc0, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x55, 0x89, 0xe5, 0x31, 0xc0, 0x5d, 0xc3, 0x90,
+	0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x00, 0x01, 0x02, 0x03,
+	0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+}
+
+var machoFile = []byte{
+	0xcf, 0xfa, 0xed, 0xfe, 0x07, 0x00, 0x00, 0x01, 0x03, 0x00, 0x00, 0x00,
+	0x02, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x48, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x19, 0x00, 0x00, 0x00,
+	0x98, 0x00, 0x00, 0x00, 0x5f, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x5f, 0x5f, 0x74, 0x65,
+	0x78, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x5f, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x70, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+	0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x70, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x04, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x19, 0x00, 0x00, 0x00, 0x98, 0x00, 0x00, 0x00,
+	0x5f, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x03, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x5f, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x5f, 0x5f, 0x44, 0x41,
+	0x54, 0x41, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x28, 0x00, 0x00, 0x80, 0x18, 0x00, 0x00, 0x00, 0x70, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x55, 0x48, 0x89, 0xe5,
+	0x31, 0xc0, 0x5d, 0xc3, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90,
+	0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b,
+	0x0c, 0x0d, 0x0e, 0x0f,
+}
+
+var zipFile = []byte{
+	0x50, 0x4b, 0x03, 0x04, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x21, 0x56, 0x20, 0x30, 0x3a, 0x36, 0x06, 0x00, 0x00, 0x00, 0x06, 0x00,
+	0x00, 0x00, 0x09, 0x00, 0x00, 0x00, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x2e,
+	0x74, 0x78, 0x74, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x0a, 0x50, 0x4b, 0x03,
+	0x04, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x21, 0x56, 0x9f,
+	0x68, 0xaa, 0x88, 0x08, 0x00, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x08,
+	0x00, 0x00, 0x00, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x62, 0x69, 0x6e, 0x00,
+	0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x50, 0x4b, 0x01, 0x02, 0x14,
+	0x03, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x21, 0x56, 0x20,
+	0x30, 0x3a, 0x36, 0x06, 0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0x00, 0x09,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80,
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x2e, 0x74,
+	0x78, 0x74, 0x50, 0x4b, 0x01, 0x02, 0x14, 0x03, 0x14, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x21, 0x56, 0x9f, 0x68, 0xaa, 0x88, 0x08, 0x00,
+	0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x2d, 0x00, 0x00, 0x00,
+	0x64, 0x61, 0x74, 0x61, 0x2e, 0x62, 0x69, 0x6e, 0x50, 0x4b, 0x05, 0x06,
+	0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x02, 0x00, 0x6d, 0x00, 0x00, 0x00,
+	0x5b, 0x00, 0x00, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,26 @@
+package macho
+
+import "fmt"
+
+// FileError identifies an error related to a macho file
+type FileError string
+
+// Error returns a string representation of a FileError
+func (r FileError) Error() string {
+	return string(r)
+}
+
+// CustomError returns FileError that can use the classic fmt message/varargs.
+func CustomError(original FileError, msg string, args ...any) error {
+	nested := fmt.Sprintf(msg, args...)
+	return fmt.Errorf("%w: %s", original, nested)
+}
+
+const (
+	FileOpenErr        = FileError("cannot open the file with the passed file name")
+	NoSuchSectionErr   = FileError("there is no such section in the passed macho file")
+	OutOfBoundsErr     = FileError("attempting to write out of the section bounds")
+	NoSuchSegmentErr   = FileError("there is no such segment in the passed macho file")
+	NoEntryPointErr    = FileError("the passed macho file has no LC_MAIN entry point command")
+	ZerofillSectionErr = FileError("the section is a zerofill one, which has no data in the file")
+)
@@ -0,0 +1,196 @@
+package macho
+
+import (
+	"bytes"
+	"debug/macho"
+	"io"
+)
+
+const (
+	// loadCmdMain is the LC_MAIN load command, which is not exposed by the
+	// debug/macho package
+	loadCmdMain macho.LoadCmd = 0x80000028
+
+	// entryOffset is the offset of the entryoff field within LC_MAIN
+	entryOffset = 0x08
+
+	textSegment = "__TEXT"
+
+	// sectionType masks the type of a section within its flags, and the
+	// zerofill types mark sections that have no data within the file
+	sectionType                = 0xff
+	sectionZerofill            = 0x01
+	sectionGigabyteZerofill    = 0x0c
+	sectionThreadLocalZerofill = 0x12
+)
+
+// File represents the contents of a macho binary file
+type File struct {
+	file  *macho.File
+	bytes []byte
+}
+
+// ReadAll initializes a macho file object from a file stream
+func ReadAll(file io.Reader) (*File, error) {
+	byteData, err := io.ReadAll(file)
+	if err != nil {
+		return nil, FileOpenErr
+	}
+
+	machoFile, err := macho.NewFile(bytes.NewReader(byteData))
+	if err != nil {
+		return nil, FileOpenErr
+	}
+
+	return &File{
+		file:  machoFile,
+		bytes: byteData,
+	}, nil
+}
+
+// AsBytes returns a copy of the file as a byte array representation
+func (mf *File) AsBytes() []byte {
+	buf := make([]byte, len(mf.bytes))
+	copy(buf, mf.bytes)
+	return buf
+}
+
+// HasSection returns whether a macho file has a section named 'name'
+func (mf *File) HasSection(name string) bool {
+	return mf.file.Section(name) != nil
+}
+
+// Sections returns a list of the sections within a macho file
+func (mf *File) Sections() []string {
+	var sections []string
+	for _, section := range mf.file.Sections {
+		sections = append(sections, section.Name)
+	}
+	return sections
+}
+
+// WriteSection writes data at the specified offset within the specified section
+func (mf *File) WriteSection(name string, data []byte, offset uint64) error {
+	if data == nil {
+		data = []byte{}
+	}
+
+	section := mf.file.Section(name)
+	if section == nil {
+		return NoSuchSectionErr
+	}
+
+	if isZerofill(section) {
+		return ZerofillSectionErr
+	}
+
+	dataSize := uint64(len(data))
+	if dataSize+offset > section.Size {
+		return OutOfBoundsErr
+	}
+
+	start := uint64(section.Offset) + offset
+	if start+dataSize > uint64(len(mf.bytes)) {
+		return OutOfBoundsErr
+	}
+	copy(mf.bytes[start:], data)
+	return nil
+}
+
+// ReadSection reads the whole specified macho section
+func (mf *File) ReadSection(name string) ([]byte, error) {
+	section := mf.file.Section(name)
+	if section == nil {
+		return nil, NoSuchSectionErr
+	}
+
+	if isZerofill(section) {
+		return nil, ZerofillSectionErr
+	}
+
+	start := uint64(section.Offset)
+	end := start + section.Size
+	if end > uint64(len(mf.bytes)) {
+		return nil, OutOfBoundsErr
+	}
+
+	contents := make([]byte, section.Size)
+	copy(contents, mf.bytes[start:end])
+	return contents, nil
+}
+
+// SectionAddress returns the address of the section, if it exists
+func (mf *File) SectionAddress(name string) (uint64, error) {
+	section := mf.file.Section(name)
+	if section == nil {
+		return 0, NoSuchSectionErr
+	}
+	return section.Addr, nil
+}
+
+// SectionSize returns the size of the section, if it exists
+func (mf *File) SectionSize(name string) (uint64, error) {
+	section := mf.file.Section(name)
+	if section == nil {
+		return 0, NoSuchSectionErr
+	}
+	return section.Size, nil
+}
+
+// Segments returns a list of the segments within a macho file
+func (mf *File) Segments() []string {
+	var segments []string
+	for _, load := range mf.file.Loads {
+		if segment, isSegment := load.(*macho.Segment); isSegment {
+			segments = append(segments, segment.Name)
+		}
+	}
+	return segments
+}
+
+// SegmentAddress returns the virtual address of the segment, if it exists
+func (mf *File) SegmentAddress(name string) (uint64, error) {
+	segment := mf.file.Segment(name)
+	if segment == nil {
+		return 0, NoSuchSegmentErr
+	}
+	return segment.Addr, nil
+}
+
+// SegmentSize returns the virtual memory size of the segment, if it exists
+func (mf *File) SegmentSize(name string) (uint64, error) {
+	segment := mf.file.Segment(name)
+	if segment == nil {
+		return 0, NoSuchSegmentErr
+	}
+	return segment.Memsz, nil
+}
+
+// Entry returns the address of the entry point, computed as the offset
+// specified by the LC_MAIN command relative to the __TEXT segment address
+func (mf *File) Entry() (uint64, error) {
+	for _, load := range mf.file.Loads {
+		raw := load.Raw()
+		if len(raw) < entryOffset+8 || mf.file.ByteOrder.Uint32(raw) != uint32(loadCmdMain) {
+			continue
+		}
+
+		entry := mf.file.ByteOrder.Uint64(raw[entryOffset:])
+		if text := mf.file.Segment(textSegment); text != nil {
+			entry += text.Addr
+		}
+		return entry, nil
+	}
+	return 0, NoEntryPointErr
+}
+
+// isZerofill returns whether the section is a zerofill one, such as
+// '__bss', which is only allocated in memory and has no data in the file.
+func isZerofill(section *macho.Section) bool {
+	switch section.Flags & sectionType {
+	case sectionZerofill, sectionGigabyteZerofill, sectionThreadLocalZerofill:
+		return true
+	default:
+		return false
+	}
+}
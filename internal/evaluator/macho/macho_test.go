@@ -0,0 +1,251 @@
+package macho
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// This is a minimal hand-crafted 64-bit x86_64 Mach-O executable with a
+// '__TEXT' segment holding a 16 bytes '__text' section, a '__DATA' segment
+// holding a 16 bytes '__data' section (containing the sequence 0x00..0x0f)
+// and an LC_MAIN command pointing to the start of '__text'.
+var machoFile = []byte{
+	0xcf, 0xfa, 0xed, 0xfe, 0x07, 0x00, 0x00, 0x01, 0x03, 0x00, 0x00, 0x00,
+	0x02, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x48, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x19, 0x00, 0x00, 0x00,
+	0x98, 0x00, 0x00, 0x00, 0x5f, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x5f, 0x5f, 0x74, 0x65,
+	0x78, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x5f, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x70, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+	0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x70, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x04, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x19, 0x00, 0x00, 0x00, 0x98, 0x00, 0x00, 0x00,
+	0x5f, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x03, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x5f, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x5f, 0x5f, 0x44, 0x41,
+	0x54, 0x41, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x28, 0x00, 0x00, 0x80, 0x18, 0x00, 0x00, 0x00, 0x70, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x55, 0x48, 0x89, 0xe5,
+	0x31, 0xc0, 0x5d, 0xc3, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90,
+	0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b,
+	0x0c, 0x0d, 0x0e, 0x0f,
+}
+
+func TestReadall(t *testing.T) {
+	var machoNull []byte
+
+	_, err := ReadAll(bytes.NewReader(machoFile))
+	if err != nil {
+		t.Errorf("Unexpected error reading valid macho file")
+	}
+
+	_, err = ReadAll(bytes.NewReader(machoNull))
+	if err == nil {
+		t.Errorf("Expected error reading invalid macho file, got nil")
+	}
+}
+
+func TestFile_Sections(t *testing.T) {
+	expectedSections := []string{"__text", "__data"}
+	expectedSegments := []string{"__TEXT", "__DATA"}
+
+	file, err := ReadAll(bytes.NewReader(machoFile))
+	if err != nil {
+		t.Fatalf("Unexpected error reading valid macho file")
+	}
+
+	sections := file.Sections()
+	if len(sections) != len(expectedSections) {
+		t.Fatalf("expected sections %v, got %v", expectedSections, sections)
+	}
+
+	for idx, section := range sections {
+		if section != expectedSections[idx] || !file.HasSection(section) {
+			t.Errorf("expected sections %v, got %v", expectedSections, sections)
+		}
+	}
+
+	segments := file.Segments()
+	if len(segments) != len(expectedSegments) {
+		t.Fatalf("expected segments %v, got %v", expectedSegments, segments)
+	}
+
+	for idx, segment := range segments {
+		if segment != expectedSegments[idx] {
+			t.Errorf("expected segments %v, got %v", expectedSegments, segments)
+		}
+	}
+
+	if file.HasSection("__bss") {
+		t.Errorf("expected HasSection(__bss) = false, got true")
+	}
+}
+
+func TestFile_ReadSection(t *testing.T) {
+	dataConts := [16]byte{}
+	for idx := range dataConts {
+		dataConts[idx] = byte(idx)
+	}
+
+	tests := []struct {
+		name          string
+		expectedConts []byte
+		expectedErr   error
+	}{
+		{"__random", []byte{}, NoSuchSectionErr},
+		{"__data", dataConts[:], nil},
+	}
+	file, ferr := ReadAll(bytes.NewReader(machoFile))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid macho file")
+	}
+
+	for idx, testCase := range tests {
+		sectionData, err := file.ReadSection(testCase.name)
+		switch testCase.expectedErr {
+		case NoSuchSectionErr:
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expectedErr %v got %v", testCase.expectedErr, err)
+			}
+		case nil:
+			if !bytes.Equal(sectionData, testCase.expectedConts) {
+				t.Errorf("case '%d'\nexpected: %v\ngot %v", idx, testCase.expectedConts, sectionData)
+			}
+		}
+	}
+}
+
+func TestFile_WriteSection(t *testing.T) {
+	array16 := [16]byte{}
+	array20 := [20]byte{}
+
+	tests := []struct {
+		name        string
+		contents    []byte
+		offset      uint64
+		expectedErr error
+	}{
+		{"__random", nil, 0, NoSuchSectionErr},
+		{"__data", array16[:], 0, nil},
+		{"__data", []byte{0xde, 0xad}, 14, nil},
+		{"__data", array16[:], 1, OutOfBoundsErr},
+		{"__text", array20[:], 0, OutOfBoundsErr},
+	}
+	file, ferr := ReadAll(bytes.NewReader(machoFile))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid macho file")
+	}
+
+	for _, testCase := range tests {
+		err := file.WriteSection(testCase.name, testCase.contents, testCase.offset)
+		switch testCase.expectedErr {
+		case NoSuchSectionErr:
+			fallthrough
+		case OutOfBoundsErr:
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expectedErr %v got %v", testCase.expectedErr, err)
+			}
+		case nil:
+			sectionData, _ := file.ReadSection(testCase.name)
+			written := sectionData[testCase.offset : testCase.offset+uint64(len(testCase.contents))]
+			if !bytes.Equal(written, testCase.contents) {
+				t.Errorf("expected section %q to contain %v at %d, got %v",
+					testCase.name, testCase.contents, testCase.offset, written)
+			}
+		}
+	}
+}
+
+// zerofillMachoFile returns a copy of machoFile where the '__data' section
+// is turned into a '__bss' zerofill section, with no data in the file.
+func zerofillMachoFile() []byte {
+	zerofill := make([]byte, len(machoFile))
+	copy(zerofill, machoFile)
+
+	header := bytes.Index(zerofill, []byte("__data"))
+	copy(zerofill[header:], "__bss\x00")
+	binary.LittleEndian.PutUint32(zerofill[header+48:], 0) // offset
+	binary.LittleEndian.PutUint32(zerofill[header+64:], 1) // flags: S_ZEROFILL
+	return zerofill
+}
+
+func TestFile_ZerofillSection(t *testing.T) {
+	file, err := ReadAll(bytes.NewReader(zerofillMachoFile()))
+	if err != nil {
+		t.Fatalf("Unexpected error reading valid macho file: %v", err)
+	}
+
+	if !file.HasSection("__bss") {
+		t.Fatalf("expected HasSection(__bss) = true, got false")
+	}
+
+	if _, err := file.ReadSection("__bss"); !errors.Is(err, ZerofillSectionErr) {
+		t.Errorf("expected err %v reading __bss, got %v", ZerofillSectionErr, err)
+	}
+
+	header := make([]byte, 4)
+	copy(header, file.bytes)
+	if err := file.WriteSection("__bss", []byte{0xde, 0xad, 0xbe, 0xef}, 0); !errors.Is(err, ZerofillSectionErr) {
+		t.Errorf("expected err %v writing __bss, got %v", ZerofillSectionErr, err)
+	}
+
+	if !bytes.Equal(file.bytes[:4], header) {
+		t.Errorf("expected the header to be untouched, got %v", file.bytes[:4])
+	}
+
+	if _, err := file.ReadSection("__text"); err != nil {
+		t.Errorf("unexpected error reading __text: %v", err)
+	}
+}
+
+func TestFile_Addresses(t *testing.T) {
+	file, err := ReadAll(bytes.NewReader(machoFile))
+	if err != nil {
+		t.Fatalf("Unexpected error reading valid macho file")
+	}
+
+	if addr, err := file.SectionAddress("__data"); err != nil || addr != 0x100001000 {
+		t.Errorf("expected __data address 0x100001000, got 0x%x (err: %v)", addr, err)
+	}
+
+	if size, err := file.SectionSize("__text"); err != nil || size != 16 {
+		t.Errorf("expected __text size 16, got %d (err: %v)", size, err)
+	}
+
+	if addr, err := file.SegmentAddress("__TEXT"); err != nil || addr != 0x100000000 {
+		t.Errorf("expected __TEXT address 0x100000000, got 0x%x (err: %v)", addr, err)
+	}
+
+	if size, err := file.SegmentSize("__DATA"); err != nil || size != 0x1000 {
+		t.Errorf("expected __DATA size 0x1000, got 0x%x (err: %v)", size, err)
+	}
+
+	if entry, err := file.Entry(); err != nil || entry != 0x100000170 {
+		t.Errorf("expected entry point 0x100000170, got 0x%x (err: %v)", entry, err)
+	}
+
+	if _, err := file.SectionAddress("__random"); !errors.Is(err, NoSuchSectionErr) {
+		t.Errorf("expectedErr %v got %v", NoSuchSectionErr, err)
+	}
+
+	if _, err := file.SegmentAddress("__RANDOM"); !errors.Is(err, NoSuchSegmentErr) {
+		t.Errorf("expectedErr %v got %v", NoSuchSegmentErr, err)
+	}
+}
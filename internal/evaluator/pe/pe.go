@@ -0,0 +1,114 @@
+package pe
+
+import (
+	"bytes"
+	"debug/pe"
+	"io"
+)
+
+// File represents the contents of a pe (Windows executable) file
+type File struct {
+	file  *pe.File
+	bytes []byte
+}
+
+// ReadAll initializes a pe file object from a file stream
+func ReadAll(file io.Reader) (*File, error) {
+	byteData, err := io.ReadAll(file)
+	if err != nil {
+		return nil, FileOpenErr
+	}
+
+	peFile, err := pe.NewFile(bytes.NewReader(byteData))
+	if err != nil {
+		return nil, FileOpenErr
+	}
+
+	return &File{
+		file:  peFile,
+		bytes: byteData,
+	}, nil
+}
+
+// AsBytes returns a copy of the file as a byte array representation
+func (pf *File) AsBytes() []byte {
+	buf := make([]byte, len(pf.bytes))
+	copy(buf, pf.bytes)
+	return buf
+}
+
+// HasSection returns whether a pe file has a section named 'name'
+func (pf *File) HasSection(name string) bool {
+	return pf.file.Section(name) != nil
+}
+
+// Sections returns a list of the sections within a pe file
+func (pf *File) Sections() []string {
+	var sections []string
+	for _, section := range pf.file.Sections {
+		sections = append(sections, section.Name)
+	}
+	return sections
+}
+
+// WriteSection writes data at the specified offset within the raw data of
+// the specified section
+func (pf *File) WriteSection(name string, data []byte, offset uint64) error {
+	if data == nil {
+		data = []byte{}
+	}
+
+	section := pf.file.Section(name)
+	if section == nil {
+		return NoSuchSectionErr
+	}
+
+	dataSize := uint64(len(data))
+	if dataSize+offset > uint64(section.Size) {
+		return OutOfBoundsErr
+	}
+
+	start := uint64(section.Offset) + offset
+	if start+dataSize > uint64(len(pf.bytes)) {
+		return OutOfBoundsErr
+	}
+	copy(pf.bytes[start:], data)
+	return nil
+}
+
+// ReadSection reads the whole raw data of the specified pe section
+func (pf *File) ReadSection(name string) ([]byte, error) {
+	section := pf.file.Section(name)
+	if section == nil {
+		return nil, NoSuchSectionErr
+	}
+
+	start := uint64(section.Offset)
+	end := start + uint64(section.Size)
+	if end > uint64(len(pf.bytes)) {
+		return nil, OutOfBoundsErr
+	}
+
+	contents := make([]byte, section.Size)
+	copy(contents, pf.bytes[start:end])
+	return contents, nil
+}
+
+// SectionAddress returns the relative virtual address of the section, if it
+// exists
+func (pf *File) SectionAddress(name string) (uint64, error) {
+	section := pf.file.Section(name)
+	if section == nil {
+		return 0, NoSuchSectionErr
+	}
+	return uint64(section.VirtualAddress), nil
+}
+
+// SectionSize returns the size of the raw data of the section, if it exists
+func (pf *File) SectionSize(name string) (uint64, error) {
+	section := pf.file.Section(name)
+	if section == nil {
+		return 0, NoSuchSectionErr
+	}
+	return uint64(section.Size), nil
+}
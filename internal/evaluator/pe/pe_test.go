@@ -0,0 +1,211 @@
+package pe
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// This is a minimal hand-crafted PE32 (i386) image with two 16 bytes
+// sections: '.text', containing a tiny function returning 0 padded with
+// nops, and '.data', containing the sequence 0x00..0x0f.
+var peFile = []byte{
+	0x4d, 0x5a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x40, 0x00, 0x00, 0x00, 0x50, 0x45, 0x00, 0x00, 0x4c, 0x01, 0x02, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xe0, 0x00, 0x02, 0x01, 0x0b, 0x01, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00,
+	0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x40, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x30, 0x00, 0x00, 0x90, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x10, 0x00, 0x00,
+	0x00, 0x00, 0x10, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x2e, 0x74, 0x65, 0x78, 0x74, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00,
+	0x00, 0x10, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x90, 0x01, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x20, 0x00, 0x00, 0x60, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x00, 0x00, 0x00,
+	0x10, 0x00, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00,
+	0xa0, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0xc0, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x55, 0x89, 0xe5, 0x31, 0xc0, 0x5d, 0xc3, 0x90,
+	0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x90, 0x00, 0x01, 0x02, 0x03,
+	0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+}
+
+func TestReadall(t *testing.T) {
+	var peNull []byte
+
+	_, err := ReadAll(bytes.NewReader(peFile))
+	if err != nil {
+		t.Errorf("Unexpected error reading valid pe file")
+	}
+
+	_, err = ReadAll(bytes.NewReader(peNull))
+	if err == nil {
+		t.Errorf("Expected error reading invalid pe file, got nil")
+	}
+}
+
+func TestFile_Sections(t *testing.T) {
+	expected := []string{".text", ".data"}
+
+	file, err := ReadAll(bytes.NewReader(peFile))
+	if err != nil {
+		t.Fatalf("Unexpected error reading valid pe file")
+	}
+
+	sections := file.Sections()
+	if len(sections) != len(expected) {
+		t.Fatalf("expected sections %v, got %v", expected, sections)
+	}
+
+	for idx, section := range sections {
+		if section != expected[idx] {
+			t.Errorf("expected sections %v, got %v", expected, sections)
+		}
+	}
+}
+
+func TestFile_HasSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{".text", true},
+		{".data", true},
+		{".rdata", false},
+	}
+	file, err := ReadAll(bytes.NewReader(peFile))
+	if err != nil {
+		t.Errorf("Unexpected error reading valid pe file")
+	}
+
+	for _, testCase := range tests {
+		if file.HasSection(testCase.name) != testCase.expected {
+			t.Errorf("expectedErr HasSection(%s) = %t, got %t", testCase.name, testCase.expected, !testCase.expected)
+		}
+	}
+}
+
+func TestFile_ReadSection(t *testing.T) {
+	dataConts := [16]byte{}
+	for idx := range dataConts {
+		dataConts[idx] = byte(idx)
+	}
+
+	tests := []struct {
+		name          string
+		expectedConts []byte
+		expectedErr   error
+	}{
+		{".random", []byte{}, NoSuchSectionErr},
+		{".data", dataConts[:], nil},
+	}
+	file, ferr := ReadAll(bytes.NewReader(peFile))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid pe file")
+	}
+
+	for idx, testCase := range tests {
+		sectionData, err := file.ReadSection(testCase.name)
+		switch testCase.expectedErr {
+		case NoSuchSectionErr:
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expectedErr %v got %v", testCase.expectedErr, err)
+			}
+		case nil:
+			if !bytes.Equal(sectionData, testCase.expectedConts) {
+				t.Errorf("case '%d'\nexpected: %v\ngot %v", idx, testCase.expectedConts, sectionData)
+			}
+		}
+	}
+}
+
+func TestFile_WriteSection(t *testing.T) {
+	array16 := [16]byte{}
+	array20 := [20]byte{}
+
+	tests := []struct {
+		name        string
+		contents    []byte
+		offset      uint64
+		expectedErr error
+	}{
+		{".random", nil, 0, NoSuchSectionErr},
+		{".random", []byte{}, 0, NoSuchSectionErr},
+		{".data", array16[:], 0, nil},
+		{".data", []byte{0xde, 0xad}, 14, nil},
+		{".data", array16[:], 1, OutOfBoundsErr},
+		{".text", array20[:], 0, OutOfBoundsErr},
+	}
+	file, ferr := ReadAll(bytes.NewReader(peFile))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid pe file")
+	}
+
+	for _, testCase := range tests {
+		err := file.WriteSection(testCase.name, testCase.contents, testCase.offset)
+		switch testCase.expectedErr {
+		case NoSuchSectionErr:
+			fallthrough
+		case OutOfBoundsErr:
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expectedErr %v got %v", testCase.expectedErr, err)
+			}
+		case nil:
+			sectionData, _ := file.ReadSection(testCase.name)
+			written := sectionData[testCase.offset : testCase.offset+uint64(len(testCase.contents))]
+			if !bytes.Equal(written, testCase.contents) {
+				t.Errorf("expected section %q to contain %v at %d, got %v",
+					testCase.name, testCase.contents, testCase.offset, written)
+			}
+		}
+	}
+}
+
+func TestFile_SectionAddress(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectedAddr uint64
+		expectedSize uint64
+		expectedErr  error
+	}{
+		{".text", 0x1000, 16, nil},
+		{".data", 0x2000, 16, nil},
+		{".random", 0, 0, NoSuchSectionErr},
+	}
+	file, ferr := ReadAll(bytes.NewReader(peFile))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid pe file")
+	}
+
+	for _, testCase := range tests {
+		addr, err := file.SectionAddress(testCase.name)
+		if !errors.Is(err, testCase.expectedErr) || addr != testCase.expectedAddr {
+			t.Errorf("%s: expected address 0x%x (err: %v), got 0x%x (err: %v)",
+				testCase.name, testCase.expectedAddr, testCase.expectedErr, addr, err)
+		}
+
+		size, err := file.SectionSize(testCase.name)
+		if !errors.Is(err, testCase.expectedErr) || size != testCase.expectedSize {
+			t.Errorf("%s: expected size %d (err: %v), got %d (err: %v)",
+				testCase.name, testCase.expectedSize, testCase.expectedErr, size, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package tar
+
+import "fmt"
+
+// FileError identifies an error related to a tar file
+type FileError string
+
+// Error returns a string representation of a FileError
+func (r FileError) Error() string {
+	return string(r)
+}
+
+// CustomError returns FileError that can use the classic fmt message/varargs.
+func CustomError(original FileError, msg string, args ...any) error {
+	nested := fmt.Sprintf(msg, args...)
+	return fmt.Errorf("%w: %s", original, nested)
+}
+
+const (
+	FileOpenErr       = FileError("cannot open the file with the passed file name")
+	NoSuchEntryErr    = FileError("there is no such entry in the passed tar file")
+	DuplicateEntryErr = FileError("the passed tar file already contains an entry with this name")
+	EntryWriteErr     = FileError("cannot write the entry to the tar file")
+	InvalidModeErr    = FileError("the entry mode must be a valid permission mask")
+)
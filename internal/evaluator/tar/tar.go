@@ -0,0 +1,144 @@
+package tar
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// maxMode is the largest permission mask that an entry can have
+const maxMode = 0o7777
+
+type entry struct {
+	header *tar.Header
+	data   []byte
+}
+
+// File represents the contents of a tar archive
+type File struct {
+	entries []entry
+	bytes   []byte
+}
+
+// ReadAll initializes a tar file object from a file stream
+func ReadAll(file io.Reader) (*File, error) {
+	byteData, err := io.ReadAll(file)
+	if err != nil || len(byteData) == 0 {
+		return nil, FileOpenErr
+	}
+
+	var entries []entry
+	reader := tar.NewReader(bytes.NewReader(byteData))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, FileOpenErr
+		}
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, FileOpenErr
+		}
+		entries = append(entries, entry{header: header, data: data})
+	}
+
+	return &File{
+		entries: entries,
+		bytes:   byteData,
+	}, nil
+}
+
+// AsBytes returns a copy of the file as a byte array representation
+func (tf *File) AsBytes() []byte {
+	buf := make([]byte, len(tf.bytes))
+	copy(buf, tf.bytes)
+	return buf
+}
+
+// HasEntry returns whether a tar file has an entry named 'name'
+func (tf *File) HasEntry(name string) bool {
+	return tf.entry(name) != nil
+}
+
+// Entries returns a list of the names of the entries within a tar file
+func (tf *File) Entries() []string {
+	var entries []string
+	for _, entry := range tf.entries {
+		entries = append(entries, entry.header.Name)
+	}
+	return entries
+}
+
+// ReadEntry reads the whole contents of the specified entry
+func (tf *File) ReadEntry(name string) ([]byte, error) {
+	entry := tf.entry(name)
+	if entry == nil {
+		return nil, NoSuchEntryErr
+	}
+
+	contents := make([]byte, len(entry.data))
+	copy(contents, entry.data)
+	return contents, nil
+}
+
+// AddEntry appends a new regular file entry with the passed name, contents
+// and permission mode to the tar file. The archive is re-encoded, with the
+// pre-existing entries kept in their original order.
+func (tf *File) AddEntry(name string, data []byte, mode int64) error {
+	if tf.HasEntry(name) {
+		return DuplicateEntryErr
+	}
+
+	if mode < 0 || mode > maxMode {
+		return InvalidModeErr
+	}
+
+	if data == nil {
+		data = []byte{}
+	}
+
+	newEntry := entry{
+		header: &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     mode,
+			Size:     int64(len(data)),
+		},
+		data: data,
+	}
+	entries := append(tf.entries[:len(tf.entries):len(tf.entries)], newEntry)
+
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		if err := writer.WriteHeader(entry.header); err != nil {
+			return EntryWriteErr
+		}
+
+		if _, err := writer.Write(entry.data); err != nil {
+			return EntryWriteErr
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return EntryWriteErr
+	}
+
+	tf.entries = entries
+	tf.bytes = buf.Bytes()
+	return nil
+}
+
+func (tf *File) entry(name string) *entry {
+	for idx := range tf.entries {
+		if tf.entries[idx].header.Name == name {
+			return &tf.entries[idx]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package tar
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// newTarFixture builds a tar archive containing two regular files:
+// 'hello.txt', containing the "hello\n" string, and 'data/data.bin',
+// containing the sequence 0x00..0x07.
+func newTarFixture(t *testing.T) []byte {
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{"hello.txt", []byte("hello\n")},
+		{"data/data.bin", []byte{0, 1, 2, 3, 4, 5, 6, 7}},
+	}
+
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     entry.name,
+			Mode:     0o644,
+			Size:     int64(len(entry.data)),
+		}
+
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatalf("cannot write the tar fixture header: %v", err)
+		}
+
+		if _, err := writer.Write(entry.data); err != nil {
+			t.Fatalf("cannot write the tar fixture data: %v", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("cannot close the tar fixture: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadall(t *testing.T) {
+	var tarNull []byte
+
+	_, err := ReadAll(bytes.NewReader(newTarFixture(t)))
+	if err != nil {
+		t.Errorf("Unexpected error reading valid tar file")
+	}
+
+	_, err = ReadAll(bytes.NewReader(tarNull))
+	if err == nil {
+		t.Errorf("Expected error reading invalid tar file, got nil")
+	}
+
+	_, err = ReadAll(bytes.NewReader(bytes.Repeat([]byte{0xff}, 1024)))
+	if err == nil {
+		t.Errorf("Expected error reading invalid tar file, got nil")
+	}
+}
+
+func TestFile_Entries(t *testing.T) {
+	expected := []string{"hello.txt", "data/data.bin"}
+
+	file, err := ReadAll(bytes.NewReader(newTarFixture(t)))
+	if err != nil {
+		t.Fatalf("Unexpected error reading valid tar file")
+	}
+
+	entries := file.Entries()
+	if len(entries) != len(expected) {
+		t.Fatalf("expected entries %v, got %v", expected, entries)
+	}
+
+	for idx, entry := range entries {
+		if entry != expected[idx] || !file.HasEntry(entry) {
+			t.Errorf("expected entries %v, got %v", expected, entries)
+		}
+	}
+
+	if file.HasEntry("other.txt") {
+		t.Errorf("expected HasEntry(other.txt) = false, got true")
+	}
+}
+
+func TestFile_ReadEntry(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectedConts []byte
+		expectedErr   error
+	}{
+		{"hello.txt", []byte("hello\n"), nil},
+		{"data/data.bin", []byte{0, 1, 2, 3, 4, 5, 6, 7}, nil},
+		{"other.txt", nil, NoSuchEntryErr},
+	}
+	file, ferr := ReadAll(bytes.NewReader(newTarFixture(t)))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid tar file")
+	}
+
+	for _, testCase := range tests {
+		entryData, err := file.ReadEntry(testCase.name)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Errorf("expectedErr %v got %v", testCase.expectedErr, err)
+		}
+
+		if !bytes.Equal(entryData, testCase.expectedConts) {
+			t.Errorf("%s: expected %v, got %v", testCase.name, testCase.expectedConts, entryData)
+		}
+	}
+}
+
+func TestFile_AddEntry(t *testing.T) {
+	contents := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	tests := []struct {
+		name        string
+		contents    []byte
+		mode        int64
+		expectedErr error
+	}{
+		{"hello.txt", contents, 0o644, DuplicateEntryErr},
+		{"added.bin", contents, 0o755, nil},
+		{"added.bin", contents, 0o755, DuplicateEntryErr},
+		{"invalid.bin", contents, -1, InvalidModeErr},
+		{"invalid.bin", contents, 0o10000, InvalidModeErr},
+		{"empty.bin", nil, 0o600, nil},
+	}
+	file, ferr := ReadAll(bytes.NewReader(newTarFixture(t)))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid tar file")
+	}
+
+	for _, testCase := range tests {
+		err := file.AddEntry(testCase.name, testCase.contents, testCase.mode)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Errorf("expectedErr %v got %v", testCase.expectedErr, err)
+		}
+	}
+
+	reread, err := ReadAll(bytes.NewReader(file.AsBytes()))
+	if err != nil {
+		t.Fatalf("unexpected error re-reading the modified tar file: %v", err)
+	}
+
+	expected := []string{"hello.txt", "data/data.bin", "added.bin", "empty.bin"}
+	entries := reread.Entries()
+	if len(entries) != len(expected) {
+		t.Fatalf("expected entries %v, got %v", expected, entries)
+	}
+
+	for name, expected := range map[string][]byte{"hello.txt": []byte("hello\n"), "added.bin": contents} {
+		entryData, err := reread.ReadEntry(name)
+		if err != nil || !bytes.Equal(entryData, expected) {
+			t.Errorf("%s: expected %v, got %v (err: %v)", name, expected, entryData, err)
+		}
+	}
+
+	if mode := reread.entry("added.bin").header.Mode; mode != 0o755 {
+		t.Errorf("expected added.bin to have mode 0755, got %o", mode)
+	}
+}
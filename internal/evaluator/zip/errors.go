@@ -0,0 +1,25 @@
+package zip
+
+import "fmt"
+
+// FileError identifies an error related to a zip file
+type FileError string
+
+// Error returns a string representation of a FileError
+func (r FileError) Error() string {
+	return string(r)
+}
+
+// CustomError returns FileError that can use the classic fmt message/varargs.
+func CustomError(original FileError, msg string, args ...any) error {
+	nested := fmt.Sprintf(msg, args...)
+	return fmt.Errorf("%w: %s", original, nested)
+}
+
+const (
+	FileOpenErr       = FileError("cannot open the file with the passed file name")
+	NoSuchEntryErr    = FileError("there is no such entry in the passed zip file")
+	DuplicateEntryErr = FileError("the passed zip file already contains an entry with this name")
+	EntryReadErr      = FileError("cannot read the contents of the entry")
+	EntryWriteErr     = FileError("cannot write the entry to the zip file")
+)
@@ -0,0 +1,166 @@
+package zip
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+const (
+	// eocdLen is the length of the end of central directory record, without
+	// the trailing comment, and eocdDirSize and eocdDirOffset are the offsets
+	// of the size and offset of the central directory within it
+	eocdLen       = 22
+	eocdDirSize   = 12
+	eocdDirOffset = 16
+)
+
+var eocdSignature = []byte{0x50, 0x4b, 0x05, 0x06}
+
+// File represents the contents of a zip archive
+type File struct {
+	reader *zip.Reader
+	bytes  []byte
+}
+
+// ReadAll initializes a zip file object from a file stream
+func ReadAll(file io.Reader) (*File, error) {
+	byteData, err := io.ReadAll(file)
+	if err != nil {
+		return nil, FileOpenErr
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(byteData), int64(len(byteData)))
+	if err != nil {
+		return nil, FileOpenErr
+	}
+
+	return &File{
+		reader: reader,
+		bytes:  byteData,
+	}, nil
+}
+
+// AsBytes returns a copy of the file as a byte array representation
+func (zf *File) AsBytes() []byte {
+	buf := make([]byte, len(zf.bytes))
+	copy(buf, zf.bytes)
+	return buf
+}
+
+// HasEntry returns whether a zip file has an entry named 'name'
+func (zf *File) HasEntry(name string) bool {
+	return zf.entry(name) != nil
+}
+
+// Entries returns a list of the names of the entries within a zip file
+func (zf *File) Entries() []string {
+	var entries []string
+	for _, entry := range zf.reader.File {
+		entries = append(entries, entry.Name)
+	}
+	return entries
+}
+
+// ReadEntry reads the whole uncompressed contents of the specified entry
+func (zf *File) ReadEntry(name string) ([]byte, error) {
+	entry := zf.entry(name)
+	if entry == nil {
+		return nil, NoSuchEntryErr
+	}
+
+	entryReader, err := entry.Open()
+	if err != nil {
+		return nil, EntryReadErr
+	}
+	defer func() { _ = entryReader.Close() }()
+
+	contents, err := io.ReadAll(entryReader)
+	if err != nil {
+		return nil, EntryReadErr
+	}
+	return contents, nil
+}
+
+// AddEntry appends a new deflate-compressed entry with the passed name and
+// contents to the zip file. The pre-existing entries are copied over as they
+// are, without being recompressed.
+func (zf *File) AddEntry(name string, data []byte) error {
+	if zf.HasEntry(name) {
+		return DuplicateEntryErr
+	}
+
+	// the data preceding the archive, e.g. the executable of a
+	// self-extracting archive, is kept as it is, as the comment is
+	prefix := zf.bytes[:archiveOffset(zf.bytes)]
+
+	var buf bytes.Buffer
+	buf.Write(prefix)
+
+	writer := zip.NewWriter(&buf)
+	writer.SetOffset(int64(len(prefix)))
+	if err := writer.SetComment(zf.reader.Comment); err != nil {
+		return EntryWriteErr
+	}
+
+	for _, entry := range zf.reader.File {
+		if err := writer.Copy(entry); err != nil {
+			return EntryWriteErr
+		}
+	}
+
+	entryWriter, err := writer.CreateHeader(&zip.FileHeader{
+		Name:   name,
+		Method: zip.Deflate,
+	})
+	if err != nil {
+		return EntryWriteErr
+	}
+
+	if _, err := entryWriter.Write(data); err != nil {
+		return EntryWriteErr
+	}
+
+	if err := writer.Close(); err != nil {
+		return EntryWriteErr
+	}
+
+	newBytes := buf.Bytes()
+	reader, err := zip.NewReader(bytes.NewReader(newBytes), int64(len(newBytes)))
+	if err != nil {
+		return EntryWriteErr
+	}
+
+	zf.reader = reader
+	zf.bytes = newBytes
+	return nil
+}
+
+// archiveOffset returns the offset where the archive starts within data,
+// which is not zero when the archive is appended to other data. The offset
+// is computed from the end of central directory record, as archive/zip does,
+// and is zero if the record is missing or uses the zip64 format.
+func archiveOffset(data []byte) int {
+	eocd := bytes.LastIndex(data, eocdSignature)
+	if eocd < 0 || len(data)-eocd < eocdLen {
+		return 0
+	}
+
+	dirSize := int64(binary.LittleEndian.Uint32(data[eocd+eocdDirSize:]))
+	dirOffset := int64(binary.LittleEndian.Uint32(data[eocd+eocdDirOffset:]))
+	offset := int64(eocd) - dirSize - dirOffset
+	if offset < 0 {
+		return 0
+	}
+	return int(offset)
+}
+
+func (zf *File) entry(name string) *zip.File {
+	for _, entry := range zf.reader.File {
+		if entry.Name == name {
+			return entry
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package zip
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// This is a zip archive containing two stored (uncompressed) entries:
+// 'hello.txt', containing the "hello\n" string, and 'data.bin', containing
+// the sequence 0x00..0x07.
+var zipFile = []byte{
+	0x50, 0x4b, 0x03, 0x04, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x21, 0x56, 0x20, 0x30, 0x3a, 0x36, 0x06, 0x00, 0x00, 0x00, 0x06, 0x00,
+	0x00, 0x00, 0x09, 0x00, 0x00, 0x00, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x2e,
+	0x74, 0x78, 0x74, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x0a, 0x50, 0x4b, 0x03,
+	0x04, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x21, 0x56, 0x9f,
+	0x68, 0xaa, 0x88, 0x08, 0x00, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x08,
+	0x00, 0x00, 0x00, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x62, 0x69, 0x6e, 0x00,
+	0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x50, 0x4b, 0x01, 0x02, 0x14,
+	0x03, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x21, 0x56, 0x20,
+	0x30, 0x3a, 0x36, 0x06, 0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0x00, 0x09,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80,
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x2e, 0x74,
+	0x78, 0x74, 0x50, 0x4b, 0x01, 0x02, 0x14, 0x03, 0x14, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x21, 0x56, 0x9f, 0x68, 0xaa, 0x88, 0x08, 0x00,
+	0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x2d, 0x00, 0x00, 0x00,
+	0x64, 0x61, 0x74, 0x61, 0x2e, 0x62, 0x69, 0x6e, 0x50, 0x4b, 0x05, 0x06,
+	0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x02, 0x00, 0x6d, 0x00, 0x00, 0x00,
+	0x5b, 0x00, 0x00, 0x00, 0x00, 0x00,
+}
+
+func TestReadall(t *testing.T) {
+	var zipNull []byte
+
+	_, err := ReadAll(bytes.NewReader(zipFile))
+	if err != nil {
+		t.Errorf("Unexpected error reading valid zip file")
+	}
+
+	_, err = ReadAll(bytes.NewReader(zipNull))
+	if err == nil {
+		t.Errorf("Expected error reading invalid zip file, got nil")
+	}
+}
+
+func TestFile_Entries(t *testing.T) {
+	expected := []string{"hello.txt", "data.bin"}
+
+	file, err := ReadAll(bytes.NewReader(zipFile))
+	if err != nil {
+		t.Fatalf("Unexpected error reading valid zip file")
+	}
+
+	entries := file.Entries()
+	if len(entries) != len(expected) {
+		t.Fatalf("expected entries %v, got %v", expected, entries)
+	}
+
+	for idx, entry := range entries {
+		if entry != expected[idx] || !file.HasEntry(entry) {
+			t.Errorf("expected entries %v, got %v", expected, entries)
+		}
+	}
+
+	if file.HasEntry("other.txt") {
+		t.Errorf("expected HasEntry(other.txt) = false, got true")
+	}
+}
+
+func TestFile_ReadEntry(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectedConts []byte
+		expectedErr   error
+	}{
+		{"hello.txt", []byte("hello\n"), nil},
+		{"data.bin", []byte{0, 1, 2, 3, 4, 5, 6, 7}, nil},
+		{"other.txt", nil, NoSuchEntryErr},
+	}
+	file, ferr := ReadAll(bytes.NewReader(zipFile))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid zip file")
+	}
+
+	for _, testCase := range tests {
+		entryData, err := file.ReadEntry(testCase.name)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Errorf("expectedErr %v got %v", testCase.expectedErr, err)
+		}
+
+		if !bytes.Equal(entryData, testCase.expectedConts) {
+			t.Errorf("%s: expected %v, got %v", testCase.name, testCase.expectedConts, entryData)
+		}
+	}
+}
+
+func TestFile_AddEntry(t *testing.T) {
+	contents := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	tests := []struct {
+		name        string
+		contents    []byte
+		expectedErr error
+	}{
+		{"hello.txt", contents, DuplicateEntryErr},
+		{"added.bin", contents, nil},
+		{"added.bin", contents, DuplicateEntryErr},
+		{"dir/empty.bin", nil, nil},
+	}
+	file, ferr := ReadAll(bytes.NewReader(zipFile))
+	if ferr != nil {
+		t.Errorf("Unexpected error reading valid zip file")
+	}
+
+	for _, testCase := range tests {
+		err := file.AddEntry(testCase.name, testCase.contents)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Errorf("expectedErr %v got %v", testCase.expectedErr, err)
+		}
+	}
+
+	reread, err := ReadAll(bytes.NewReader(file.AsBytes()))
+	if err != nil {
+		t.Fatalf("unexpected error re-reading the modified zip file: %v", err)
+	}
+
+	for _, entry := range []string{"hello.txt", "data.bin", "added.bin", "dir/empty.bin"} {
+		if !reread.HasEntry(entry) {
+			t.Errorf("expected the modified zip file to have the %q entry", entry)
+		}
+	}
+
+	for name, expected := range map[string][]byte{"hello.txt": []byte("hello\n"), "added.bin": contents} {
+		entryData, err := reread.ReadEntry(name)
+		if err != nil || !bytes.Equal(entryData, expected) {
+			t.Errorf("%s: expected %v, got %v (err: %v)", name, expected, entryData, err)
+		}
+	}
+}
+
+func TestFile_AddEntryKeepsPrefixAndComment(t *testing.T) {
+	prefix := []byte("\x7fELF self-extracting stub")
+	comment := "archive comment"
+
+	// the fixture ends with an empty comment, whose length is replaced
+	prefixed := append([]byte{}, prefix...)
+	prefixed = append(prefixed, zipFile[:len(zipFile)-2]...)
+	prefixed = append(prefixed, byte(len(comment)), 0x00)
+	prefixed = append(prefixed, comment...)
+
+	file, err := ReadAll(bytes.NewReader(prefixed))
+	if err != nil {
+		t.Fatalf("unexpected error reading the prefixed zip file: %v", err)
+	}
+
+	if err := file.AddEntry("added.bin", []byte{0xde, 0xad}); err != nil {
+		t.Fatalf("unexpected error adding an entry: %v", err)
+	}
+
+	modified := file.AsBytes()
+	if !bytes.HasPrefix(modified, prefix) {
+		t.Errorf("expected the modified zip file to start with %q, got %q", prefix, modified[:len(prefix)])
+	}
+
+	if !bytes.HasSuffix(modified, []byte(comment)) || file.reader.Comment != comment {
+		t.Errorf("expected the modified zip file to keep the %q comment, got %q", comment, file.reader.Comment)
+	}
+
+	reread, err := ReadAll(bytes.NewReader(modified))
+	if err != nil {
+		t.Fatalf("unexpected error re-reading the modified zip file: %v", err)
+	}
+
+	for name, expected := range map[string][]byte{"hello.txt": []byte("hello\n"), "added.bin": {0xde, 0xad}} {
+		entryData, err := reread.ReadEntry(name)
+		if err != nil || !bytes.Equal(entryData, expected) {
+			t.Errorf("%s: expected %v, got %v (err: %v)", name, expected, entryData, err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"fmt"
 	"io"
 	"strconv"
 	"strings"
@@ -10,13 +11,24 @@ import (
 )
 
 type Lexer struct {
-	input io.RuneScanner
-	char  rune
-	line  int
+	input    io.RuneScanner
+	char     rune
+	line     int
+	column   int
+	comments int
+
+	// tokenColumn is the column the last token starts at
+	tokenColumn int
 }
 
 func NewLexer(input io.RuneScanner) *Lexer {
-	l := &Lexer{input: input, line: 1}
+	return NewLexerAtLine(input, 1)
+}
+
+// NewLexerAtLine returns a lexer whose line count starts from the passed
+// line, used for sources that are embedded within a larger program.
+func NewLexerAtLine(input io.RuneScanner, line int) *Lexer {
+	l := &Lexer{input: input, line: line}
 	l.readRune()
 	return l
 }
@@ -24,6 +36,7 @@ func NewLexer(input io.RuneScanner) *Lexer {
 func (lexer *Lexer) NextToken() token.Token {
 	var t token.Token
 	lexer.skipWhitespace()
+	lexer.tokenColumn = lexer.column
 
 	switch lexer.char {
 	case '=':
@@ -35,7 +48,17 @@ func (lexer *Lexer) NextToken() token.Token {
 	case '\'':
 		fallthrough
 	case '"':
-		str, err := lexer.readString()
+		str, parts, err := lexer.readString()
+		if err != nil {
+			return token.Token{Type: token.ILLEGAL, Literal: err.Error()}
+		}
+		if parts != nil {
+			t = token.Token{Type: token.INTERP, Literal: str, Parts: parts}
+		} else {
+			t = token.Token{Type: token.STR, Literal: str}
+		}
+	case '`':
+		str, err := lexer.readRawString()
 		if err != nil {
 			return token.Token{Type: token.ILLEGAL, Literal: err.Error()}
 		}
@@ -85,6 +108,12 @@ func (lexer *Lexer) NextToken() token.Token {
 		} else {
 			t = token.Token{Type: token.OR, Literal: string(lexer.char)}
 		}
+	case '?':
+		if lexer.peekRune() == '?' {
+			t = token.Token{Type: token.COALESCE, Literal: lexer.buildTwoRuneOperator()}
+		} else {
+			t = token.Token{Type: token.ILLEGAL, Literal: string(lexer.char)}
+		}
 	case '^':
 		t = token.Token{Type: token.XOR, Literal: string(lexer.char)}
 	case '&':
@@ -103,6 +132,7 @@ func (lexer *Lexer) NextToken() token.Token {
 		t = token.Token{Type: token.COLON, Literal: string(lexer.char)}
 	case '\n':
 		lexer.line++
+		lexer.tokenColumn = 0 // the line count already refers to the next line
 		t = token.Token{Type: token.NEWLINE, Literal: string(lexer.char)}
 	case '(':
 		t = token.Token{Type: token.LPAREN, Literal: string(lexer.char)}
@@ -136,10 +166,22 @@ func (lexer *Lexer) NextToken() token.Token {
 	return t
 }
 
+// Comments returns the number of comments skipped so far, which
+// do not produce any token.
+func (lexer *Lexer) Comments() int {
+	return lexer.comments
+}
+
 func (lexer *Lexer) GetLineNumber() int {
 	return lexer.line
 }
 
+// GetColumnNumber returns the column, counted in runes starting from 1,
+// of the last token read, or 0 if it was a newline.
+func (lexer *Lexer) GetColumnNumber() int {
+	return lexer.tokenColumn
+}
+
 func (lexer *Lexer) readIdentifier() string {
 	var buf strings.Builder
 	for unicode.IsLetter(lexer.char) || unicode.IsDigit(lexer.char) || lexer.char == '_' {
@@ -175,6 +217,11 @@ func (lexer *Lexer) readHexNumber() string {
 }
 
 func (lexer *Lexer) readRune() {
+	if lexer.char == '\n' {
+		lexer.column = 0
+	}
+	lexer.column++
+
 	if r, _, err := lexer.input.ReadRune(); err == nil {
 		lexer.char = r
 		return
@@ -191,23 +238,101 @@ func (lexer *Lexer) peekRune() rune {
 	return 0
 }
 
-func (lexer *Lexer) readString() (string, error) {
+// readString reads a quoted string, processing its escape sequences.
+// Double-quoted strings can embed ${expr} expressions: in that case, the
+// string is also returned split in parts, as described in token.Token.
+func (lexer *Lexer) readString() (string, []string, error) {
 	var buf strings.Builder
+	var text strings.Builder
+	var parts []string
 	quoteType := lexer.char
 	lexer.readRune()
 	for ; lexer.char != quoteType && lexer.char != 0; lexer.readRune() {
 		if lexer.char == '\\' {
 			esc, err := lexer.readEscapeChar()
 			if err != nil {
-				return "", err
+				return "", nil, err
 			}
 			buf.WriteRune(esc)
+			text.WriteRune(esc)
+			continue
+		}
+
+		if quoteType == '"' && lexer.char == '$' && lexer.peekRune() == '{' {
+			expression, err := lexer.readInterpolation()
+			if err != nil {
+				return "", nil, err
+			}
+			parts = append(parts, text.String(), expression)
+			text.Reset()
+			buf.WriteString("${" + expression + "}")
 			continue
 		}
 		buf.WriteRune(lexer.char)
+		text.WriteRune(lexer.char)
+	}
+	if lexer.char == 0 {
+		return "", nil, invalidString
+	}
+
+	if parts != nil {
+		parts = append(parts, text.String())
+	}
+	return buf.String(), parts, nil
+}
+
+// readInterpolation reads the source of an expression embedded in a
+// string as ${expr}, up to its matching closing brace.
+func (lexer *Lexer) readInterpolation() (string, error) {
+	var buf strings.Builder
+	lexer.readRune()
+	depth := 1
+	for lexer.readRune(); lexer.char != 0; lexer.readRune() {
+		switch lexer.char {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				if strings.TrimSpace(buf.String()) == "" {
+					return "", emptyInterpolation
+				}
+				return buf.String(), nil
+			}
+		case '"', '\'', '`':
+			// strings within the expression are copied verbatim, so
+			// that the braces they contain are not counted
+			quoteType := lexer.char
+			buf.WriteRune(lexer.char)
+			for lexer.readRune(); lexer.char != quoteType && lexer.char != 0; lexer.readRune() {
+				if lexer.char == '\\' && quoteType != '`' {
+					buf.WriteRune(lexer.char)
+					lexer.readRune()
+				}
+				buf.WriteRune(lexer.char)
+			}
+			if lexer.char == 0 {
+				return "", invalidInterpolation
+			}
+		}
+		buf.WriteRune(lexer.char)
+	}
+	return "", invalidInterpolation
+}
+
+// readRawString reads a backtick-delimited string, which can span
+// multiple lines and is not subject to escape processing.
+func (lexer *Lexer) readRawString() (string, error) {
+	var buf strings.Builder
+	lexer.readRune()
+	for ; lexer.char != '`' && lexer.char != 0; lexer.readRune() {
+		if lexer.char == '\n' {
+			lexer.line++
+		}
+		buf.WriteRune(lexer.char)
 	}
 	if lexer.char == 0 {
-		return "", invalidString
+		return "", invalidRaw
 	}
 	return buf.String(), nil
 }
@@ -219,6 +344,7 @@ func (lexer *Lexer) skipWhitespace() {
 }
 
 func (lexer *Lexer) skipComment() {
+	lexer.comments++
 	for lexer.char != '\n' && lexer.char != 0 {
 		lexer.readRune()
 	}
@@ -237,12 +363,24 @@ func (lexer *Lexer) readEscapeChar() (rune, error) {
 	switch lexer.char {
 	case '\\':
 		return '\\', nil
+	case '$':
+		return '$', nil
 	case 't':
 		return '\t', nil
 	case 'n':
 		return '\n', nil
 	case 'r':
 		return '\r', nil
+	case '0':
+		return 0, nil
+	case 'a':
+		return '\a', nil
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'v':
+		return '\v', nil
 	case 'x', 'X':
 		hex := make([]rune, 2, 2)
 		for idx := range hex {
@@ -266,7 +404,7 @@ func (lexer *Lexer) readEscapeChar() (rune, error) {
 		val, _ := strconv.ParseInt(string(uni), 16, 64)
 		return rune(val), nil
 	default:
-		return 0, invalidEsc
+		return 0, fmt.Errorf("%w: \\%c", invalidEsc, lexer.char)
 	}
 }
 
@@ -5,6 +5,10 @@ const (
 	invalidUni    = LexError("invalid unicode escape, expected \\xUUUU, where U is an hex digit (0-9 a-f)")
 	invalidEsc    = LexError("invalid escape")
 	invalidString = LexError("quote delimiter not found at the end of the string")
+	invalidRaw    = LexError("backtick delimiter not found at the end of the raw string")
+
+	invalidInterpolation = LexError("closing brace not found at the end of the interpolated expression")
+	emptyInterpolation   = LexError("the interpolated expression cannot be empty")
 )
 
 type LexError string
@@ -15,8 +15,8 @@ fun f(a, b) {
 	var c = try div(a, b)
 }
 !|&^~-/*<>
-if ret false true else
-!= == <= >= % >> << && || 0xFF
+if ret false true else null
+!= == <= >= % >> << && || ?? 0xFF
 "long string with text"
 'string with single quote'
 [1, 2, "ciao"]
@@ -81,6 +81,7 @@ test.method()`
 		{token.FALSE, "false"},
 		{token.TRUE, "true"},
 		{token.ELSE, "else"},
+		{token.NULL, "null"},
 		{token.NEWLINE, "\n"},
 
 		{token.NOTEQUALS, "!="},
@@ -92,6 +93,7 @@ test.method()`
 		{token.LSHIFT, "<<"},
 		{token.LOGICAND, "&&"},
 		{token.LOGICOR, "||"},
+		{token.COALESCE, "??"},
 		{token.INT, "0xFF"},
 		{token.NEWLINE, "\n"},
 
@@ -150,3 +152,200 @@ test.method()`
 		}
 	}
 }
+
+func TestRawString(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{"`C:\\Users\\test\\file.bin`", token.STR, `C:\Users\test\file.bin`},
+		{"`\\x55\\n`", token.STR, `\x55\n`},
+		{"`with \"double\" and 'single' quotes`", token.STR, `with "double" and 'single' quotes`},
+		{"`multi\nline`", token.STR, "multi\nline"},
+		{"``", token.STR, ""},
+		{"`unterminated", token.ILLEGAL, string(invalidRaw)},
+	}
+
+	for _, testCase := range tests {
+		lexer := NewLexer(bufio.NewReader(bytes.NewBufferString(testCase.input)))
+		tok := lexer.NextToken()
+		if tok.Type != testCase.expectedType {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expectedType, tok.Type)
+		}
+
+		if tok.Literal != testCase.expectedLiteral {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expectedLiteral, tok.Literal)
+		}
+	}
+
+	lexer := NewLexer(bufio.NewReader(bytes.NewBufferString("`a\nb`\nc")))
+	lexer.NextToken()
+	lexer.NextToken()
+	if lexer.GetLineNumber() != 3 {
+		t.Errorf("expected raw string newlines to be counted, got line %d", lexer.GetLineNumber())
+	}
+}
+
+func TestEscapeChars(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{`"\0"`, token.STR, "\x00"},
+		{`"a\0b"`, token.STR, "a\x00b"},
+		{`"\a"`, token.STR, "\a"},
+		{`"\b"`, token.STR, "\b"},
+		{`"\f"`, token.STR, "\f"},
+		{`"\v"`, token.STR, "\v"},
+		{`"\t\n\r\\"`, token.STR, "\t\n\r\\"},
+		{`"\x00\x7f"`, token.STR, "\x00\x7f"},
+		{`"\q"`, token.ILLEGAL, `invalid escape: \q`},
+		{`"\8"`, token.ILLEGAL, `invalid escape: \8`},
+	}
+
+	for _, testCase := range tests {
+		lexer := NewLexer(bufio.NewReader(bytes.NewBufferString(testCase.input)))
+		tok := lexer.NextToken()
+		if tok.Type != testCase.expectedType {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expectedType, tok.Type)
+		}
+
+		if tok.Literal != testCase.expectedLiteral {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestWordOperators(t *testing.T) {
+	input := "a and b or not c\nandy order nothing"
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"},
+		{token.LOGICAND, "and"},
+		{token.IDENT, "b"},
+		{token.LOGICOR, "or"},
+		{token.NOT, "not"},
+		{token.IDENT, "c"},
+		{token.NEWLINE, "\n"},
+		{token.IDENT, "andy"},
+		{token.IDENT, "order"},
+		{token.IDENT, "nothing"},
+		{token.EOF, ""},
+	}
+
+	lexer := NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
+
+	for idx, testCase := range tests {
+		tok := lexer.NextToken()
+		if tok.Type != testCase.expectedType {
+			t.Fatalf("Expected %q, got %q for token #%d", testCase.expectedType, tok.Type, idx)
+		}
+
+		if tok.Literal != testCase.expectedLiteral {
+			t.Fatalf("Expected %q, got %q for token #%d", testCase.expectedLiteral, tok.Literal, idx)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"var a = 1", 0},
+		{"// first\nvar a = 1 // second\n//", 3},
+		{"var url = \"http://host\"", 0},
+		{"var url = `http://host` // third", 1},
+	}
+
+	for _, testCase := range tests {
+		lexer := NewLexer(bufio.NewReader(bytes.NewBufferString(testCase.input)))
+		for tok := lexer.NextToken(); tok.Type != token.EOF; tok = lexer.NextToken() {
+		}
+
+		if lexer.Comments() != testCase.expected {
+			t.Errorf("%q: expected %d comments, got %d", testCase.input, testCase.expected, lexer.Comments())
+		}
+	}
+}
+
+func TestColumnNumber(t *testing.T) {
+	input := "var a = 1\n  // comment\n\tb == \"é\" + c"
+	expected := []struct {
+		literal string
+		line    int
+		column  int
+	}{
+		{"var", 1, 1},
+		{"a", 1, 5},
+		{"=", 1, 7},
+		{"1", 1, 9},
+		{"\n", 2, 0},
+		{"\n", 3, 0},
+		{"b", 3, 2},
+		{"==", 3, 4},
+		{"é", 3, 7},
+		{"+", 3, 11},
+		{"c", 3, 13},
+	}
+
+	lexer := NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
+	for _, exp := range expected {
+		tok := lexer.NextToken()
+		if tok.Literal != exp.literal {
+			t.Fatalf("expected token %q, got %q", exp.literal, tok.Literal)
+		}
+
+		if lexer.GetLineNumber() != exp.line || lexer.GetColumnNumber() != exp.column {
+			t.Errorf("%q: expected %d:%d, got %d:%d", tok.Literal, exp.line, exp.column,
+				lexer.GetLineNumber(), lexer.GetColumnNumber())
+		}
+	}
+}
+
+func TestInterpolatedString(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.TokenType
+		expectedLiteral string
+		expectedParts   []string
+	}{
+		{`"value: ${x}"`, token.INTERP, "value: ${x}", []string{"value: ", "x", ""}},
+		{`"${a + b}, ${f({"k": 1}["k"])}!"`, token.INTERP, `${a + b}, ${f({"k": 1}["k"])}!`,
+			[]string{"", "a + b", ", ", `f({"k": 1}["k"])`, "!"}},
+		{`"${"}"}"`, token.INTERP, `${"}"}`, []string{"", `"}"`, ""}},
+		{`"cost: \${x}"`, token.STR, "cost: ${x}", nil},
+		{`"$ {x} $x"`, token.STR, "$ {x} $x", nil},
+		{`'value: ${x}'`, token.STR, "value: ${x}", nil},
+		{`"${}"`, token.ILLEGAL, string(emptyInterpolation), nil},
+		{`"${x"`, token.ILLEGAL, string(invalidInterpolation), nil},
+	}
+
+	for _, testCase := range tests {
+		lexer := NewLexer(bufio.NewReader(bytes.NewBufferString(testCase.input)))
+		tok := lexer.NextToken()
+		if tok.Type != testCase.expectedType {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expectedType, tok.Type)
+		}
+
+		if tok.Literal != testCase.expectedLiteral {
+			t.Errorf("%s: expected %q, got %q", testCase.input, testCase.expectedLiteral, tok.Literal)
+		}
+
+		if len(tok.Parts) != len(testCase.expectedParts) {
+			t.Errorf("%s: expected parts %q, got %q", testCase.input, testCase.expectedParts, tok.Parts)
+			continue
+		}
+
+		for idx, part := range tok.Parts {
+			if part != testCase.expectedParts[idx] {
+				t.Errorf("%s: expected parts %q, got %q", testCase.input, testCase.expectedParts, tok.Parts)
+				break
+			}
+		}
+	}
+}
@@ -1,8 +1,15 @@
 package object
 
+import (
+	"context"
+	"io"
+)
+
 type Environment struct {
 	names map[string]Object
 	outer *Environment
+	ctx   context.Context
+	trace io.Writer
 }
 
 func NewEnvironment() *Environment {
@@ -11,12 +18,43 @@ func NewEnvironment() *Environment {
 	}
 }
 
+// NewEnvironmentWithContext returns an environment bound to the passed
+// context: evaluating code within it, or within any environment wrapping
+// it, is interrupted once the context is done.
+func NewEnvironmentWithContext(ctx context.Context) *Environment {
+	env := NewEnvironment()
+	env.ctx = ctx
+	return env
+}
+
 func WrappedEnvironment(outerEnv *Environment) *Environment {
 	inner := NewEnvironment()
 	inner.outer = outerEnv
+	inner.ctx = outerEnv.ctx
 	return inner
 }
 
+// Err returns the error of the context the environment is bound to, which
+// is not nil if the evaluation must be interrupted.
+func (env *Environment) Err() error {
+	if env.ctx == nil {
+		return nil
+	}
+	return env.ctx.Err()
+}
+
+// SetTrace sets the writer where the top-level statements evaluated
+// within the environment are logged, together with their results.
+// Passing nil disables the trace, which is the default.
+func (env *Environment) SetTrace(trace io.Writer) {
+	env.trace = trace
+}
+
+// Trace returns the writer set through SetTrace, if any.
+func (env *Environment) Trace() io.Writer {
+	return env.trace
+}
+
 func (env *Environment) Get(name string) (Object, bool) {
 	obj, ok := env.names[name]
 	if !ok && env.outer != nil {
@@ -33,3 +71,30 @@ func (env *Environment) Set(name string, obj Object) Object {
 func (env *Environment) IsNestedBlock() bool {
 	return env.outer != nil
 }
+
+// Snapshot is an opaque token recording the names bound in an
+// environment at the time it was taken.
+type Snapshot struct {
+	names map[string]Object
+}
+
+// Snapshot records the names currently bound in the environment, so that
+// any later definition or re-definition can be reverted through Restore.
+// Only the bindings are recorded: objects mutated in place, such as maps
+// modified through their methods, are not reverted.
+func (env *Environment) Snapshot() Snapshot {
+	names := make(map[string]Object, len(env.names))
+	for name, obj := range env.names {
+		names[name] = obj
+	}
+	return Snapshot{names: names}
+}
+
+// Restore reverts the names bound in the environment to the passed snapshot.
+func (env *Environment) Restore(snapshot Snapshot) {
+	names := make(map[string]Object, len(snapshot.names))
+	for name, obj := range snapshot.names {
+		names[name] = obj
+	}
+	env.names = names
+}
@@ -0,0 +1,50 @@
+package object
+
+import "testing"
+
+func TestEnvironmentSnapshotRestore(t *testing.T) {
+	env := NewEnvironment()
+	env.Set("a", &Integer{Value: 1})
+
+	snapshot := env.Snapshot()
+	env.Set("b", &Integer{Value: 2})
+	env.Set("a", &Integer{Value: 3})
+
+	env.Restore(snapshot)
+	if _, ok := env.Get("b"); ok {
+		t.Errorf("expected b to be removed after restoring the snapshot")
+	}
+
+	a, ok := env.Get("a")
+	if !ok {
+		t.Fatalf("expected a to be defined after restoring the snapshot")
+	}
+
+	if a.(*Integer).Value != 1 {
+		t.Errorf("expected a = 1 after restoring the snapshot, got %s", a.Inspect())
+	}
+
+	env.Set("c", &Integer{Value: 4})
+	env.Restore(snapshot)
+	if _, ok := env.Get("c"); ok {
+		t.Errorf("expected the snapshot to be restorable more than once")
+	}
+}
+
+func TestEnvironmentSnapshotInner(t *testing.T) {
+	outer := NewEnvironment()
+	outer.Set("a", &Integer{Value: 1})
+	inner := WrappedEnvironment(outer)
+
+	snapshot := inner.Snapshot()
+	inner.Set("b", &Integer{Value: 2})
+	inner.Restore(snapshot)
+
+	if _, ok := inner.Get("b"); ok {
+		t.Errorf("expected b to be removed after restoring the snapshot")
+	}
+
+	if _, ok := inner.Get("a"); !ok {
+		t.Errorf("expected the outer environment to be reachable after restoring")
+	}
+}
@@ -8,6 +8,10 @@ import (
 
 	"github.com/Abathargh/harlock/internal/evaluator/bytes"
 	"github.com/Abathargh/harlock/internal/evaluator/elf"
+	"github.com/Abathargh/harlock/internal/evaluator/macho"
+	"github.com/Abathargh/harlock/internal/evaluator/pe"
+	"github.com/Abathargh/harlock/internal/evaluator/tar"
+	"github.com/Abathargh/harlock/internal/evaluator/zip"
 	"github.com/Abathargh/harlock/pkg/hex"
 
 	"github.com/Abathargh/harlock/internal/ast"
@@ -26,7 +30,13 @@ const (
 	MapObj          ObjectType = "Map"
 	HexObj          ObjectType = "Hex File"
 	ElfObj          ObjectType = "Elf File"
+	PeObj           ObjectType = "Pe File"
+	MachoObj        ObjectType = "Macho File"
+	ZipObj          ObjectType = "Zip File"
+	TarObj          ObjectType = "Tar File"
 	BytesObj        ObjectType = "Bytes File"
+	BytesCursorObj  ObjectType = "Bytes Cursor"
+	BytesBuilderObj ObjectType = "Bytes Builder"
 	ErrorObj        ObjectType = "Error"
 	ArrayObj        ObjectType = "Array"
 	StringObj       ObjectType = "String"
@@ -37,6 +47,7 @@ const (
 	FunctionObj     ObjectType = "Function"
 	RuntimeErrorObj ObjectType = "Runtime Error"
 	ReturnValueObj  ObjectType = "Return value"
+	BreakObj        ObjectType = "Break"
 )
 
 type BuiltinFunction func(args ...Object) Object
@@ -113,8 +124,27 @@ func (rv *ReturnValue) Inspect() string {
 	return rv.Value.Inspect()
 }
 
+// Break signals the enclosing loops that they must stop: an empty
+// Label stops the innermost one, otherwise the loop with that label.
+type Break struct {
+	Label string
+	Line  int
+}
+
+func (b *Break) Type() ObjectType {
+	return BreakObj
+}
+
+func (b *Break) Inspect() string {
+	if b.Label == "" {
+		return "break"
+	}
+	return "break " + b.Label
+}
+
 type Error struct {
 	Message string
+	Line    int
 }
 
 func (e *Error) Type() ObjectType {
@@ -122,6 +152,9 @@ func (e *Error) Type() ObjectType {
 }
 
 func (e *Error) Inspect() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("Error: %s on line %d", e.Message, e.Line)
+	}
 	return fmt.Sprintf("Error: %s", e.Message)
 }
 
@@ -130,16 +163,24 @@ type RuntimeErrorType string
 const (
 	TypeError   RuntimeErrorType = "Type Error"
 	KeyError    RuntimeErrorType = "Key Error"
-	HexError                     = "Hex Error"
-	ElfError                     = "Elf Error"
-	BytesError                   = "Bytes Error"
-	FileError                    = "File Error"
-	CustomError                  = "Runtime Error"
+	HexError    RuntimeErrorType = "Hex Error"
+	ElfError    RuntimeErrorType = "Elf Error"
+	PeError     RuntimeErrorType = "Pe Error"
+	MachoError  RuntimeErrorType = "Macho Error"
+	ZipError    RuntimeErrorType = "Zip Error"
+	TarError    RuntimeErrorType = "Tar Error"
+	BytesError  RuntimeErrorType = "Bytes Error"
+	FileError   RuntimeErrorType = "File Error"
+	CustomError RuntimeErrorType = "Runtime Error"
 )
 
+// RuntimeError is a recoverable error, raised by builtins and methods.
+// Line is the line of the script where the error was raised, or zero if
+// it is not known yet, and gets appended to the message when inspected.
 type RuntimeError struct {
 	Kind    RuntimeErrorType
 	Message string
+	Line    int
 }
 
 func (ee *RuntimeError) Type() ObjectType {
@@ -147,6 +188,9 @@ func (ee *RuntimeError) Type() ObjectType {
 }
 
 func (ee *RuntimeError) Inspect() string {
+	if ee.Line > 0 {
+		return fmt.Sprintf("%s: %s on line %d", ee.Kind, ee.Message, ee.Line)
+	}
 	return fmt.Sprintf("%s: %s", ee.Kind, ee.Message)
 }
 
@@ -207,6 +251,7 @@ func (t *Type) Inspect() string {
 }
 
 type Array struct {
+	frozen
 	Elements []Object
 }
 
@@ -233,6 +278,7 @@ type HashPair struct {
 }
 
 type Map struct {
+	frozen
 	Mappings map[HashKey]HashPair
 }
 
@@ -298,6 +344,7 @@ type Method struct {
 	Description string
 	ArgTypes    []ObjectType
 	MethodFunc  MethodFunction
+	Mutating    bool
 }
 
 func (m *Method) GetBuiltinName() string {
@@ -313,6 +360,20 @@ func (m *Method) GetBuiltinArgTypes() []ObjectType {
 }
 
 func (m *Method) Call(args ...Object) Object {
+	if file, isFile := args[0].(File); isFile && m.Mutating && file.ReadOnly() {
+		return &RuntimeError{
+			Kind:    FileError,
+			Message: "cannot modify a file opened in read-only mode",
+		}
+	}
+
+	if coll, isFreezable := args[0].(Freezable); isFreezable && m.Mutating && coll.Frozen() {
+		return &RuntimeError{
+			Kind:    TypeError,
+			Message: fmt.Sprintf("cannot modify a frozen %s", strings.ToLower(string(coll.Type()))),
+		}
+	}
+
 	if len(args) == 1 {
 		return m.MethodFunc(args[0])
 	}
@@ -328,6 +389,7 @@ func (m *Method) Inspect() string {
 }
 
 type Set struct {
+	frozen
 	Elements map[HashKey]Object
 }
 
@@ -352,9 +414,48 @@ type File interface {
 	Name() string
 	Perms() uint32
 	AsBytes() []byte
+	ReadOnly() bool
+	SetReadOnly(readOnly bool)
+}
+
+// Freezable is implemented by the collection objects that
+// can be made immutable through the freeze builtin
+type Freezable interface {
+	Object
+	Frozen() bool
+	Freeze()
+}
+
+// frozen tracks whether a collection object can be modified,
+// and is embedded in the array, map and set object types
+type frozen struct {
+	isFrozen bool
+}
+
+func (f *frozen) Frozen() bool {
+	return f.isFrozen
+}
+
+func (f *frozen) Freeze() {
+	f.isFrozen = true
+}
+
+// access tracks whether a file object can be modified, and is
+// embedded in every file object type
+type access struct {
+	readOnly bool
+}
+
+func (a *access) ReadOnly() bool {
+	return a.readOnly
+}
+
+func (a *access) SetReadOnly(readOnly bool) {
+	a.readOnly = readOnly
 }
 
 type HexFile struct {
+	access
 	name  string
 	perms uint32
 	File  *hex.File
@@ -403,6 +504,7 @@ func (hf *HexFile) Inspect() string {
 }
 
 type ElfFile struct {
+	access
 	name  string
 	perms uint32
 	File  *elf.File
@@ -445,7 +547,189 @@ func (ef *ElfFile) Inspect() string {
 	return buf.String()
 }
 
+type PeFile struct {
+	access
+	name  string
+	perms uint32
+	File  *pe.File
+}
+
+func NewPeFile(name string, perms uint32, pefile *pe.File) *PeFile {
+	return &PeFile{
+		name:  name,
+		perms: perms,
+		File:  pefile,
+	}
+}
+
+func (pf *PeFile) Name() string {
+	return pf.name
+}
+
+func (pf *PeFile) Perms() uint32 {
+	return pf.perms
+}
+
+func (pf *PeFile) AsBytes() []byte {
+	return pf.File.AsBytes()
+}
+
+func (pf *PeFile) Type() ObjectType {
+	return PeObj
+}
+
+func (pf *PeFile) Inspect() string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("PeFile(@%s) {\n", pf.name))
+	buf.WriteString("  Sections: [")
+	for _, section := range pf.File.Sections() {
+		buf.WriteString(fmt.Sprintf("%s ", section))
+	}
+	buf.WriteString("]\n")
+	buf.WriteString("}")
+
+	return buf.String()
+}
+
+type MachoFile struct {
+	access
+	name  string
+	perms uint32
+	File  *macho.File
+}
+
+func NewMachoFile(name string, perms uint32, machofile *macho.File) *MachoFile {
+	return &MachoFile{
+		name:  name,
+		perms: perms,
+		File:  machofile,
+	}
+}
+
+func (mf *MachoFile) Name() string {
+	return mf.name
+}
+
+func (mf *MachoFile) Perms() uint32 {
+	return mf.perms
+}
+
+func (mf *MachoFile) AsBytes() []byte {
+	return mf.File.AsBytes()
+}
+
+func (mf *MachoFile) Type() ObjectType {
+	return MachoObj
+}
+
+func (mf *MachoFile) Inspect() string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("MachoFile(@%s) {\n", mf.name))
+	buf.WriteString("  Segments: [")
+	for _, segment := range mf.File.Segments() {
+		buf.WriteString(fmt.Sprintf("%s ", segment))
+	}
+	buf.WriteString("]\n")
+	buf.WriteString("  Sections: [")
+	for _, section := range mf.File.Sections() {
+		buf.WriteString(fmt.Sprintf("%s ", section))
+	}
+	buf.WriteString("]\n")
+	buf.WriteString("}")
+
+	return buf.String()
+}
+
+type ZipFile struct {
+	access
+	name  string
+	perms uint32
+	File  *zip.File
+}
+
+func NewZipFile(name string, perms uint32, zipfile *zip.File) *ZipFile {
+	return &ZipFile{
+		name:  name,
+		perms: perms,
+		File:  zipfile,
+	}
+}
+
+func (zf *ZipFile) Name() string {
+	return zf.name
+}
+
+func (zf *ZipFile) Perms() uint32 {
+	return zf.perms
+}
+
+func (zf *ZipFile) AsBytes() []byte {
+	return zf.File.AsBytes()
+}
+
+func (zf *ZipFile) Type() ObjectType {
+	return ZipObj
+}
+
+func (zf *ZipFile) Inspect() string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("ZipFile(@%s) {\n", zf.name))
+	buf.WriteString("  Entries: [")
+	for _, entry := range zf.File.Entries() {
+		buf.WriteString(fmt.Sprintf("%s ", entry))
+	}
+	buf.WriteString("]\n")
+	buf.WriteString("}")
+
+	return buf.String()
+}
+
+type TarFile struct {
+	access
+	name  string
+	perms uint32
+	File  *tar.File
+}
+
+func NewTarFile(name string, perms uint32, tarfile *tar.File) *TarFile {
+	return &TarFile{
+		name:  name,
+		perms: perms,
+		File:  tarfile,
+	}
+}
+
+func (tf *TarFile) Name() string {
+	return tf.name
+}
+
+func (tf *TarFile) Perms() uint32 {
+	return tf.perms
+}
+
+func (tf *TarFile) AsBytes() []byte {
+	return tf.File.AsBytes()
+}
+
+func (tf *TarFile) Type() ObjectType {
+	return TarObj
+}
+
+func (tf *TarFile) Inspect() string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("TarFile(@%s) {\n", tf.name))
+	buf.WriteString("  Entries: [")
+	for _, entry := range tf.File.Entries() {
+		buf.WriteString(fmt.Sprintf("%s ", entry))
+	}
+	buf.WriteString("]\n")
+	buf.WriteString("}")
+
+	return buf.String()
+}
+
 type BytesFile struct {
+	access
 	name  string
 	perms uint32
 	size  int64
@@ -469,6 +753,12 @@ func (bf *BytesFile) Perms() uint32 {
 	return bf.perms
 }
 
+// SetSize updates the size of the bytes file, after its
+// contents have been grown or shrunk
+func (bf *BytesFile) SetSize(size int64) {
+	bf.size = size
+}
+
 func (bf *BytesFile) AsBytes() []byte {
 	data, _ := bf.Bytes.ReadAt(0, int(bf.size))
 	return data
@@ -490,6 +780,35 @@ func (bf *BytesFile) Inspect() string {
 	return buf.String()
 }
 
+// BytesCursor is a position within a bytes file, which
+// advances while the file is read sequentially through it.
+type BytesCursor struct {
+	File     *BytesFile
+	Position int64
+}
+
+func (bc *BytesCursor) Type() ObjectType {
+	return BytesCursorObj
+}
+
+func (bc *BytesCursor) Inspect() string {
+	return fmt.Sprintf("cursor(%d)", bc.Position)
+}
+
+// BytesBuilder is a growing buffer, to which data is
+// appended sequentially before building a bytes file.
+type BytesBuilder struct {
+	Data []byte
+}
+
+func (bb *BytesBuilder) Type() ObjectType {
+	return BytesBuilderObj
+}
+
+func (bb *BytesBuilder) Inspect() string {
+	return fmt.Sprintf("bytes_builder(%d bytes)", len(bb.Data))
+}
+
 func OrType(baseTypes ...ObjectType) ObjectType {
 	typeStrList := make([]string, len(baseTypes))
 	for idx, obj := range baseTypes {
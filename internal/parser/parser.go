@@ -14,6 +14,7 @@ type Priority int
 
 const (
 	LOWEST Priority = iota + 1
+	COALESCE
 	LOGICAL
 	EQUALS
 	LESSGREATER
@@ -29,6 +30,7 @@ const (
 )
 
 var priorities = map[token.TokenType]Priority{
+	token.COALESCE:  COALESCE,
 	token.LOGICOR:   LOGICAL,
 	token.LOGICAND:  LOGICAL,
 	token.EQUALS:    EQUALS,
@@ -57,13 +59,30 @@ type (
 	infixParseFn  func(expression ast.Expression) ast.Expression
 )
 
+// Error is an error found while parsing a program, together with
+// the position of the token the parser was looking at.
+type Error struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+// Error returns the message of the error, followed by its line.
+func (err Error) Error() string {
+	return fmt.Sprintf("%s on line %d", err.Message, err.Line)
+}
+
 type Parser struct {
 	lex    *lexer.Lexer
-	errors []string
+	errors []Error
 
 	current token.Token
 	peeked  token.Token
 
+	// columns the current and the peeked tokens start at
+	currentColumn int
+	peekedColumn  int
+
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
 }
@@ -77,13 +96,16 @@ func NewParser(lex *lexer.Lexer) *Parser {
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
 	p.registerPrefix(token.TRUE, p.parseBoolean)
 	p.registerPrefix(token.FALSE, p.parseBoolean)
+	p.registerPrefix(token.NULL, p.parseNull)
 
 	p.registerPrefix(token.IF, p.parseIfExpression)
 	p.registerPrefix(token.TRY, p.parseTryExpression)
+	p.registerPrefix(token.DO, p.parseDoWhileExpression)
 
 	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
 
 	p.registerPrefix(token.STR, p.parseStringLiteral)
+	p.registerPrefix(token.INTERP, p.parseInterpolatedString)
 
 	p.registerPrefix(token.LBRACK, p.parseArrayLiteral)
 	p.registerPrefix(token.LBRACE, p.parseMapLiteral)
@@ -100,6 +122,7 @@ func NewParser(lex *lexer.Lexer) *Parser {
 	p.registerInfix(token.LBRACK, p.parseIndexExpression)
 
 	p.registerInfix(token.LOGICOR, p.parseInfixExpression)
+	p.registerInfix(token.COALESCE, p.parseInfixExpression)
 	p.registerInfix(token.LOGICAND, p.parseInfixExpression)
 	p.registerInfix(token.EQUALS, p.parseInfixExpression)
 	p.registerInfix(token.NOTEQUALS, p.parseInfixExpression)
@@ -136,15 +159,46 @@ func (parser *Parser) ParseProgram() *ast.Program {
 }
 
 func (parser *Parser) Errors() []string {
+	if len(parser.errors) == 0 {
+		return nil
+	}
+
+	errs := make([]string, len(parser.errors))
+	for idx, err := range parser.errors {
+		errs[idx] = err.Error()
+	}
+	return errs
+}
+
+// DetailedErrors returns the errors found while parsing, together
+// with the position they were found at.
+func (parser *Parser) DetailedErrors() []Error {
 	return parser.errors
 }
 
+// addError records an error found on the current line of the lexer,
+// at the passed column.
+func (parser *Parser) addError(column int, format string, args ...any) {
+	parser.errors = append(parser.errors, Error{
+		Message: fmt.Sprintf(format, args...),
+		Line:    parser.lex.GetLineNumber(),
+		Column:  column,
+	})
+}
+
 func (parser *Parser) parseStatement() ast.Statement {
 	switch parser.current.Type {
 	case token.VAR:
 		return parser.parseVarStatement()
 	case token.RET:
 		return parser.parseReturnStatement()
+	case token.BREAK:
+		return parser.parseBreakStatement()
+	case token.IDENT:
+		if parser.peeked.Type == token.COLON {
+			return parser.parseLabeledStatement()
+		}
+		return parser.parseExpressionStatement()
 	case token.NEWLINE:
 		return parser.parseNewlineRow()
 	default:
@@ -153,7 +207,10 @@ func (parser *Parser) parseStatement() ast.Statement {
 }
 
 func (parser *Parser) parseVarStatement() *ast.VarStatement {
-	statement := &ast.VarStatement{Token: parser.current}
+	statement := &ast.VarStatement{
+		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
+		Token:        parser.current,
+	}
 	if !parser.expectPeek(token.IDENT) {
 		return nil
 	}
@@ -192,8 +249,7 @@ func (parser *Parser) parseReturnStatement() *ast.ReturnStatement {
 	for parser.current.Type != token.NEWLINE &&
 		(parser.peeked.Type != token.RBRACE && parser.peeked.Type != token.NEWLINE) {
 		if parser.current.Type == token.EOF {
-			errMsg := fmt.Sprintf("unexpected %s on line %d", token.EOF, parser.lex.GetLineNumber())
-			parser.errors = append(parser.errors, errMsg)
+			parser.addError(parser.currentColumn, "unexpected %s", token.EOF)
 			return nil
 		}
 		parser.nextToken()
@@ -201,6 +257,61 @@ func (parser *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return statement
 }
 
+func (parser *Parser) parseBreakStatement() *ast.BreakStatement {
+	statement := &ast.BreakStatement{
+		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
+		Token:        parser.current,
+	}
+
+	switch parser.peeked.Type {
+	case token.NEWLINE, token.RBRACE, token.EOF:
+		return statement
+	}
+
+	if !parser.expectPeek(token.IDENT) {
+		return nil
+	}
+
+	statement.Label = &ast.Identifier{
+		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
+		Token:        parser.current,
+		Value:        parser.current.Literal,
+	}
+	return statement
+}
+
+// parseLabeledStatement parses a loop preceded by a label, in the
+// "label: do { ... } while cond" form.
+func (parser *Parser) parseLabeledStatement() ast.Statement {
+	statement := &ast.ExpressionStatement{
+		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
+		Token:        parser.current,
+	}
+
+	label := &ast.Identifier{
+		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
+		Token:        parser.current,
+		Value:        parser.current.Literal,
+	}
+
+	parser.nextToken()
+	if !parser.expectPeek(token.DO) {
+		return nil
+	}
+
+	loop, isLoop := parser.parseDoWhileExpression().(*ast.DoWhileExpression)
+	if !isLoop || loop == nil {
+		return nil
+	}
+
+	loop.Label = label
+	statement.Expression = loop
+	if parser.peeked.Type == token.NEWLINE {
+		parser.nextToken()
+	}
+	return statement
+}
+
 func (parser *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	statement := &ast.ExpressionStatement{
 		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
@@ -262,9 +373,7 @@ func (parser *Parser) parseIntegerLiteral() ast.Expression {
 		value, err = strconv.ParseInt(parser.current.Literal, 0, 64)
 	}
 	if err != nil {
-		errMsg := fmt.Sprintf("%q could not be parsed as an integer, on line %d", parser.current.Literal,
-			parser.lex.GetLineNumber())
-		parser.errors = append(parser.errors, errMsg)
+		parser.addError(parser.currentColumn, "%q could not be parsed as an integer", parser.current.Literal)
 		return nil
 	}
 	literal.Value = value
@@ -279,6 +388,13 @@ func (parser *Parser) parseBoolean() ast.Expression {
 	}
 }
 
+func (parser *Parser) parseNull() ast.Expression {
+	return &ast.Null{
+		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
+		Token:        parser.current,
+	}
+}
+
 func (parser *Parser) parseStringLiteral() ast.Expression {
 	return &ast.StringLiteral{
 		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
@@ -287,6 +403,53 @@ func (parser *Parser) parseStringLiteral() ast.Expression {
 	}
 }
 
+func (parser *Parser) parseInterpolatedString() ast.Expression {
+	line := parser.lex.GetLineNumber()
+	interpolated := &ast.InterpolatedString{
+		LineMetadata: ast.LineMetadata{LineNumber: line},
+		Token:        parser.current,
+	}
+
+	for idx, part := range parser.current.Parts {
+		if idx%2 == 0 {
+			interpolated.Parts = append(interpolated.Parts, &ast.StringLiteral{
+				LineMetadata: ast.LineMetadata{LineNumber: line},
+				Token:        token.Token{Type: token.STR, Literal: part},
+				Value:        part,
+			})
+			continue
+		}
+
+		expression := parser.parseEmbeddedExpression(part, line)
+		if expression == nil {
+			return nil
+		}
+		interpolated.Parts = append(interpolated.Parts, expression)
+	}
+	return interpolated
+}
+
+// parseEmbeddedExpression parses the source of an expression embedded
+// within an interpolated string, found on the passed line.
+func (parser *Parser) parseEmbeddedExpression(source string, line int) ast.Expression {
+	embedded := NewParser(lexer.NewLexerAtLine(strings.NewReader(source), line))
+	expression := embedded.parseExpression(LOWEST)
+	if embedded.peeked.Type != token.EOF {
+		embedded.addError(embedded.peekedColumn, "unexpected %q in the interpolated expression %q",
+			embedded.peeked.Literal, source)
+	}
+
+	if len(embedded.errors) != 0 {
+		// the columns of the embedded parser are relative to the expression
+		for _, err := range embedded.errors {
+			err.Column = parser.currentColumn
+			parser.errors = append(parser.errors, err)
+		}
+		return nil
+	}
+	return expression
+}
+
 func (parser *Parser) parseArrayLiteral() ast.Expression {
 	return &ast.ArrayLiteral{
 		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
@@ -304,8 +467,7 @@ func (parser *Parser) parseMapLiteral() ast.Expression {
 
 	for parser.peeked.Type != token.RBRACE {
 		if !parser.skipNewline() {
-			errMsg := fmt.Sprintf("unexpected %s on line %d", token.EOF, parser.lex.GetLineNumber())
-			parser.errors = append(parser.errors, errMsg)
+			parser.addError(parser.peekedColumn, "unexpected %s", token.EOF)
 			return nil
 		}
 
@@ -365,6 +527,26 @@ func (parser *Parser) parseIfExpression() ast.Expression {
 	return expression
 }
 
+func (parser *Parser) parseDoWhileExpression() ast.Expression {
+	expression := &ast.DoWhileExpression{
+		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
+		Token:        parser.current,
+	}
+
+	if !parser.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	expression.Body = parser.parseBlockStatement()
+	if !parser.expectPeek(token.WHILE) {
+		return nil
+	}
+
+	parser.nextToken()
+	expression.Condition = parser.parseExpression(LOWEST)
+	return expression
+}
+
 func (parser *Parser) parseTryExpression() ast.Expression {
 	tryExpression := &ast.TryExpression{
 		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
@@ -430,17 +612,38 @@ func (parser *Parser) parseIndexExpression(array ast.Expression) ast.Expression
 	parser.nextToken()
 	indexExpression.Index = parser.parseExpression(LOWEST)
 
+	if parser.peeked.Type == token.COLON {
+		parser.nextToken()
+		return parser.parseSliceExpression(indexExpression)
+	}
+
 	if !parser.expectPeek(token.RBRACK) {
 		return nil
 	}
 	return indexExpression
 }
 
+func (parser *Parser) parseSliceExpression(index *ast.IndexExpression) ast.Expression {
+	sliceExpression := &ast.SliceExpression{
+		LineMetadata: index.LineMetadata,
+		Token:        index.Token,
+		Left:         index.Left,
+		Start:        index.Index,
+	}
+	parser.nextToken()
+	sliceExpression.End = parser.parseExpression(LOWEST)
+
+	if !parser.expectPeek(token.RBRACK) {
+		return nil
+	}
+	return sliceExpression
+}
+
 func (parser *Parser) parsePrefixExpression() ast.Expression {
 	prefixExpression := &ast.PrefixExpression{
 		LineMetadata: ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
 		Token:        parser.current,
-		Operator:     parser.current.Literal,
+		Operator:     operator(parser.current),
 	}
 
 	parser.nextToken()
@@ -453,7 +656,7 @@ func (parser *Parser) parseInfixExpression(leftExpression ast.Expression) ast.Ex
 		LineMetadata:   ast.LineMetadata{LineNumber: parser.lex.GetLineNumber()},
 		Token:          parser.current,
 		LeftExpression: leftExpression,
-		Operator:       parser.current.Literal,
+		Operator:       operator(parser.current),
 	}
 	prio := parser.currentPrecedence()
 	parser.nextToken()
@@ -461,15 +664,19 @@ func (parser *Parser) parseInfixExpression(leftExpression ast.Expression) ast.Ex
 	return infixExpression
 }
 
+// operator returns the symbolic form of an operator token, so that word
+// operators (and, or, not) behave exactly like their symbolic counterparts.
+func operator(t token.Token) string {
+	return string(t.Type)
+}
+
 func (parser *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{Token: parser.current}
 	parser.nextToken()
 
 	for parser.current.Type != token.RBRACE {
 		if parser.current.Type == token.EOF {
-			errMsg := fmt.Sprintf("expected %s, got %s on line %d", token.RBRACE, token.EOF,
-				parser.lex.GetLineNumber())
-			parser.errors = append(parser.errors, errMsg)
+			parser.addError(parser.currentColumn, "expected %s, got %s", token.RBRACE, token.EOF)
 			return nil
 		}
 		statement := parser.parseStatement()
@@ -555,25 +762,22 @@ func (parser *Parser) peekPrecedence() Priority {
 }
 
 func (parser *Parser) peekError(t token.TokenType) {
-	errMsg := fmt.Sprintf("expected token of type %q, got %q on line %d", t, parser.peeked.Type,
-		parser.lex.GetLineNumber())
-	parser.errors = append(parser.errors, errMsg)
+	parser.addError(parser.peekedColumn, "expected token of type %q, got %q", t, parser.peeked.Type)
 }
 
 func (parser *Parser) noPrefixParseFunctionError(t token.Token) {
-	errMsg := fmt.Sprintf("cannot parse: prefix operator %q on line %d", t.Literal, parser.lex.GetLineNumber())
-	parser.errors = append(parser.errors, errMsg)
+	parser.addError(parser.currentColumn, "cannot parse: prefix operator %q", t.Literal)
 }
 
 func (parser *Parser) invalidExpressionError(t token.Token, p token.Token) {
-	errMsg := fmt.Sprintf("cannot parse: invalid expression \"%s%s\" on line %d", t.Literal, p.Literal,
-		parser.lex.GetLineNumber())
-	parser.errors = append(parser.errors, errMsg)
+	parser.addError(parser.currentColumn, "cannot parse: invalid expression \"%s%s\"", t.Literal, p.Literal)
 }
 
 func (parser *Parser) nextToken() {
 	parser.current = parser.peeked
+	parser.currentColumn = parser.peekedColumn
 	parser.peeked = parser.lex.NextToken()
+	parser.peekedColumn = parser.lex.GetColumnNumber()
 }
 
 func (parser *Parser) registerPrefix(t token.TokenType, fn prefixParseFn) {
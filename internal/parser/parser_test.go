@@ -155,6 +155,8 @@ func TestParsingPrefixExpressions(t *testing.T) {
 		{"~20", "~", 20},
 		{"!true", "!", true},
 		{"!false", "!", false},
+		{"not true", "!", true},
+		{"not 10", "!", 10},
 	}
 
 	for _, testCase := range tests {
@@ -212,6 +214,8 @@ func TestParsingInfixOperators(t *testing.T) {
 		{"5 << 5", 5, "<<", 5},
 		{"true && false", true, "&&", false},
 		{"false || true", false, "||", true},
+		{"true and false", true, "&&", false},
+		{"false or true", false, "||", true},
 		{"true == true", true, "==", true},
 		{"false == false", false, "==", false},
 		{"true != false", true, "!=", false},
@@ -277,6 +281,27 @@ func TestBooleanExpression(t *testing.T) {
 	}
 }
 
+func TestNullExpression(t *testing.T) {
+	lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString("null")))
+	p := NewParser(lex)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("Expected 1 statements, got %d", len(program.Statements))
+	}
+	statement := program.Statements[0].(*ast.ExpressionStatement)
+
+	literal, ok := statement.Expression.(*ast.Null)
+	if !ok {
+		t.Fatalf("Expected the expression to have *Null type, got %T", statement.Expression)
+	}
+
+	if literal.TokenLiteral() != "null" {
+		t.Errorf("Expected token literal to be null, got %q", literal.TokenLiteral())
+	}
+}
+
 func TestOperatorPrecedenceParsing(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -293,6 +318,10 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 		{"a * [1,2,5][2*1] / 2 ", "((a*[1, 2, 5][(2*1)])/2)"},
 		{"call(2 * a[2], 3 + a[3])", "call((2*a[2]), (3+a[3]))"},
 		{"2 * test.method()", "(2*test.method())"},
+		{"a ?? b || c == d", "(a??(b||(c==d)))"},
+		{"a ?? b ?? c", "((a??b)??c)"},
+		{"not a and b or c", "(((!a)&&b)||c)"},
+		{"a or b and not c", "((a||b)&&(!c))"},
 	}
 
 	for _, testCase := range tests {
@@ -308,6 +337,41 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 	}
 }
 
+func TestStringRoundTrip(t *testing.T) {
+	programs := []string{
+		"var a = 1 + 2 * 3\nprint(a)",
+		"if x <= y { x }",
+		"if x { 1 } else { 2 }",
+		"if x {\n} else {\n  var b = 2\n  b\n}",
+		"var f = fun(a, b) {\n var c = a + b\n ret c\n}\nf(1, 2)",
+		"var g = fun() { ret }\nfun() {}",
+		"var h = fun(x) {\n  if x > 0 {\n    ret fun(y) { x + y }\n  }\n  ret null\n}",
+		"[1, 2].map(fun(x) { x * 2 }).reduce(fun(a, b) { if a > b { a } else { b } }, 0)",
+		"var m = {\"b\": fun() { 1 }, 'a': [1, 2][0:1]}",
+		"var s = \"tab\\tquote\\x22 backslash\\\\ bell\\a\"\nvar r = `raw\nstring`",
+		"var i = 0\ndo {\n  var i = i + 1\n} while i < 10",
+		"outer: do {\n  do {\n    break outer\n  } while true\n  break\n} while x",
+		"var t = try open(args[1], \"hex\") ?? -1",
+		"var u = \"${a + b} costs \\$${f(\"${c}\")}\"",
+	}
+
+	parse := func(input string) *ast.Program {
+		lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
+		p := NewParser(lex)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		return program
+	}
+
+	for _, input := range programs {
+		first := parse(input).String()
+		second := parse(first).String()
+		if first != second {
+			t.Errorf("%q: expected a stable representation, got %q and then %q", input, first, second)
+		}
+	}
+}
+
 func TestIfExpression(t *testing.T) {
 	input := `if x <= y { x }`
 	lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
@@ -350,6 +414,104 @@ func TestIfExpression(t *testing.T) {
 	}
 }
 
+func TestDoWhileExpression(t *testing.T) {
+	input := `do { x } while x < y`
+	lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
+	p := NewParser(lex)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("Expected 1 statements, got %d", len(program.Statements))
+	}
+	statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Expected the statement to have ExpressionStatement type, got %T", program.Statements[0])
+	}
+
+	expression, ok := statement.Expression.(*ast.DoWhileExpression)
+	if !ok {
+		t.Fatalf("Expected the expression to have *DoWhileExpression type, got %T", statement.Expression)
+	}
+
+	if len(expression.Body.Statements) != 1 {
+		t.Fatalf("Expected 1 body statement got %d", len(expression.Body.Statements))
+	}
+
+	body, ok := expression.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Expected the body to have *ExpressionStatement type, got %T", expression.Body.Statements[0])
+	}
+
+	if !testIdentifier(t, body.Expression, "x") {
+		return
+	}
+
+	testInfixExpression(t, expression.Condition, "x", "<", "y")
+
+	for _, invalid := range []string{"do { x }", "do x while y", "do { x } y"} {
+		lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(invalid)))
+		p := NewParser(lex)
+		p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("%q: expected a parsing error", invalid)
+		}
+	}
+}
+
+func TestLabeledLoop(t *testing.T) {
+	input := "outer: do {\n\tdo {\n\t\tbreak outer\n\t} while true\n\tbreak\n} while x"
+	lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
+	p := NewParser(lex)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("Expected 1 statements, got %d", len(program.Statements))
+	}
+	statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Expected the statement to have ExpressionStatement type, got %T", program.Statements[0])
+	}
+
+	outer, ok := statement.Expression.(*ast.DoWhileExpression)
+	if !ok {
+		t.Fatalf("Expected the expression to have *DoWhileExpression type, got %T", statement.Expression)
+	}
+
+	if outer.Label == nil || outer.Label.Value != "outer" {
+		t.Fatalf("Expected the loop to be labeled \"outer\", got %v", outer.Label)
+	}
+
+	if len(outer.Body.Statements) != 2 {
+		t.Fatalf("Expected 2 body statements got %d", len(outer.Body.Statements))
+	}
+
+	inner := outer.Body.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.DoWhileExpression)
+	if inner.Label != nil {
+		t.Errorf("Expected the inner loop to have no label, got %v", inner.Label)
+	}
+
+	labeledBreak, ok := inner.Body.Statements[0].(*ast.BreakStatement)
+	if !ok || labeledBreak.Label == nil || labeledBreak.Label.Value != "outer" {
+		t.Errorf("Expected a break outer statement, got %v", inner.Body.Statements[0])
+	}
+
+	plainBreak, ok := outer.Body.Statements[1].(*ast.BreakStatement)
+	if !ok || plainBreak.Label != nil {
+		t.Errorf("Expected a break statement, got %v", outer.Body.Statements[1])
+	}
+
+	for _, invalid := range []string{"outer: 1", "outer: do { x }", "break 1"} {
+		lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(invalid)))
+		p := NewParser(lex)
+		p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("%q: expected a parsing error", invalid)
+		}
+	}
+}
+
 func TestIfElseExpression(t *testing.T) {
 	input := `if (x <= y) { z } else { w }`
 	lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
@@ -574,6 +736,33 @@ func TestIndexExpression(t *testing.T) {
 	}
 }
 
+func TestSliceExpression(t *testing.T) {
+	input := `str[1:2 + 3]`
+
+	lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
+	p := NewParser(lex)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	statement := program.Statements[0].(*ast.ExpressionStatement)
+	sliceExpression, ok := statement.Expression.(*ast.SliceExpression)
+	if !ok {
+		t.Fatalf("Expected the statement to have SliceExpression type, got %T", statement.Expression)
+	}
+
+	if !testIdentifier(t, sliceExpression.Left, "str") {
+		return
+	}
+
+	if !testLiteralExpression(t, sliceExpression.Start, 1) {
+		return
+	}
+
+	if !testInfixExpression(t, sliceExpression.End, 2, "+", 3) {
+		return
+	}
+}
+
 func TestMapLiteralParsing(t *testing.T) {
 	input := `{"test": 6, "tests": 7}`
 	expected := map[string]int64{
@@ -655,9 +844,9 @@ func TestMapLiteralParsingWithExpressions(t *testing.T) {
 		if !ok {
 			t.Fatalf("expected string key, got %T", key)
 		}
-		testFunction, ok := expectedTests[strKey.String()]
+		testFunction, ok := expectedTests[strKey.Value]
 		if !ok {
-			t.Fatalf("expected function for key %s, not found", strKey.String())
+			t.Fatalf("expected function for key %s, not found", strKey.Value)
 		}
 		testFunction(val)
 	}
@@ -828,6 +1017,47 @@ func testVarStatement(t *testing.T, statement ast.Statement, name string) bool {
 	return true
 }
 
+func TestDetailedErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Error
+	}{
+		{"var x = 5", nil},
+		{"var = 12", []Error{
+			{Message: "expected token of type \"IDENT\", got \"=\"", Line: 1, Column: 5},
+			{Message: "cannot parse: prefix operator \"=\"", Line: 1, Column: 5},
+		}},
+		{"var a = 1\n  var = 2", []Error{
+			{Message: "expected token of type \"IDENT\", got \"=\"", Line: 2, Column: 7},
+			{Message: "cannot parse: prefix operator \"=\"", Line: 2, Column: 7},
+		}},
+		{"var s = \"${1 2}\"", []Error{
+			{Message: "unexpected \"2\" in the interpolated expression \"1 2\"", Line: 1, Column: 9},
+		}},
+	}
+
+	for _, testCase := range tests {
+		lex := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(testCase.input)))
+		p := NewParser(lex)
+		p.ParseProgram()
+
+		errs := p.DetailedErrors()
+		if len(errs) != len(testCase.expected) {
+			t.Fatalf("%q: expected %d errors, got %v", testCase.input, len(testCase.expected), errs)
+		}
+
+		for idx, err := range errs {
+			if err != testCase.expected[idx] {
+				t.Errorf("%q: expected %+v, got %+v", testCase.input, testCase.expected[idx], err)
+			}
+
+			if p.Errors()[idx] != fmt.Sprintf("%s on line %d", err.Message, err.Line) {
+				t.Errorf("%q: expected the error message to report its line, got %q", testCase.input, p.Errors()[idx])
+			}
+		}
+	}
+}
+
 func checkParserErrors(t *testing.T, parser *Parser) {
 	errors := parser.Errors()
 	if len(errors) == 0 {
@@ -5,7 +5,9 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/Abathargh/harlock/internal/evaluator"
 	"github.com/Abathargh/harlock/internal/lexer"
@@ -16,29 +18,128 @@ import (
 const PROMPT = ">>> "
 const FOLLOWING = "... "
 
+// INDENT is the indentation added to the FOLLOWING prompt
+// for each block that is still open.
+const INDENT = "    "
+
+// TimeCommand is the meta-command that evaluates the expression following
+// it and reports the wall-clock duration of its evaluation.
+const TimeCommand = ":time"
+
+// CompleteCommand is the meta-command that lists the builtins, or the
+// methods after a '.', whose name starts with the identifier following it.
+const CompleteCommand = ":complete"
+
+const (
+	// PromptEnv and FollowingEnv are the environment variables that,
+	// when set, replace the PROMPT and FOLLOWING prompts.
+	PromptEnv    = "HARLOCK_PROMPT"
+	FollowingEnv = "HARLOCK_FOLLOWING"
+
+	// ColorEnv is the environment variable that, when set to a non-empty
+	// value, enables the colored prompts.
+	ColorEnv = "HARLOCK_COLOR"
+)
+
+const (
+	promptColor    = "\x1b[1;32m"
+	followingColor = "\x1b[1;34m"
+	resetColor     = "\x1b[0m"
+)
+
+const (
+	// MaxDisplayLines is the number of lines over which a value is
+	// displayed truncated, showing only DisplayedLines lines at the
+	// start and at the end of it.
+	MaxDisplayLines = 40
+	DisplayedLines  = 10
+
+	// MaxDisplayChars is the number of characters over which a single line
+	// value is displayed truncated, showing only DisplayedChars characters
+	// at the start and at the end of it.
+	MaxDisplayChars = 2000
+	DisplayedChars  = 200
+)
+
+// Config holds the prompts displayed by the REPL and whether they
+// are colored, which only happens if the output is a terminal.
+type Config struct {
+	Prompt    string
+	Following string
+	Color     bool
+}
+
+// DefaultConfig returns the configuration with the default
+// PROMPT and FOLLOWING prompts and no colors.
+func DefaultConfig() Config {
+	return Config{Prompt: PROMPT, Following: FOLLOWING}
+}
+
+// ConfigFromEnv returns the default configuration, overridden by
+// the PromptEnv, FollowingEnv and ColorEnv environment variables.
+func ConfigFromEnv() Config {
+	config := DefaultConfig()
+	if prompt, isSet := os.LookupEnv(PromptEnv); isSet {
+		config.Prompt = prompt
+	}
+
+	if following, isSet := os.LookupEnv(FollowingEnv); isSet {
+		config.Following = following
+	}
+
+	config.Color = os.Getenv(ColorEnv) != ""
+	return config
+}
+
 func Start(input io.Reader, output io.Writer) {
+	StartWithConfig(input, output, DefaultConfig())
+}
+
+// StartWithConfig starts the REPL as Start does, displaying
+// the prompts set in the passed configuration.
+func StartWithConfig(input io.Reader, output io.Writer, config Config) {
 	scanner := bufio.NewScanner(input)
 	env := object.NewEnvironment()
 
+	prompt, following := config.Prompt, config.Following
+	if config.Color && isTerminal(output) {
+		prompt = promptColor + prompt + resetColor
+		following = followingColor + following + resetColor
+	}
+
 	var buf strings.Builder
 	exprStarted := false
+	depth := 0
 
 	for {
 		if !exprStarted {
-			_, _ = fmt.Fprintf(output, PROMPT)
+			_, _ = io.WriteString(output, prompt)
 		} else {
-			_, _ = fmt.Fprintf(output, FOLLOWING)
+			_, _ = io.WriteString(output, following+strings.Repeat(INDENT, depth))
 		}
 		if !scanner.Scan() {
 			return
 		}
 
 		line := strings.TrimSpace(scanner.Text())
+		if !exprStarted && isCommand(line, TimeCommand) {
+			timeEval(output, strings.TrimSpace(strings.TrimPrefix(line, TimeCommand)), env)
+			continue
+		}
+
+		if !exprStarted && isCommand(line, CompleteCommand) {
+			for _, candidate := range completions(strings.TrimSpace(strings.TrimPrefix(line, CompleteCommand)), env) {
+				_, _ = fmt.Fprintln(output, candidate)
+			}
+			continue
+		}
+
 		switch {
 		case line == "" && !exprStarted:
 			continue
 		case line == "" && exprStarted:
 			exprStarted = false
+			depth = 0
 			if !parseAndEval(output, buf.String(), env) {
 				buf.Reset()
 				continue
@@ -52,12 +153,45 @@ func Start(input io.Reader, output io.Writer) {
 			exprStarted = true
 			fallthrough
 		case line != "" && exprStarted:
+			depth = braceDepth(depth, line)
 			buf.WriteString(line)
 			buf.WriteString("\n")
 		}
 	}
 }
 
+// braceDepth returns the number of blocks that are still open after the
+// passed line, starting from depth. Braces within strings and comments are
+// not counted, and the depth never goes below zero.
+func braceDepth(depth int, line string) int {
+	var quote rune
+	escaped := false
+	prev := rune(0)
+
+	for _, char := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if char == '\\' && quote != '`' {
+				escaped = true
+			} else if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'' || char == '`':
+			quote = char
+		case char == '/' && prev == '/':
+			return depth
+		case char == '{':
+			depth++
+		case char == '}' && depth > 0:
+			depth--
+		}
+		prev = char
+	}
+	return depth
+}
+
 func parseAndEval(output io.Writer, input string, env *object.Environment) bool {
 	l := lexer.NewLexer(bufio.NewReader(bytes.NewBufferString(input)))
 	p := parser.NewParser(l)
@@ -69,14 +203,105 @@ func parseAndEval(output io.Writer, input string, env *object.Environment) bool
 
 	evaluatedProg := evaluator.Eval(program, env)
 	if evaluatedProg != nil {
-		_, _ = io.WriteString(output, evaluatedProg.Inspect())
+		_, _ = io.WriteString(output, truncate(evaluatedProg.Inspect()))
 		_, _ = io.WriteString(output, "\n")
 	}
 	return true
 }
 
+// isTerminal returns whether the passed output is a character device,
+// such as a terminal, rather than a pipe or a regular file.
+func isTerminal(output io.Writer) bool {
+	file, isFile := output.(*os.File)
+	if !isFile {
+		return false
+	}
+
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func isCommand(line, command string) bool {
+	return line == command || strings.HasPrefix(line, command+" ")
+}
+
+// completions returns the names completing the identifier at the end of the
+// passed input: builtin names, or method names if the identifier follows a
+// '.'. The methods are restricted to the ones of the receiver type when the
+// receiver is a variable defined in the environment.
+func completions(input string, env *object.Environment) []string {
+	start := len(input)
+	for start > 0 && isIdentChar(input[start-1]) {
+		start--
+	}
+	prefix := input[start:]
+
+	names := evaluator.BuiltinNames()
+	if start > 0 && input[start-1] == '.' {
+		receiverEnd := start - 1
+		receiverStart := receiverEnd
+		for receiverStart > 0 && isIdentChar(input[receiverStart-1]) {
+			receiverStart--
+		}
+
+		var receiverType object.ObjectType
+		if receiver, isDefined := env.Get(input[receiverStart:receiverEnd]); isDefined {
+			receiverType = receiver.Type()
+		}
+		names = evaluator.MethodNames(receiverType)
+	}
+
+	var candidates []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			candidates = append(candidates, name)
+		}
+	}
+	return candidates
+}
+
+func isIdentChar(char byte) bool {
+	return char == '_' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' ||
+		char >= '0' && char <= '9'
+}
+
+// timeEval evaluates the passed input as parseAndEval does, printing
+// the time elapsed during the evaluation after its result.
+func timeEval(output io.Writer, input string, env *object.Environment) {
+	if input == "" {
+		_, _ = fmt.Fprintf(output, "usage: %s <expr>\n", TimeCommand)
+		return
+	}
+
+	start := time.Now()
+	if parseAndEval(output, input, env) {
+		_, _ = fmt.Fprintf(output, "elapsed: %s\n", time.Since(start))
+	}
+}
+
 func printParserErrors(writer io.Writer, errors []string) {
 	for _, errorMsg := range errors {
 		_, _ = io.WriteString(writer, fmt.Sprintf("%s\n", errorMsg))
 	}
 }
+
+// truncate shortens the representation of huge objects, such as files, so
+// that displaying them does not flood the terminal.
+func truncate(repr string) string {
+	lines := strings.Split(repr, "\n")
+	if len(lines) > MaxDisplayLines {
+		omitted := len(lines) - 2*DisplayedLines
+		return fmt.Sprintf("%s\n... (%d lines omitted) ...\n%s",
+			strings.Join(lines[:DisplayedLines], "\n"), omitted,
+			strings.Join(lines[len(lines)-DisplayedLines:], "\n"))
+	}
+
+	// counting runes rather than bytes, not to cut multi-byte characters
+	chars := []rune(repr)
+	if len(lines) == 1 && len(chars) > MaxDisplayChars {
+		omitted := len(chars) - 2*DisplayedChars
+		return fmt.Sprintf("%s ... (%d characters omitted) ... %s",
+			string(chars[:DisplayedChars]), omitted, string(chars[len(chars)-DisplayedChars:]))
+	}
+	return repr
+}
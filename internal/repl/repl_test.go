@@ -0,0 +1,215 @@
+package repl
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+func TestStartTruncatesHugeObjects(t *testing.T) {
+	var hexFile strings.Builder
+	for idx := 0; idx < 100; idx++ {
+		addr := idx * 16
+		sum := 0x10 + (addr >> 8) + (addr & 0xff)
+		hexFile.WriteString(fmt.Sprintf(":10%04X00%s%02X\n", addr,
+			strings.Repeat("00", 16), byte(-sum)))
+	}
+	hexFile.WriteString(":00000001FF\n")
+
+	if err := os.WriteFile("test.hex", []byte(hexFile.String()), 0666); err != nil {
+		t.Fatalf("cannot create the test.hex file")
+	}
+	defer func() { _ = os.Remove("test.hex") }()
+
+	if err := os.WriteFile("test.bin", make([]byte, 4096), 0666); err != nil {
+		t.Fatalf("cannot create the test.bin file")
+	}
+	defer func() { _ = os.Remove("test.bin") }()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"var h = open(\"test.hex\", \"hex\")\nh\n", "... (81 lines omitted) ..."},
+		{"var b = open(\"test.bin\", \"bytes\")\nb\n", "... (11886 characters omitted) ..."},
+	}
+
+	for _, testCase := range tests {
+		var out bytes.Buffer
+		Start(strings.NewReader(testCase.input), &out)
+
+		if !strings.Contains(out.String(), testCase.expected) {
+			t.Errorf("expected the displayed object to contain %q", testCase.expected)
+		}
+
+		if out.Len() > 2*MaxDisplayChars {
+			t.Errorf("expected the displayed object to be truncated, got %d characters", out.Len())
+		}
+	}
+}
+
+func TestTruncateMultiByteCharacters(t *testing.T) {
+	repr := strings.Repeat("é", MaxDisplayChars+1)
+	truncated := truncate(repr)
+	if !utf8.ValidString(truncated) {
+		t.Errorf("expected the truncated object to be valid UTF-8, got %q", truncated)
+	}
+
+	expected := fmt.Sprintf("%s ... (%d characters omitted) ... %s", strings.Repeat("é", DisplayedChars),
+		MaxDisplayChars+1-2*DisplayedChars, strings.Repeat("é", DisplayedChars))
+	if truncated != expected {
+		t.Errorf("expected %q, got %q", expected, truncated)
+	}
+
+	short := strings.Repeat("é", MaxDisplayChars)
+	if truncate(short) != short {
+		t.Errorf("expected an object with %d characters not to be truncated", MaxDisplayChars)
+	}
+}
+
+func TestStartDoesNotTruncateSmallObjects(t *testing.T) {
+	var out bytes.Buffer
+	Start(strings.NewReader("[1, 2, 3]\n"), &out)
+
+	expected := PROMPT + "[1, 2, 3]\n" + PROMPT
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestStartTimeCommand(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  string
+		hasTiming bool
+	}{
+		{":time 1 + 2\n", PROMPT + "3\nelapsed: ", true},
+		{"var a = 2\n:time a * 3\n", PROMPT + PROMPT + "6\nelapsed: ", true},
+		{":time var b = 1\n", PROMPT + "elapsed: ", true},
+		{":time\n", PROMPT + "usage: :time <expr>\n", false},
+		{":time var\n", PROMPT, false},
+	}
+
+	for _, testCase := range tests {
+		var out bytes.Buffer
+		Start(strings.NewReader(testCase.input), &out)
+
+		if !strings.HasPrefix(out.String(), testCase.expected) {
+			t.Errorf("%q: expected the output to start with %q, got %q",
+				testCase.input, testCase.expected, out.String())
+		}
+
+		if strings.Contains(out.String(), "elapsed: ") != testCase.hasTiming {
+			t.Errorf("%q: expected timing line presence = %t, got %q",
+				testCase.input, testCase.hasTiming, out.String())
+		}
+	}
+}
+
+func TestBraceDepth(t *testing.T) {
+	tests := []struct {
+		lines    []string
+		expected []int
+	}{
+		{
+			[]string{"var f = fun(a) {", "if a > 1 {", "a", "} else {", "0", "}", "}"},
+			[]int{1, 2, 2, 2, 2, 1, 0},
+		},
+		{
+			[]string{"if true {", `var s = "{ \" {"`, "var r = `{{`", "// {", "}"},
+			[]int{1, 1, 1, 1, 0},
+		},
+		{
+			[]string{"if true { 1 } // }", "}", "}"},
+			[]int{0, 0, 0},
+		},
+	}
+
+	for _, testCase := range tests {
+		depth := 0
+		for idx, line := range testCase.lines {
+			depth = braceDepth(depth, line)
+			if depth != testCase.expected[idx] {
+				t.Errorf("%q: expected depth %d, got %d", line, testCase.expected[idx], depth)
+			}
+		}
+	}
+}
+
+func TestStartIndentsNestedBlocks(t *testing.T) {
+	var out bytes.Buffer
+	Start(strings.NewReader("if true {\nif true {\n1\n}\n}\n\n"), &out)
+
+	expected := PROMPT + FOLLOWING + INDENT + FOLLOWING + INDENT + INDENT +
+		FOLLOWING + INDENT + INDENT + FOLLOWING + INDENT + FOLLOWING + "1\n" + PROMPT
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	env := object.NewEnvironment()
+	env.Set("s", &object.String{Value: "test"})
+	env.Set("n", &object.Integer{Value: 1})
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"hex", []string{"hex", "hex_dump_diff", "hexdump"}},
+		{"var a = base32_", []string{"base32_decode", "base32_encode"}},
+		{"s.pad", []string{"pad_end", "pad_start"}},
+		{"n.pad", nil},
+		{"undefined.pad_st", []string{"pad_start"}},
+		{"not_a_builtin", nil},
+	}
+
+	for _, testCase := range tests {
+		candidates := completions(testCase.input, env)
+		if strings.Join(candidates, ",") != strings.Join(testCase.expected, ",") {
+			t.Errorf("%q: expected %v, got %v", testCase.input, testCase.expected, candidates)
+		}
+	}
+}
+
+func TestStartCompleteCommand(t *testing.T) {
+	var out bytes.Buffer
+	Start(strings.NewReader(":complete base32_\n"), &out)
+
+	expected := PROMPT + "base32_decode\nbase32_encode\n" + PROMPT
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(PromptEnv, "harlock> ")
+	t.Setenv(FollowingEnv, "      | ")
+	t.Setenv(ColorEnv, "1")
+
+	config := ConfigFromEnv()
+	if config.Prompt != "harlock> " || config.Following != "      | " || !config.Color {
+		t.Errorf("expected the configuration to be read from the environment, got %+v", config)
+	}
+
+	var out bytes.Buffer
+	StartWithConfig(strings.NewReader("if true {\n1\n}\n\n"), &out, config)
+
+	// the output is not a terminal, so no colors are added
+	expected := "harlock> " + "      | " + INDENT + "      | " + INDENT + "      | " + "1\n" + "harlock> "
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestDefaultConfig(t *testing.T) {
+	expected := Config{Prompt: PROMPT, Following: FOLLOWING}
+	if config := DefaultConfig(); config != expected {
+		t.Errorf("expected %+v, got %+v", expected, config)
+	}
+}
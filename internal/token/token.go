@@ -5,6 +5,10 @@ type TokenType string
 type Token struct {
 	Type    TokenType
 	Literal string
+	// Parts holds the segments of an interpolated string, alternating
+	// literal text and the source of the embedded expressions, starting
+	// and ending with literal text.
+	Parts []string
 }
 
 const (
@@ -15,6 +19,8 @@ const (
 	INT   = "INT"
 	STR   = "STRING"
 
+	INTERP = "INTERP"
+
 	ASSIGN  = "="
 	PLUS    = "+"
 	MINUS   = "-"
@@ -40,6 +46,7 @@ const (
 
 	LOGICAND = "&&"
 	LOGICOR  = "||"
+	COALESCE = "??"
 
 	COMMA   = ","
 	COLON   = ":"
@@ -58,9 +65,13 @@ const (
 	TRY      = "TRY"
 	TRUE     = "TRUE"
 	FALSE    = "FALSE"
+	NULL     = "NULL"
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RET      = "RET"
+	DO       = "DO"
+	WHILE    = "WHILE"
+	BREAK    = "BREAK"
 )
 
 var keywords = map[string]TokenType{
@@ -69,9 +80,16 @@ var keywords = map[string]TokenType{
 	"try":   TRY,
 	"true":  TRUE,
 	"false": FALSE,
+	"null":  NULL,
 	"if":    IF,
 	"else":  ELSE,
 	"ret":   RET,
+	"do":    DO,
+	"while": WHILE,
+	"break": BREAK,
+	"and":   LOGICAND,
+	"or":    LOGICOR,
+	"not":   NOT,
 }
 
 func LookupIdentifier(identifier string) TokenType {
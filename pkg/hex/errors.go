@@ -38,4 +38,7 @@ const (
 	AccessOutOfBounds = FileError("cannot access the hex file out of the length of the encoded program")
 	RecordErr         = FileError("faulty record")
 	RecordOutOfBounds = FileError("attempting to request a record out of the bounds of the file")
+	InvalidRecordSize = FileError("the record size must be in the 1..255 range")
+	NoDataErr         = FileError("the hex file does not contain any data record")
+	EmptyPatternErr   = FileError("the pattern to search for cannot be empty")
 )
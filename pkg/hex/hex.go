@@ -1,8 +1,24 @@
 package hex
 
 import (
+	"bytes"
 	"encoding/hex"
 	"io"
+	"sort"
+)
+
+const (
+	// DefaultRecordSize is the number of data bytes held by each
+	// data record generated by FromBytes, unless specified otherwise
+	DefaultRecordSize = 16
+
+	// MaxRecordSize is the maximum number of data bytes that a
+	// data record can hold
+	MaxRecordSize = 255
+
+	// segmentSize is the size of the address space covered by
+	// a single extended linear address record
+	segmentSize = 1 << 16
 )
 
 // File implements an Intel Hex-encoded file
@@ -11,6 +27,13 @@ type File struct {
 	records []*Record
 }
 
+// DataBlock is a run of contiguous data bytes, starting
+// from an absolute address, spanning one or more records
+type DataBlock struct {
+	Address uint32
+	Data    []byte
+}
+
 // recordView is an internal struct used to
 // abstract data accesses to the hex file
 type recordView struct {
@@ -49,6 +72,39 @@ func ReadAll(in io.ByteScanner) (*File, error) {
 	return nil, err
 }
 
+// FromBytes encodes the passed data into a hex file, starting from
+// address 0. Each data record holds up to recordSize bytes, and
+// extended linear address records are emitted whenever the data
+// crosses a 64KiB boundary.
+func FromBytes(data []byte, recordSize int) (*File, error) {
+	if recordSize < 1 || recordSize > MaxRecordSize {
+		return nil, InvalidRecordSize
+	}
+
+	var records []*Record
+	for offset := 0; offset < len(data); {
+		if offset != 0 && offset%segmentSize == 0 {
+			upper := uint16(offset / segmentSize)
+			records = append(records, newRecord(ExtendedLinearAddrRecord, 0,
+				[]byte{byte(upper >> 8), byte(upper)}))
+		}
+
+		size := recordSize
+		if remaining := len(data) - offset; remaining < size {
+			size = remaining
+		}
+		if toBoundary := segmentSize - offset%segmentSize; toBoundary < size {
+			size = toBoundary
+		}
+
+		records = append(records, newRecord(DataRecord, uint16(offset), data[offset:offset+size]))
+		offset += size
+	}
+
+	records = append(records, newRecord(EOFRecord, 0, nil))
+	return &File{binSize: len(data), records: records}, nil
+}
+
 func (hf *File) Iterator() <-chan *Record {
 	ch := make(chan *Record)
 	go func(recs []*Record, channel chan *Record) {
@@ -60,6 +116,26 @@ func (hf *File) Iterator() <-chan *Record {
 	return ch
 }
 
+// Clone returns a deep copy of the file, which can be modified
+// independently of the original one
+func (hf *File) Clone() *File {
+	records := make([]*Record, len(hf.records))
+	for idx, record := range hf.records {
+		data := make([]byte, len(record.data))
+		copy(data, record.data)
+		records[idx] = &Record{
+			length: record.length,
+			rType:  record.rType,
+			data:   data,
+		}
+	}
+
+	return &File{
+		binSize: hf.binSize,
+		records: records,
+	}
+}
+
 // Size returns the number of records in the file
 func (hf *File) Size() int {
 	return len(hf.records)
@@ -79,6 +155,18 @@ func (hf *File) Record(idx int) (*Record, error) {
 	return hf.records[idx], nil
 }
 
+// Records returns the records whose index is within
+// the [start; end) interval, if it is a valid one.
+func (hf *File) Records(start, end int) ([]*Record, error) {
+	if start < 0 || end > len(hf.records) || start > end {
+		return nil, RecordOutOfBounds
+	}
+
+	records := make([]*Record, end-start)
+	copy(records, hf.records[start:end])
+	return records, nil
+}
+
 // ReadAt reads size bytes starting from pos position in the
 // hex-encoded file. This implements a sort of random access
 // to the data mapped in hex-format.
@@ -183,25 +271,12 @@ func (hf *File) accessAt(pos uint32, size int) (*recordView, error) {
 
 	for idx, record := range hf.records {
 		switch record.rType {
-		case StartSegmentAddrRecord:
-			// Do nothing
-		case ExtendedSegmentAddrRecord:
-			data, err := hexToInt[uint16](record.ReadData(), false)
+		case ExtendedSegmentAddrRecord, ExtendedLinearAddrRecord:
+			newBase, err := extendedBase(record)
 			if err != nil {
-				return nil, RecordErr
-			}
-			base = uint32(data) * 16
-		case StartLinearAddrRecord:
-			// Do nothing
-		case ExtendedLinearAddrRecord:
-			data, err := hexToInt[uint16](record.ReadData(), false)
-			if err != nil {
-				return nil, RecordErr
+				return nil, err
 			}
-			extendedBase := uint32(data)
-			base = extendedBase << 16
-		case EOFRecord:
-			// Do nothing
+			base = newBase
 		case DataRecord:
 			uLen := uint32(record.length)
 			hLen := uLen * 2
@@ -251,6 +326,296 @@ func (hf *File) accessAt(pos uint32, size int) (*recordView, error) {
 	return nil, AccessOutOfBounds
 }
 
+// InsertRecord inserts the passed record right before the EOF
+// record of the file. EOF records are rejected, since a valid
+// file contains exactly one of them.
+func (hf *File) InsertRecord(record *Record) error {
+	if record.rType == EOFRecord {
+		return MultipleEofErr
+	}
+
+	eofIdx := len(hf.records) - 1
+	hf.records = append(hf.records[:eofIdx+1], hf.records[eofIdx])
+	hf.records[eofIdx] = record
+
+	if record.rType == DataRecord {
+		hf.binSize += record.ByteCount()
+	}
+	return nil
+}
+
+// RecordAddress returns the absolute start address of the idx-th record,
+// taking into account the base address set by the extended segment and
+// extended linear address records that precede it.
+func (hf *File) RecordAddress(idx int) (uint32, error) {
+	if idx < 0 || idx >= len(hf.records) {
+		return 0, RecordOutOfBounds
+	}
+
+	base := uint32(0)
+	for _, record := range hf.records[:idx] {
+		switch record.rType {
+		case ExtendedSegmentAddrRecord, ExtendedLinearAddrRecord:
+			newBase, err := extendedBase(record)
+			if err != nil {
+				return 0, err
+			}
+			base = newBase
+		}
+	}
+	return base + uint32(hf.records[idx].Address()), nil
+}
+
+// BaseAddresses returns the base address transitions established by the
+// extended segment and extended linear address records of the file, as a
+// mapping from the index of each of these records to the base address it sets.
+func (hf *File) BaseAddresses() (map[int]uint32, error) {
+	bases := make(map[int]uint32)
+	for idx, record := range hf.records {
+		switch record.rType {
+		case ExtendedSegmentAddrRecord, ExtendedLinearAddrRecord:
+			base, err := extendedBase(record)
+			if err != nil {
+				return nil, err
+			}
+			bases[idx] = base
+		}
+	}
+	return bases, nil
+}
+
+// AddressRange returns the lowest and the highest absolute byte addresses
+// covered by the data records of the file. Files that do not contain any
+// data byte have no address range, and a NoDataErr is returned for them.
+func (hf *File) AddressRange() (uint32, uint32, error) {
+	minAddr, maxAddr := uint32(0), uint32(0)
+	found := false
+	base := uint32(0)
+
+	for _, record := range hf.records {
+		switch record.rType {
+		case ExtendedSegmentAddrRecord, ExtendedLinearAddrRecord:
+			newBase, err := extendedBase(record)
+			if err != nil {
+				return 0, 0, err
+			}
+			base = newBase
+		case DataRecord:
+			if record.length == 0 {
+				continue
+			}
+
+			start := base + uint32(record.Address())
+			end := start + uint32(record.length) - 1
+			if !found || start < minAddr {
+				minAddr = start
+			}
+			if !found || end > maxAddr {
+				maxAddr = end
+			}
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, 0, NoDataErr
+	}
+	return minAddr, maxAddr, nil
+}
+
+// FillUnused covers the gaps between the data of the file, within its
+// address range, with data records holding the fill byte. The records
+// filling a gap are inserted right after the data record preceding it,
+// together with any extended linear address record needed to reach the
+// gap, followed by a record restoring the previous base address.
+func (hf *File) FillUnused(fill byte) error {
+	if _, _, err := hf.AddressRange(); err != nil {
+		return err
+	}
+
+	type interval struct{ start, end uint32 }
+	var covered []interval
+	base := uint32(0)
+	for _, record := range hf.records {
+		switch record.rType {
+		case ExtendedSegmentAddrRecord, ExtendedLinearAddrRecord:
+			newBase, err := extendedBase(record)
+			if err != nil {
+				return err
+			}
+			base = newBase
+		case DataRecord:
+			if record.length != 0 {
+				start := base + uint32(record.Address())
+				covered = append(covered, interval{start, start + uint32(record.length)})
+			}
+		}
+	}
+
+	sort.Slice(covered, func(i, j int) bool {
+		return covered[i].start < covered[j].start
+	})
+
+	// maps the start of each gap to its end
+	gaps := make(map[uint32]uint32)
+	end := covered[0].end
+	for _, current := range covered[1:] {
+		if current.start > end {
+			gaps[end] = current.start
+		}
+		if current.end > end {
+			end = current.end
+		}
+	}
+
+	records := make([]*Record, 0, len(hf.records))
+	base = 0
+	for _, record := range hf.records {
+		records = append(records, record)
+		switch record.rType {
+		case ExtendedSegmentAddrRecord, ExtendedLinearAddrRecord:
+			base, _ = extendedBase(record)
+		case DataRecord:
+			start := base + uint32(record.Address()) + uint32(record.length)
+			if gapEnd, isGap := gaps[start]; isGap && record.length != 0 {
+				records = append(records, fillRecords(base, start, gapEnd, fill)...)
+				hf.binSize += int(gapEnd - start)
+				delete(gaps, start)
+			}
+		}
+	}
+	hf.records = records
+	return nil
+}
+
+// fillRecords returns the records filling the [start; end) interval with
+// the fill byte, starting with the passed base address in effect.
+func fillRecords(base, start, end uint32, fill byte) []*Record {
+	var records []*Record
+	current := base
+	for addr := start; addr < end; {
+		if addr-current >= segmentSize {
+			current = addr &^ (segmentSize - 1)
+			upper := uint16(current >> 16)
+			records = append(records, newRecord(ExtendedLinearAddrRecord, 0,
+				[]byte{byte(upper >> 8), byte(upper)}))
+		}
+
+		size := uint32(DefaultRecordSize)
+		if remaining := end - addr; remaining < size {
+			size = remaining
+		}
+		if toBoundary := current + segmentSize - addr; toBoundary < size {
+			size = toBoundary
+		}
+
+		data := bytes.Repeat([]byte{fill}, int(size))
+		records = append(records, newRecord(DataRecord, uint16(addr-current), data))
+		addr += size
+	}
+
+	if current != base {
+		if base%segmentSize == 0 {
+			upper := uint16(base >> 16)
+			records = append(records, newRecord(ExtendedLinearAddrRecord, 0,
+				[]byte{byte(upper >> 8), byte(upper)}))
+		} else {
+			segment := uint16(base >> 4)
+			records = append(records, newRecord(ExtendedSegmentAddrRecord, 0,
+				[]byte{byte(segment >> 8), byte(segment)}))
+		}
+	}
+	return records
+}
+
+// FindAll returns the absolute addresses of all the non-overlapping
+// occurrences of pattern within the data of the file. An occurrence
+// can span multiple records, as long as their data is contiguous.
+func (hf *File) FindAll(pattern []byte) ([]uint32, error) {
+	if len(pattern) == 0 {
+		return nil, EmptyPatternErr
+	}
+
+	blocks, err := hf.DataBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []uint32{}
+	for _, block := range blocks {
+		for start := 0; ; {
+			idx := bytes.Index(block.Data[start:], pattern)
+			if idx < 0 {
+				break
+			}
+			addresses = append(addresses, block.Address+uint32(start+idx))
+			start += idx + len(pattern)
+		}
+	}
+	return addresses, nil
+}
+
+// DataBlocks returns the decoded data of the file, in record order, as
+// blocks of contiguous bytes: a new block starts whenever a data record
+// does not begin right where the previous one ends.
+func (hf *File) DataBlocks() ([]DataBlock, error) {
+	var blocks []DataBlock
+	var block []byte
+	blockStart := uint32(0)
+
+	base := uint32(0)
+	for _, record := range hf.records {
+		switch record.rType {
+		case ExtendedSegmentAddrRecord, ExtendedLinearAddrRecord:
+			newBase, err := extendedBase(record)
+			if err != nil {
+				return nil, err
+			}
+			base = newBase
+		case DataRecord:
+			start := base + uint32(record.Address())
+			if start != blockStart+uint32(len(block)) {
+				if len(block) != 0 {
+					blocks = append(blocks, DataBlock{Address: blockStart, Data: block})
+				}
+				block = nil
+				blockStart = start
+			}
+
+			data := make([]byte, record.length)
+			if _, err := hex.Decode(data, record.ReadData()); err != nil {
+				return nil, RecordErr
+			}
+			block = append(block, data...)
+		}
+	}
+
+	if len(block) != 0 {
+		blocks = append(blocks, DataBlock{Address: blockStart, Data: block})
+	}
+	return blocks, nil
+}
+
+// AbsoluteAddress computes the absolute address corresponding to
+// the passed segment and offset, as in extended segment addressing.
+func AbsoluteAddress(segment uint16, offset uint16) uint32 {
+	return uint32(segment)*16 + uint32(offset)
+}
+
+// extendedBase returns the base address set by an extended
+// segment or an extended linear address record.
+func extendedBase(record *Record) (uint32, error) {
+	data, err := hexToInt[uint16](record.ReadData(), false)
+	if err != nil {
+		return 0, RecordErr
+	}
+
+	if record.rType == ExtendedSegmentAddrRecord {
+		return AbsoluteAddress(data, 0), nil
+	}
+	return uint32(data) << 16, nil
+}
+
 // updateChecksum is a helper function used to fix checksums
 // of modified records
 func updateChecksum(record *Record) {
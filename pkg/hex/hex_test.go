@@ -185,6 +185,52 @@ func TestReadAll(t *testing.T) {
 	}
 }
 
+func TestFile_Records(t *testing.T) {
+	test := `:04000000FA00000200
+:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:10C21000FFFFF6F50EFE4B66F2FA0CFEF2F40EFE90
+:00000001FF
+`
+
+	file, err := ReadAll(bytes.NewBufferString(test))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	splitted := strings.Split(test, "\n")
+	tests := []struct {
+		start       int
+		end         int
+		expectedErr error
+		expected    []string
+	}{
+		{1, 3, nil, splitted[1:3]},
+		{0, 5, nil, splitted[:5]},
+		{2, 2, nil, []string{}},
+		{-1, 2, RecordOutOfBounds, nil},
+		{3, 6, RecordOutOfBounds, nil},
+		{3, 2, RecordOutOfBounds, nil},
+	}
+
+	for _, testCase := range tests {
+		records, err := file.Records(testCase.start, testCase.end)
+		if err != testCase.expectedErr {
+			t.Fatalf("expected error %v, got %v", testCase.expectedErr, err)
+		}
+
+		if len(records) != len(testCase.expected) {
+			t.Fatalf("expected %d records, got %d", len(testCase.expected), len(records))
+		}
+
+		for idx, record := range records {
+			if record.AsString() != testCase.expected[idx] {
+				t.Errorf("expected record '%s', got '%s'", testCase.expected[idx], record.AsString())
+			}
+		}
+	}
+}
+
 func TestFile_ReadAt(t *testing.T) {
 	hexFile := `:10000000FFAEAEFF00000000000000000000000096
 :04000000FA00000200
@@ -358,3 +404,458 @@ func TestFile_WriteAt(t *testing.T) {
 		}
 	}
 }
+
+func TestFile_RecordAddress(t *testing.T) {
+	test := `:04000000FA00000200
+:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:10C21000FFFFF6F50EFE4B66F2FA0CFEF2F40EFE90
+:020000040800F2
+:10C22000F04EF05FF06CF07DCA0050C2F086F097DF
+:00000001FF
+`
+	tests := []struct {
+		idx         int
+		expected    uint32
+		expectedErr error
+	}{
+		{0, 0, nil},
+		{2, 0x1C200, nil},
+		{3, 0x1C210, nil},
+		{5, 0x800C220, nil},
+		{-1, 0, RecordOutOfBounds},
+		{7, 0, RecordOutOfBounds},
+	}
+
+	file, err := ReadAll(bytes.NewBufferString(test))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	for _, testCase := range tests {
+		address, err := file.RecordAddress(testCase.idx)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Errorf("expected err %v, got %v", testCase.expectedErr, err)
+		}
+
+		if address != testCase.expected {
+			t.Errorf("expected address 0x%x for record %d, got 0x%x", testCase.expected, testCase.idx, address)
+		}
+	}
+
+	if AbsoluteAddress(0x1000, 0xC200) != 0x1C200 {
+		t.Errorf("expected 0x1C200, got 0x%x", AbsoluteAddress(0x1000, 0xC200))
+	}
+}
+
+func TestFile_BaseAddresses(t *testing.T) {
+	test := `:04000000FA00000200
+:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:020000040800F2
+:10C22000F04EF05FF06CF07DCA0050C2F086F097DF
+:00000001FF
+`
+	expected := map[int]uint32{1: 0x10000, 3: 0x8000000}
+
+	file, err := ReadAll(bytes.NewBufferString(test))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	bases, err := file.BaseAddresses()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bases) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, bases)
+	}
+
+	for idx, base := range expected {
+		if bases[idx] != base {
+			t.Errorf("expected base 0x%x for record %d, got 0x%x", base, idx, bases[idx])
+		}
+	}
+}
+
+func TestFile_AddressRange(t *testing.T) {
+	tests := []struct {
+		file        string
+		min         uint32
+		max         uint32
+		expectedErr error
+	}{
+		{
+			":020000021000EC\n:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93\n" +
+				":020000022000DC\n:04000000FA00000200\n:00000001FF\n",
+			0x1C200, 0x20003, nil,
+		},
+		{
+			":04000000FA00000200\n:020000021000EC\n" +
+				":10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93\n:00000001FF\n",
+			0x0, 0x1C20F, nil,
+		},
+		{":00000001FF\n", 0, 0, NoDataErr},
+		{":020000040800F2\n:00000001FF\n", 0, 0, NoDataErr},
+	}
+
+	for _, testCase := range tests {
+		file, err := ReadAll(bytes.NewBufferString(testCase.file))
+		if err != nil {
+			t.Fatalf("Expected valid hex file got %s", err)
+		}
+
+		minAddr, maxAddr, err := file.AddressRange()
+		if err != testCase.expectedErr {
+			t.Fatalf("expected error %v, got %v", testCase.expectedErr, err)
+		}
+
+		if minAddr != testCase.min || maxAddr != testCase.max {
+			t.Errorf("expected range [0x%x, 0x%x], got [0x%x, 0x%x]",
+				testCase.min, testCase.max, minAddr, maxAddr)
+		}
+	}
+}
+
+func TestFile_FindAll(t *testing.T) {
+	test := `:020000021000EC
+:04C20000DEADBEEF02
+:04C20400000000DE58
+:04C20800ADBEEF00D8
+:020000022000DC
+:04000000DEADBEEFC4
+:00000001FF
+`
+	tests := []struct {
+		pattern     []byte
+		expectedErr error
+		expected    []uint32
+	}{
+		{[]byte{0xDE, 0xAD, 0xBE, 0xEF}, nil, []uint32{0x1C200, 0x1C207, 0x20000}},
+		{[]byte{0x00, 0x00}, nil, []uint32{0x1C204}},
+		{[]byte{0xEF, 0xDE}, nil, []uint32{}},
+		{[]byte{}, EmptyPatternErr, nil},
+	}
+
+	file, err := ReadAll(bytes.NewBufferString(test))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	for _, testCase := range tests {
+		addresses, err := file.FindAll(testCase.pattern)
+		if err != testCase.expectedErr {
+			t.Fatalf("expected error %v, got %v", testCase.expectedErr, err)
+		}
+
+		if len(addresses) != len(testCase.expected) {
+			t.Fatalf("expected %v, got %v", testCase.expected, addresses)
+		}
+
+		for idx, address := range testCase.expected {
+			if addresses[idx] != address {
+				t.Errorf("expected %v, got %v", testCase.expected, addresses)
+				break
+			}
+		}
+	}
+}
+
+func TestFile_FillUnused(t *testing.T) {
+	test := `:020000021000EC
+:04C20000DEADBEEF02
+:04C20800ADBEEF00D8
+:020000022000DC
+:04000000DEADBEEFC4
+:00000001FF
+`
+	file, err := ReadAll(bytes.NewBufferString(test))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	if err := file.FillUnused(0xFF); err != nil {
+		t.Fatalf("unexpected error filling the unused regions: %s", err)
+	}
+
+	expectedSize := 0x20004 - 0x1C200
+	if file.BinarySize() != expectedSize {
+		t.Errorf("expected binary size %d, got %d", expectedSize, file.BinarySize())
+	}
+
+	minAddr, maxAddr, err := file.AddressRange()
+	if err != nil || minAddr != 0x1C200 || maxAddr != 0x20003 {
+		t.Errorf("expected range [0x1c200, 0x20003], got [0x%x, 0x%x] (%v)", minAddr, maxAddr, err)
+	}
+
+	tests := []struct {
+		pos      uint32
+		size     int
+		expected []byte
+	}{
+		{0x1C202, 8, []byte{0xBE, 0xEF, 0xFF, 0xFF, 0xFF, 0xFF, 0xAD, 0xBE}},
+		{0x1C20C, 4, []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+		{0x1FFFC, 4, []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+		{0x20000, 4, []byte{0xDE, 0xAD, 0xBE, 0xEF}},
+	}
+
+	for _, testCase := range tests {
+		data, err := file.ReadAt(testCase.pos, testCase.size)
+		if err != nil {
+			t.Fatalf("unexpected error reading at 0x%x: %s", testCase.pos, err)
+		}
+
+		if !bytes.Equal(data, testCase.expected) {
+			t.Errorf("expected %v at 0x%x, got %v", testCase.expected, testCase.pos, data)
+		}
+	}
+
+	crossing := `:020000040001F9
+:04FFFC00DEADBEEFC9
+:04000000CAFEBABEBC
+:020000040002F8
+:04000800DEADBEEFBC
+:00000001FF
+`
+	file, err = ReadAll(bytes.NewBufferString(crossing))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	if err := file.FillUnused(0x00); err != nil {
+		t.Fatalf("unexpected error filling the unused regions: %s", err)
+	}
+
+	data, err := file.ReadAt(0x20000, 8)
+	if err != nil || !bytes.Equal(data, make([]byte, 8)) {
+		t.Errorf("expected the gap to be filled, got %v (%v)", data, err)
+	}
+
+	data, err = file.ReadAt(0x20008, 4)
+	if err != nil || !bytes.Equal(data, []byte{0xDE, 0xAD, 0xBE, 0xEF}) {
+		t.Errorf("expected the data after the gap to be unchanged, got %v (%v)", data, err)
+	}
+
+	data, err = file.ReadAt(0x10000, 4)
+	if err != nil || !bytes.Equal(data, []byte{0xCA, 0xFE, 0xBA, 0xBE}) {
+		t.Errorf("expected the base address to be restored, got %v (%v)", data, err)
+	}
+
+	empty, err := ReadAll(bytes.NewBufferString(":00000001FF\n"))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	if err := empty.FillUnused(0xFF); err != NoDataErr {
+		t.Errorf("expected error %v, got %v", NoDataErr, err)
+	}
+}
+
+func TestFile_DataBlocks(t *testing.T) {
+	test := `:020000021000EC
+:04C20000DEADBEEF02
+:04C20400000000DE58
+:04C20800ADBEEF00D8
+:020000022000DC
+:04000000DEADBEEFC4
+:00000001FF
+`
+	expected := []DataBlock{
+		{0x1C200, []byte{0xDE, 0xAD, 0xBE, 0xEF, 0x00, 0x00, 0x00, 0xDE, 0xAD, 0xBE, 0xEF, 0x00}},
+		{0x20000, []byte{0xDE, 0xAD, 0xBE, 0xEF}},
+	}
+
+	file, err := ReadAll(bytes.NewBufferString(test))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	blocks, err := file.DataBlocks()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(blocks) != len(expected) {
+		t.Fatalf("expected %d blocks, got %d", len(expected), len(blocks))
+	}
+
+	for idx, block := range blocks {
+		if block.Address != expected[idx].Address || !bytes.Equal(block.Data, expected[idx].Data) {
+			t.Errorf("expected block %v, got %v", expected[idx], block)
+		}
+	}
+}
+
+func TestFile_InsertRecord(t *testing.T) {
+	test := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	tests := []struct {
+		record      string
+		expectedErr error
+		size        int
+		binSize     int
+	}{
+		{":10C21000FFFFF6F50EFE4B66F2FA0CFEF2F40EFE90\n", nil, 4, 32},
+		{":020000040800F2\n", nil, 5, 32},
+		{":00000001FF\n", MultipleEofErr, 5, 32},
+	}
+
+	file, err := ReadAll(bytes.NewBufferString(test))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	for _, testCase := range tests {
+		record, err := ParseRecord(bytes.NewBufferString(testCase.record))
+		if err != nil {
+			t.Fatalf("Expected valid record got %s", err)
+		}
+
+		err = file.InsertRecord(record)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Errorf("expected err %v, got %v", testCase.expectedErr, err)
+		}
+
+		if file.Size() != testCase.size || file.BinarySize() != testCase.binSize {
+			t.Errorf("expected size %d and binary size %d, got %d and %d",
+				testCase.size, testCase.binSize, file.Size(), file.BinarySize())
+		}
+
+		last, _ := file.Record(file.Size() - 1)
+		if last.Type() != EOFRecord {
+			t.Errorf("expected the EOF record to be the last one, got %s", last.AsString())
+		}
+	}
+
+	inserted, _ := file.Record(2)
+	if inserted.AsString() != ":10C21000FFFFF6F50EFE4B66F2FA0CFEF2F40EFE90" {
+		t.Errorf("unexpected inserted record %s", inserted.AsString())
+	}
+}
+
+func TestFile_Clone(t *testing.T) {
+	test := `:020000021000EC
+:10C20000E0A5E6F6FDFFE0AEE00FE6FCFDFFE6FD93
+:00000001FF
+`
+	file, err := ReadAll(bytes.NewBufferString(test))
+	if err != nil {
+		t.Fatalf("Expected valid hex file got %s", err)
+	}
+
+	clone := file.Clone()
+	if err := clone.WriteAt(0x1C200, []byte{0xde, 0xad}); err != nil {
+		t.Fatalf("unexpected error writing the clone: %v", err)
+	}
+
+	record, err := ParseRecord(bytes.NewBufferString(":020000040800F2\n"))
+	if err != nil {
+		t.Fatalf("Expected valid record got %s", err)
+	}
+
+	if err := clone.InsertRecord(record); err != nil {
+		t.Fatalf("unexpected error inserting into the clone: %v", err)
+	}
+
+	original, _ := file.ReadAt(0x1C200, 2)
+	if !bytes.Equal(original, []byte{0xe0, 0xa5}) || file.Size() != 3 {
+		t.Errorf("expected the original file to be unchanged, got %v with %d records",
+			original, file.Size())
+	}
+
+	cloned, _ := clone.ReadAt(0x1C200, 2)
+	if !bytes.Equal(cloned, []byte{0xde, 0xad}) || clone.Size() != 4 {
+		t.Errorf("expected the clone to be modified, got %v with %d records",
+			cloned, clone.Size())
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	data := make([]byte, 0x10000+40)
+	for idx := range data {
+		data[idx] = byte(idx)
+	}
+
+	tests := []struct {
+		data        []byte
+		recordSize  int
+		expected    []string
+		expectedErr error
+	}{
+		{data[:4], 16, []string{":0400000000010203F6", ":00000001FF"}, nil},
+		{data[:20], 16, []string{
+			":10000000000102030405060708090A0B0C0D0E0F78",
+			":0400100010111213A6",
+			":00000001FF",
+		}, nil},
+		{data[:3], 2, []string{":020000000001FD", ":0100020002FB", ":00000001FF"}, nil},
+		{nil, 16, []string{":00000001FF"}, nil},
+		{data[:4], 0, nil, InvalidRecordSize},
+		{data[:4], 256, nil, InvalidRecordSize},
+	}
+
+	for _, testCase := range tests {
+		file, err := FromBytes(testCase.data, testCase.recordSize)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Errorf("expected err %v, got %v", testCase.expectedErr, err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		var records []string
+		for record := range file.Iterator() {
+			records = append(records, record.AsString())
+		}
+
+		if !reflect.DeepEqual(records, testCase.expected) {
+			t.Errorf("expected records %v, got %v", testCase.expected, records)
+		}
+	}
+
+	for _, recordSize := range []int{16, 32} {
+		file, err := FromBytes(data, recordSize)
+		if err != nil {
+			t.Fatalf("expected valid hex file, got %s", err)
+		}
+
+		if file.BinarySize() != len(data) {
+			t.Errorf("expected binary size %d, got %d", len(data), file.BinarySize())
+		}
+
+		// every data record but the last one is full, since the
+		// record size divides the 64KiB address space
+		var encoded bytes.Buffer
+		var dataRecords []*Record
+		for record := range file.Iterator() {
+			if record.Type() == DataRecord {
+				dataRecords = append(dataRecords, record)
+			}
+			encoded.Write(record.AsBytes())
+		}
+
+		for _, record := range dataRecords[:len(dataRecords)-1] {
+			if record.ByteCount() != recordSize || len(record.AsString()) != recordSize*2+11 {
+				t.Errorf("expected a %d bytes record, got %s", recordSize, record.AsString())
+			}
+		}
+
+		// re-parsing the file validates every checksum
+		parsed, err := ReadAll(&encoded)
+		if err != nil {
+			t.Fatalf("expected valid hex file, got %s", err)
+		}
+
+		for _, pos := range []int{0xFFF0, 0x10000} {
+			readData, err := parsed.ReadAt(uint32(pos), 16)
+			if err != nil || !bytes.Equal(readData, data[pos:pos+16]) {
+				t.Errorf("expected data at 0x%x to be readable, got %v (%v)", pos, readData, err)
+			}
+		}
+	}
+}
@@ -89,6 +89,30 @@ type Record struct {
 	data   []byte
 }
 
+// newRecord builds a valid record of the passed type, computing
+// its byte count and checksum from the passed address and data.
+func newRecord(rType RecordType, address uint16, data []byte) *Record {
+	raw := make([]byte, 0, len(data)+5)
+	raw = append(raw, byte(len(data)), byte(address>>8), byte(address), byte(rType))
+	raw = append(raw, data...)
+
+	sum := byte(0)
+	for _, b := range raw {
+		sum += b
+	}
+	raw = append(raw, ^sum+1)
+
+	encoded := make([]byte, startCodeLen+hex.EncodedLen(len(raw)))
+	encoded[0] = startCode
+	hex.Encode(encoded[startCodeLen:], raw)
+
+	return &Record{
+		length: len(data),
+		rType:  rType,
+		data:   []byte(strings.ToUpper(string(encoded))),
+	}
+}
+
 // AsString returns a string representation of the record
 func (r *Record) AsString() string {
 	if r.data == nil {
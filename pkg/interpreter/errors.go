@@ -0,0 +1,60 @@
+package interpreter
+
+import (
+	"context"
+	"io"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+// ParseErrorKind is the kind reported for errors found while parsing a script
+const ParseErrorKind = "Parse Error"
+
+// ErrorInfo is a structured representation of an error produced while
+// parsing or executing a script, suitable to be serialized as JSON.
+// The Line and Column fields are zero if the information is not available,
+// as it is the case for the column of the errors raised during the execution.
+type ErrorInfo struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// ExecStructured reads a script from the passed reader and executes it,
+// like Exec does. If the parsing or the execution fails, it returns
+// an array of ErrorInfo describing the errors, or nil otherwise.
+func ExecStructured(r io.Reader, args ...string) []ErrorInfo {
+	program, errs, _ := parse(r)
+	if errs != nil {
+		infos := make([]ErrorInfo, len(errs))
+		for idx, err := range errs {
+			infos[idx] = ErrorInfo{
+				Kind:    ParseErrorKind,
+				Message: err.Message,
+				Line:    err.Line,
+				Column:  err.Column,
+			}
+		}
+		return infos
+	}
+
+	switch evaluatedErr := evaluate(context.Background(), nil, program, args...).(type) {
+	case *object.RuntimeError:
+		return []ErrorInfo{newErrorInfo(string(evaluatedErr.Kind), evaluatedErr.Message, evaluatedErr.Line)}
+	case *object.Error:
+		return []ErrorInfo{newErrorInfo(string(object.ErrorObj), evaluatedErr.Message, evaluatedErr.Line)}
+	default:
+		return nil
+	}
+}
+
+// newErrorInfo builds the ErrorInfo of an error raised during the
+// execution, on the passed line, if known.
+func newErrorInfo(kind, msg string, line int) ErrorInfo {
+	info := ErrorInfo{Kind: kind, Message: msg}
+	if line > 0 {
+		info.Line = line
+	}
+	return info
+}
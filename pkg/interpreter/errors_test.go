@@ -0,0 +1,58 @@
+package interpreter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+func TestExecStructured(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []ErrorInfo
+	}{
+		{"var a = 1\nvar b = a + 1", nil},
+		{"var a = 1\n\nvar b = 1 / 0", []ErrorInfo{
+			{Kind: string(object.ErrorObj), Message: "division by zero", Line: 3},
+		}},
+		{"var a = 1\nfrom_hex(\"jkjk\")", []ErrorInfo{
+			{Kind: string(object.TypeError), Message: "'from_hex' - invalid hex digit jk", Line: 2},
+		}},
+		{"var m = {}\n\n\nm[\"key\"]", []ErrorInfo{
+			{Kind: string(object.KeyError), Message: "key", Line: 4},
+		}},
+		{"var = 12", []ErrorInfo{
+			{Kind: ParseErrorKind, Message: "expected token of type \"IDENT\", got \"=\"", Line: 1, Column: 5},
+			{Kind: ParseErrorKind, Message: "cannot parse: prefix operator \"=\"", Line: 1, Column: 5},
+		}},
+	}
+
+	for _, testCase := range tests {
+		infos := ExecStructured(strings.NewReader(testCase.input))
+		if len(infos) != len(testCase.expected) {
+			t.Fatalf("%q: expected %d errors, got %d (%v)", testCase.input,
+				len(testCase.expected), len(infos), infos)
+		}
+
+		for idx, info := range infos {
+			if info != testCase.expected[idx] {
+				t.Errorf("%q: expected %+v, got %+v", testCase.input, testCase.expected[idx], info)
+			}
+		}
+	}
+}
+
+func TestErrorInfoJSON(t *testing.T) {
+	infos := ExecStructured(strings.NewReader("var a = 1\nerror(\"custom\")"))
+	data, err := json.Marshal(infos)
+	if err != nil {
+		t.Fatalf("unexpected error serializing the error info: %v", err)
+	}
+
+	expected := `[{"kind":"Runtime Error","message":"custom","line":2,"column":0}]`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, string(data))
+	}
+}
@@ -5,10 +5,12 @@ package interpreter
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"runtime/debug"
 
+	"github.com/Abathargh/harlock/internal/ast"
 	"github.com/Abathargh/harlock/internal/object"
 
 	"github.com/Abathargh/harlock/internal/evaluator"
@@ -26,6 +28,7 @@ func init() {
 			Version = info.Main.Version
 		}
 	}
+	evaluator.Version = Version
 }
 
 // Exec reads a script from the passed reader, executes it and
@@ -33,31 +36,98 @@ func init() {
 // phase fails, it returns an array of string containing the parsing
 // errors, or nil otherwise.
 func Exec(r io.Reader, stderr io.Writer, args ...string) []string {
-	env := object.NewEnvironment()
+	return ExecWithContext(context.Background(), r, stderr, args...)
+}
+
+// ExecWithContext works like Exec, but interrupts the execution of the
+// script once the passed context is done, e.g. when its deadline expires,
+// returning the corresponding error.
+func ExecWithContext(ctx context.Context, r io.Reader, stderr io.Writer, args ...string) []string {
+	return run(ctx, nil, r, args...)
+}
+
+// ExecWithTrace works like Exec, but also logs each top-level
+// statement of the script to the passed writer as it gets
+// evaluated, together with the value it evaluates to.
+func ExecWithTrace(r io.Reader, stderr io.Writer, args ...string) []string {
+	return run(context.Background(), stderr, r, args...)
+}
+
+// Check reads a script from the passed reader and parses it without
+// executing it. It returns an array of string containing the parsing
+// errors, or nil if the script is syntactically valid.
+func Check(r io.Reader) []string {
+	_, errs, _ := parse(r)
+	return messages(errs)
+}
+
+func run(ctx context.Context, trace io.Writer, r io.Reader, args ...string) []string {
+	program, errs, _ := parse(r)
+	if errs != nil {
+		return messages(errs)
+	}
+
+	evaluatedProg := evaluate(ctx, trace, program, args...)
+	if isError(evaluatedProg) {
+		return dumpToSlice(evaluatedProg)
+	}
+	return nil
+}
+
+// parse reads a script from the passed reader and parses it, returning
+// the errors found while parsing, if any, together with their position,
+// and the number of comments the lexer skipped.
+func parse(r io.Reader) (*ast.Program, []parser.Error, int) {
 	l := lexer.NewLexer(bufio.NewReader(r))
 	p := parser.NewParser(l)
 	program := p.ParseProgram()
-	if len(p.Errors()) != 0 {
-		return p.Errors()
+	if len(p.DetailedErrors()) != 0 {
+		return nil, p.DetailedErrors(), l.Comments()
+	}
+	return program, nil, l.Comments()
+}
+
+// messages returns the parsing errors as an array of string, or
+// nil if there are none.
+func messages(errs []parser.Error) []string {
+	if len(errs) == 0 {
+		return nil
 	}
 
+	msgs := make([]string, len(errs))
+	for idx, err := range errs {
+		msgs[idx] = err.Error()
+	}
+	return msgs
+}
+
+func evaluate(ctx context.Context, trace io.Writer, program *ast.Program, args ...string) object.Object {
+	env := newEnvironment(ctx, args...)
+	env.SetTrace(trace)
+	return evaluator.Eval(program, env)
+}
+
+func newEnvironment(ctx context.Context, args ...string) *object.Environment {
+	env := object.NewEnvironmentWithContext(ctx)
+
 	// The interpreter inherits the args from the process call
 	argsArray := &object.Array{Elements: make([]object.Object, len(args))}
 	for idx, arg := range args {
 		argsArray.Elements[idx] = &object.String{Value: arg}
 	}
 	env.Set("args", argsArray)
+	return env
+}
 
-	evaluatedProg := evaluator.Eval(program, env)
-	if evaluatedProg != nil {
-		switch evaluatedProg.(type) {
-		case *object.RuntimeError:
-			return dumpToSlice(evaluatedProg)
-		case *object.Error:
-			return dumpToSlice(evaluatedProg)
-		}
+func isError(evaluatedProg object.Object) bool {
+	switch evaluatedProg.(type) {
+	case *object.RuntimeError:
+		return true
+	case *object.Error:
+		return true
+	default:
+		return false
 	}
-	return nil
 }
 
 func dumpToSlice(evaluatedProg object.Object) []string {
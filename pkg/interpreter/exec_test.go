@@ -0,0 +1,88 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedErr bool
+	}{
+		{"var a = 12\nprint(a)", false},
+		{"var a = 1 / 0", false},
+		{`error("not raised since the script is not executed")`, false},
+		{"var = 12", true},
+		{"var a = fun(x) { ret x", true},
+		{"var a = {1: 2", true},
+	}
+
+	for _, testCase := range tests {
+		errs := Check(strings.NewReader(testCase.input))
+		if testCase.expectedErr != (errs != nil) {
+			t.Errorf("%q: expected errors = %t, got %v", testCase.input, testCase.expectedErr, errs)
+		}
+	}
+}
+
+func TestExecWithContext(t *testing.T) {
+	slow := "var fib = fun(n) {\n if n < 2 { ret n }\n ret fib(n - 1) + fib(n - 2)\n}\nfib(60)"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	errs := ExecWithContext(ctx, strings.NewReader(slow), io.Discard)
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the execution to be interrupted, took %s", time.Since(start))
+	}
+
+	if len(errs) == 0 || !strings.Contains(errs[0], context.DeadlineExceeded.Error()) {
+		t.Errorf("expected a timeout error, got %v", errs)
+	}
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if errs := ExecWithContext(cancelled, strings.NewReader("print(1)"), io.Discard); len(errs) == 0 {
+		t.Errorf("expected an error for an already cancelled context")
+	}
+
+	fast, cancelFast := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelFast()
+	if errs := ExecWithContext(fast, strings.NewReader("var a = [1, 2].map(hex)"), io.Discard); errs != nil {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestExecWithTrace(t *testing.T) {
+	var trace bytes.Buffer
+	errs := ExecWithTrace(strings.NewReader("var a = 1 + 2\na * 2"), &trace)
+	if errs != nil {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	expected := "trace: var a = (1+2) => null\ntrace: (a*2) => 6\n"
+	if trace.String() != expected {
+		t.Errorf("expected trace %q, got %q", expected, trace.String())
+	}
+
+	trace.Reset()
+	errs = ExecWithTrace(strings.NewReader("var b = 1\nb / 0\nvar c = 2"), &trace)
+	if errs == nil {
+		t.Fatalf("expected a runtime error")
+	}
+
+	if lines := strings.Split(strings.TrimSpace(trace.String()), "\n"); len(lines) != 2 {
+		t.Errorf("expected the trace to stop at the failing statement, got %q", trace.String())
+	}
+
+	var out bytes.Buffer
+	if errs := Exec(strings.NewReader("var d = 1"), &out); errs != nil || out.Len() != 0 {
+		t.Errorf("expected no trace by default, got %q (%v)", out.String(), errs)
+	}
+}
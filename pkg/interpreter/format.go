@@ -0,0 +1,50 @@
+package interpreter
+
+import (
+	"io"
+
+	"github.com/Abathargh/harlock/internal/ast"
+)
+
+// CommentsErr is returned by FormatLossless for scripts containing
+// comments, which the canonical form does not preserve.
+const CommentsErr = "the script contains comments, which formatting would drop"
+
+// Format reads a script from the passed reader and writes it to the
+// passed writer in its canonical form, one statement per line, as
+// emitted by the parsed syntax tree. Comments and blank lines are not
+// preserved. If the parsing phase fails, it returns an array of string
+// containing the parsing errors and nothing is written.
+func Format(r io.Reader, w io.Writer) []string {
+	program, errs, _ := parse(r)
+	if errs != nil {
+		return messages(errs)
+	}
+
+	writeFormatted(program, w)
+	return nil
+}
+
+// FormatLossless works like Format, but refuses to format scripts
+// containing comments, returning CommentsErr without writing anything,
+// so that its output can safely replace the original script.
+func FormatLossless(r io.Reader, w io.Writer) []string {
+	program, errs, comments := parse(r)
+	if errs != nil {
+		return messages(errs)
+	}
+
+	if comments != 0 {
+		return []string{CommentsErr}
+	}
+
+	writeFormatted(program, w)
+	return nil
+}
+
+func writeFormatted(program *ast.Program, w io.Writer) {
+	for _, statement := range program.Statements {
+		_, _ = io.WriteString(w, statement.String())
+		_, _ = io.WriteString(w, "\n")
+	}
+}
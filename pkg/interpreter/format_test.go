@@ -0,0 +1,76 @@
+package interpreter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"var   a=1+2*3", "var a = (1+(2*3))\n"},
+		{"var s = 'it\"s'\n\n\nprint(s)", "var s = \"it\\x22s\"\nprint(s)\n"},
+		{"var m = {\"b\": 2, \"a\": 1}\n// comment\nm.set(\"c\",\t3)", "var m = {\"a\": 1, \"b\": 2}\nm.set(\"c\", 3)\n"},
+		{"var x = try open(args[1], \"hex\")\nx.read_at(0, 4)[1:3]", "var x = try open(args[1], \"hex\")\nx.read_at(0, 4)[1:3]\n"},
+		{"-a + !b ?? [1,2 , ~3]", "(((-a)+(!b))??[1, 2, (~3)])\n"},
+		{"var f = fun(x) { if x { ret 1 } else { ret } }", "var f = fun(x) {\n\tif x {\n\t\tret 1\n\t} else {\n\t\tret\n\t}\n}\n"},
+	}
+
+	for _, testCase := range tests {
+		var out bytes.Buffer
+		if errs := Format(strings.NewReader(testCase.input), &out); errs != nil {
+			t.Fatalf("%q: unexpected errors %v", testCase.input, errs)
+		}
+
+		if out.String() != testCase.expected {
+			t.Errorf("%q: expected %q, got %q", testCase.input, testCase.expected, out.String())
+		}
+
+		original, _, _ := parse(strings.NewReader(testCase.input))
+		reparsed, errs, _ := parse(strings.NewReader(out.String()))
+		if errs != nil {
+			t.Fatalf("%q: cannot parse the formatted script: %v", testCase.input, errs)
+		}
+
+		if original.String() != reparsed.String() {
+			t.Errorf("%q: expected the formatted script to parse to %q, got %q",
+				testCase.input, original.String(), reparsed.String())
+		}
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	var out bytes.Buffer
+	if errs := Format(strings.NewReader("var = 12"), &out); errs == nil {
+		t.Errorf("expected a parsing error")
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("expected no output for an invalid script, got %q", out.String())
+	}
+}
+
+func TestFormatLossless(t *testing.T) {
+	commented := "// reads the header\nvar h = open(args[1], \"hex\") // the input\nh.read_at(0, 4)"
+	var out bytes.Buffer
+	errs := FormatLossless(strings.NewReader(commented), &out)
+	if len(errs) != 1 || errs[0] != CommentsErr {
+		t.Errorf("expected a %q error, got %v", CommentsErr, errs)
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %q", out.String())
+	}
+
+	out.Reset()
+	if errs := FormatLossless(strings.NewReader("var   url = \"http://host\""), &out); errs != nil {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+
+	if expected := "var url = \"http://host\"\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
@@ -0,0 +1,63 @@
+package interpreter
+
+import (
+	"context"
+	"io"
+
+	"github.com/Abathargh/harlock/internal/evaluator"
+	"github.com/Abathargh/harlock/internal/object"
+)
+
+// Session executes scripts sharing the same global environment, so that
+// the names defined by a script are visible to the ones executed after it.
+type Session struct {
+	env *object.Environment
+}
+
+// Snapshot is an opaque token recording the names defined in a
+// session at the time it was taken.
+type Snapshot struct {
+	snapshot object.Snapshot
+}
+
+// NewSession returns a new session, whose scripts get the passed
+// arguments through the args variable.
+func NewSession(args ...string) *Session {
+	return &Session{env: newEnvironment(context.Background(), args...)}
+}
+
+// Exec reads a script from the passed reader and executes it within the
+// session. Errors are returned in the same way as the package-level Exec does.
+func (session *Session) Exec(r io.Reader, stderr io.Writer) []string {
+	program, errs, _ := parse(r)
+	if errs != nil {
+		return messages(errs)
+	}
+
+	evaluatedProg := evaluator.Eval(program, session.env)
+	if isError(evaluatedProg) {
+		return dumpToSlice(evaluatedProg)
+	}
+	return nil
+}
+
+// ExecSandboxed works like Exec, but reverts any name defined or re-defined
+// by the script once it is done, whether it succeeded or not.
+func (session *Session) ExecSandboxed(r io.Reader, stderr io.Writer) []string {
+	snapshot := session.Snapshot()
+	defer session.Restore(snapshot)
+	return session.Exec(r, stderr)
+}
+
+// Snapshot records the names currently defined in the session, so that any
+// later definition or re-definition can be reverted through Restore.
+// Only the bindings are recorded: objects mutated in place, such as maps
+// modified through their methods, are not reverted.
+func (session *Session) Snapshot() Snapshot {
+	return Snapshot{snapshot: session.env.Snapshot()}
+}
+
+// Restore reverts the names defined in the session to the passed snapshot.
+func (session *Session) Restore(snapshot Snapshot) {
+	session.env.Restore(snapshot.snapshot)
+}
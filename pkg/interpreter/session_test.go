@@ -0,0 +1,64 @@
+package interpreter
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	session := NewSession("script.hlk")
+	if errs := session.Exec(strings.NewReader("var a = 1"), io.Discard); errs != nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if errs := session.Exec(strings.NewReader("assert_eq(a, 1)\nassert_eq(args[0], \"script.hlk\")"), io.Discard); errs != nil {
+		t.Errorf("expected the names to be shared across executions, got %v", errs)
+	}
+
+	if errs := session.Exec(strings.NewReader("var = 1"), io.Discard); errs == nil {
+		t.Errorf("expected parsing errors")
+	}
+
+	if errs := session.Exec(strings.NewReader("1 / 0"), io.Discard); errs == nil {
+		t.Errorf("expected a runtime error")
+	}
+}
+
+func TestSessionSnapshotRestore(t *testing.T) {
+	session := NewSession()
+	_ = session.Exec(strings.NewReader("var a = 1"), io.Discard)
+
+	snapshot := session.Snapshot()
+	_ = session.Exec(strings.NewReader("var a = 2\nvar b = 3"), io.Discard)
+	session.Restore(snapshot)
+
+	if errs := session.Exec(strings.NewReader("assert_eq(a, 1)"), io.Discard); errs != nil {
+		t.Errorf("expected a to be restored, got %v", errs)
+	}
+
+	if errs := session.Exec(strings.NewReader("b"), io.Discard); errs == nil {
+		t.Errorf("expected b to be removed by the restore")
+	}
+}
+
+func TestSessionExecSandboxed(t *testing.T) {
+	tests := []string{
+		"var a = 2\nvar b = 3",
+		"var a = 2\nvar b = 3\n1 / 0",
+	}
+
+	for _, input := range tests {
+		session := NewSession()
+		_ = session.Exec(strings.NewReader("var a = 1"), io.Discard)
+		_ = session.ExecSandboxed(strings.NewReader(input), io.Discard)
+
+		if errs := session.Exec(strings.NewReader("assert_eq(a, 1)"), io.Discard); errs != nil {
+			t.Errorf("%q: expected a to be restored, got %v", input, errs)
+		}
+
+		if errs := session.Exec(strings.NewReader("b"), io.Discard); errs == nil {
+			t.Errorf("%q: expected b to be removed after the sandboxed execution", input)
+		}
+	}
+}